@@ -146,7 +146,8 @@ func (m *AisCloudProvider) GetInfo(clusterConf cmn.CloudConfAIS) (cia cmn.CloudI
 			info.Alias = fmt.Sprintf("%v", aliases)
 		}
 		// online?
-		if smap, err := api.GetClusterMap(api.BaseParams{Client: client, URL: remAis.url}); err == nil {
+		bp := api.BaseParams{Client: client, URL: remAis.url, Token: remAis.bp.Token}
+		if smap, err := api.GetClusterMap(bp); err == nil {
 			if smap.UUID != uuid {
 				glog.Errorf("%s: unexpected (or changed) uuid %q", remAis, smap.UUID)
 				continue
@@ -183,6 +184,7 @@ func (r *remAisClust) init(alias string, confURLs []string, cfg *cmn.Config) (of
 	var (
 		url           string
 		remSmap, smap *cluster.Smap
+		token         = cfg.Auth.RemoteTokens[alias]
 		httpClient    = cmn.NewClient(cmn.TransportArgs{Timeout: cfg.Client.Timeout})
 		httpsClient   = cmn.NewClient(cmn.TransportArgs{
 			Timeout:    cfg.Client.Timeout,
@@ -195,7 +197,7 @@ func (r *remAisClust) init(alias string, confURLs []string, cfg *cmn.Config) (of
 		if cmn.IsHTTPS(u) {
 			client = httpsClient
 		}
-		if smap, err = api.GetClusterMap(api.BaseParams{Client: client, URL: u}); err != nil {
+		if smap, err = api.GetClusterMap(api.BaseParams{Client: client, URL: u, Token: token}); err != nil {
 			glog.Warningf("%s: failing to reach %q via %s: %v", aisCloudPrefix, alias, u, err)
 			continue
 		}
@@ -220,9 +222,9 @@ func (r *remAisClust) init(alias string, confURLs []string, cfg *cmn.Config) (of
 	}
 	r.smap, r.url = remSmap, url
 	if cmn.IsHTTPS(url) {
-		r.bp = api.BaseParams{Client: httpsClient, URL: url}
+		r.bp = api.BaseParams{Client: httpsClient, URL: url, Token: token}
 	} else {
-		r.bp = api.BaseParams{Client: httpClient, URL: url}
+		r.bp = api.BaseParams{Client: httpClient, URL: url, Token: token}
 	}
 	r.uuid = remSmap.UUID
 	return
@@ -455,16 +457,17 @@ func (m *AisCloudProvider) PutObj(ctx context.Context, r io.Reader, lom *cluster
 	if err != nil {
 		return "", errCode, err
 	}
-	fh, ok := r.(*cmn.FileHandle) // `PutObject` closes file handle.
-	cmn.Assert(ok)                // HTTP redirect requires Open().
+	fh, ok := r.(cmn.ReadOpenCloser) // `PutObject` closes file handle.
+	cmn.Assert(ok)                   // HTTP redirect requires Open().
+	cksum := lom.ContentCksum()      // `fh` reads decoded content
 	err = m.try(remoteBck, func(bck cmn.Bck) error {
 		args := api.PutObjectArgs{
 			BaseParams: aisCluster.bp,
 			Bck:        bck,
 			Object:     lom.ObjName,
-			Cksum:      lom.Cksum(),
+			Cksum:      cksum,
 			Reader:     fh,
-			Size:       uint64(lom.Size()),
+			Size:       uint64(lom.ContentSize()),
 		}
 		return api.PutObject(args)
 	})
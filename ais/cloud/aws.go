@@ -47,24 +47,60 @@ var _ cluster.CloudProvider = (*awsProvider)(nil)
 
 func NewAWS(t cluster.Target) (cluster.CloudProvider, error) { return &awsProvider{t: t}, nil }
 
-// A session is created using default credentials from
-// configuration file in ~/.aws/credentials and environment variables
-func createSession() *session.Session {
+// A session is created using default credentials from configuration file in
+// ~/.aws/credentials (or its named profile) and environment variables
+func createSession(profile string) *session.Session {
 	// TODO: avoid creating sessions for each request
 	return session.Must(session.NewSessionWithOptions(session.Options{
+		Profile:           profile,
 		SharedConfigState: session.SharedConfigEnable,
 		Config:            aws.Config{HTTPClient: cmn.NewClient(cmn.TransportArgs{})},
 	}))
 }
 
+// s3Conf returns the configuration of S3-compatible storage: defaults from
+// `cloud.conf.aws` overridden by the bucket's properties, if any.
+func s3Conf(bck *cmn.Bck) (conf cmn.CloudConfAWS) {
+	if v, ok := cmn.GCO.Get().Cloud.ProviderConf(cmn.ProviderAmazon); ok {
+		conf, _ = v.(cmn.CloudConfAWS)
+	}
+	if bck == nil || bck.Props == nil {
+		return
+	}
+	extra := &bck.Props.Extra
+	if extra.Endpoint != "" {
+		conf.Endpoint, conf.ForcePathStyle = extra.Endpoint, extra.ForcePathStyle
+	} else if extra.ForcePathStyle {
+		conf.ForcePathStyle = true
+	}
+	if extra.Profile != "" {
+		conf.Profile = extra.Profile
+	}
+	return
+}
+
 // newS3Client creates new S3 client that can be used to make requests. It is
 // guaranteed that the client is initialized even in case of errors.
 func (awsp *awsProvider) newS3Client(conf sessConf, tag string) (svc *s3.S3, regIsSet bool, err error) {
 	var (
-		sess    = createSession()
+		s3conf  = s3Conf(conf.bck)
+		sess    = createSession(s3conf.Profile)
 		awsConf = &aws.Config{}
 	)
 
+	if s3conf.Endpoint != "" {
+		awsConf.Endpoint = aws.String(s3conf.Endpoint)
+		awsConf.S3ForcePathStyle = aws.Bool(s3conf.ForcePathStyle)
+		if conf.region == "" && (conf.bck == nil || conf.bck.Props == nil || conf.bck.Props.Extra.CloudRegion == "") {
+			// S3-compatible storage is, typically, region-agnostic
+			awsConf.Region = aws.String(endpoints.UsEast1RegionID)
+			svc = s3.New(sess, awsConf)
+			return svc, true, nil
+		}
+	} else if s3conf.ForcePathStyle {
+		awsConf.S3ForcePathStyle = aws.Bool(true)
+	}
+
 	if conf.region != "" {
 		awsConf.Region = aws.String(conf.region)
 		regIsSet = true
@@ -73,7 +109,7 @@ func (awsp *awsProvider) newS3Client(conf sessConf, tag string) (svc *s3.S3, reg
 			if tag != "" {
 				err = fmt.Errorf("%s: unknown region for bucket %s -- proceeding with default", tag, conf.bck)
 			}
-			svc = s3.New(sess)
+			svc = s3.New(sess, awsConf)
 			return
 		}
 		regIsSet = true
@@ -125,6 +161,9 @@ func (awsp *awsProvider) ListObjects(ctx context.Context, bck *cluster.Bck, msg
 	if msg.Prefix != "" {
 		params.Prefix = aws.String(msg.Prefix)
 	}
+	if msg.Delimiter != "" {
+		params.Delimiter = aws.String(msg.Delimiter)
+	}
 	if msg.ContinuationToken != "" {
 		params.ContinuationToken = aws.String(msg.ContinuationToken)
 	}
@@ -136,7 +175,7 @@ func (awsp *awsProvider) ListObjects(ctx context.Context, bck *cluster.Bck, msg
 		return
 	}
 
-	bckList = &cmn.BucketList{Entries: make([]*cmn.BucketEntry, 0, len(resp.Contents))}
+	bckList = &cmn.BucketList{Entries: make([]*cmn.BucketEntry, 0, len(resp.Contents)+len(resp.CommonPrefixes))}
 	for _, key := range resp.Contents {
 		entry := &cmn.BucketEntry{Name: *key.Key}
 		if msg.WantProp(cmn.GetPropsSize) {
@@ -158,14 +197,10 @@ func (awsp *awsProvider) ListObjects(ctx context.Context, bck *cluster.Bck, msg
 		bckList.ContinuationToken = *resp.NextContinuationToken
 	}
 
-	if len(bckList.Entries) == 0 {
-		return
-	}
-
 	// If version is requested, read versions page by page and stop when there
 	// is nothing to read or the version page marker is greater than object page marker.
 	// Page is limited with 500+ items, so reading them is slow.
-	if msg.WantProp(cmn.GetPropsVersion) {
+	if len(bckList.Entries) > 0 && msg.WantProp(cmn.GetPropsVersion) {
 		var (
 			versions   = make(map[string]string, len(bckList.Entries))
 			keyMarker  = bckList.Entries[0].Name
@@ -210,6 +245,10 @@ func (awsp *awsProvider) ListObjects(ctx context.Context, bck *cluster.Bck, msg
 		}
 	}
 
+	// "directories" when listing with delimiter
+	for _, prefix := range resp.CommonPrefixes {
+		bckList.Entries = append(bckList.Entries, &cmn.BucketEntry{Name: *prefix.Prefix, Flags: cmn.EntryIsDir})
+	}
 	return
 }
 
@@ -254,7 +293,7 @@ func (awsp *awsProvider) HeadBucket(ctx context.Context, bck *cluster.Bck) (bckP
 		}
 
 		// Create new svc with the region details.
-		svc, _, _ = awsp.newS3Client(sessConf{region: region}, "")
+		svc, _, _ = awsp.newS3Client(sessConf{bck: cloudBck, region: region}, "")
 	}
 
 	region = *svc.Config.Region
@@ -423,12 +462,11 @@ func (awsp *awsProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (r
 
 func (awsp *awsProvider) PutObj(ctx context.Context, r io.Reader, lom *cluster.LOM) (version string, errCode int, err error) {
 	var (
-		svc                   *s3.S3
-		uploadOutput          *s3manager.UploadOutput
-		h                     = cmn.CloudHelpers.Amazon
-		cksumType, cksumValue = lom.Cksum().Get()
-		cloudBck              = lom.Bck().RemoteBck()
-		md                    = make(map[string]*string, 2)
+		svc          *s3.S3
+		uploadOutput *s3manager.UploadOutput
+		h            = cmn.CloudHelpers.Amazon
+		cloudBck     = lom.Bck().RemoteBck()
+		md           = make(map[string]*string, 2)
 	)
 
 	svc, _, err = awsp.newS3Client(sessConf{bck: cloudBck}, "[put_object]")
@@ -436,8 +474,11 @@ func (awsp *awsProvider) PutObj(ctx context.Context, r io.Reader, lom *cluster.L
 		glog.Warning(err)
 	}
 
-	md[awsChecksumType] = aws.String(cksumType)
-	md[awsChecksumVal] = aws.String(cksumValue)
+	if cksum := lom.ContentCksum(); cksum != nil { // (unknown if the object is encrypted)
+		cksumType, cksumValue := cksum.Get()
+		md[awsChecksumType] = aws.String(cksumType)
+		md[awsChecksumVal] = aws.String(cksumValue)
+	}
 
 	uploader := s3manager.NewUploaderWithClient(svc)
 	uploadOutput, err = uploader.Upload(&s3manager.UploadInput{
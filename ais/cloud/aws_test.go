@@ -0,0 +1,76 @@
+// +build aws
+
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+const (
+	s3ListResult = `<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+<Name>bucket</Name><KeyCount>2</KeyCount><MaxKeys>1000</MaxKeys><IsTruncated>false</IsTruncated>
+<Contents><Key>a.txt</Key><Size>3</Size><ETag>"900150983cd24fb0d6963f7d28e17f72"</ETag></Contents>
+<Contents><Key>b.txt</Key><Size>5</Size><ETag>"e80b5017098950fc58aad83c8c14978e"</ETag></Contents>
+</ListBucketResult>`
+	s3VersioningResult = `<?xml version="1.0" encoding="UTF-8"?>
+<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`
+)
+
+// S3-compatible stand-in that only supports path-style addressing
+func newS3StandIn(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.URL.Path == "/bucket" && query.Get("list-type") == "2":
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, s3ListResult)
+		case r.URL.Path == "/bucket" && query["versioning"] != nil:
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, s3VersioningResult)
+		default:
+			t.Errorf("unexpected request: %s %s (host %s)", r.Method, r.URL, r.Host)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestS3CompatibleEndpoint(t *testing.T) {
+	srv := newS3StandIn(t)
+	defer srv.Close()
+	os.Setenv("AWS_ACCESS_KEY_ID", "minioadmin")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "minioadmin")
+
+	props := cmn.DefaultAISBckProps()
+	props.Provider = cmn.ProviderAmazon
+	props.Extra.Endpoint = srv.URL
+	props.Extra.ForcePathStyle = true
+	tassert.CheckFatal(t, props.Validate(1))
+
+	var (
+		awsp = &awsProvider{}
+		bck  = cluster.NewBck("bucket", cmn.ProviderAmazon, cmn.NsGlobal, props)
+	)
+	bckList, _, err := awsp.ListObjects(context.Background(), bck, &cmn.SelectMsg{Props: cmn.GetPropsSize})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(bckList.Entries) == 2, "expected 2 objects, got %d", len(bckList.Entries))
+	tassert.Errorf(t, bckList.Entries[0].Name == "a.txt" && bckList.Entries[0].Size == 3,
+		"unexpected entry %+v", bckList.Entries[0])
+
+	bckProps, _, err := awsp.HeadBucket(context.Background(), bck)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bckProps[cmn.HeaderBucketVerEnabled] == "true", "expected versioning enabled")
+}
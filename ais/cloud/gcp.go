@@ -154,8 +154,8 @@ func (gcpp *gcpProvider) ListObjects(ctx context.Context, bck *cluster.Bck, msg
 		glog.Infof("list_objects %s", cloudBck.Name)
 	}
 
-	if msg.Prefix != "" {
-		query = &storage.Query{Prefix: msg.Prefix}
+	if msg.Prefix != "" || msg.Delimiter != "" {
+		query = &storage.Query{Prefix: msg.Prefix, Delimiter: msg.Delimiter}
 	}
 
 	var (
@@ -172,6 +172,10 @@ func (gcpp *gcpProvider) ListObjects(ctx context.Context, bck *cluster.Bck, msg
 	bckList = &cmn.BucketList{Entries: make([]*cmn.BucketEntry, 0, len(objs))}
 	bckList.ContinuationToken = nextPageToken
 	for _, attrs := range objs {
+		if attrs.Prefix != "" { // synthetic "directory" entry (see `storage.Query.Delimiter`)
+			bckList.Entries = append(bckList.Entries, &cmn.BucketEntry{Name: attrs.Prefix, Flags: cmn.EntryIsDir})
+			continue
+		}
 		entry := &cmn.BucketEntry{}
 		entry.Name = attrs.Name
 		if msg.WantProp(cmn.GetPropsSize) {
@@ -385,7 +389,9 @@ func (gcpp *gcpProvider) PutObj(ctx context.Context, r io.Reader, lom *cluster.L
 		wc       = gcpObj.NewWriter(gctx)
 	)
 
-	md[gcpChecksumType], md[gcpChecksumVal] = lom.Cksum().Get()
+	if cksum := lom.ContentCksum(); cksum != nil { // (unknown if the object is encrypted)
+		md[gcpChecksumType], md[gcpChecksumVal] = cksum.Get()
+	}
 
 	wc.Metadata = md
 	buf, slab := gcpp.t.MMSA().Alloc()
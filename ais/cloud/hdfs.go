@@ -0,0 +1,623 @@
+// +build hdfs
+
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// HDFS is accessed via WebHDFS REST API
+// (https://hadoop.apache.org/docs/stable/hadoop-project-dist/hadoop-hdfs/WebHDFS.html):
+// buckets are the directories under the configured root, and objects are the
+// files in those directories (and their subdirectories). HDFS does not have
+// object versions - the modification time of the file is used instead.
+
+const (
+	hdfsPathPrefix = "/webhdfs/v1"
+
+	// checksum of the content (as computed by AIS) is stored in extended attributes
+	hdfsChecksumType = "user.ais.cksum_type"
+	hdfsChecksumVal  = "user.ais.cksum_val"
+
+	hdfsTypeDir          = "DIRECTORY"
+	hdfsStandbyException = "StandbyException"
+	hdfsNotFoundExcepton = "FileNotFoundException"
+)
+
+type (
+	hdfsProvider struct {
+		t          cluster.Target
+		conf       cmn.CloudConfHDFS
+		client     *http.Client // follows redirects to datanodes
+		noRedirect *http.Client
+		active     atomic.Int32 // index of the active namenode
+	}
+
+	hdfsFileStatus struct {
+		PathSuffix       string `json:"pathSuffix"`
+		Type             string `json:"type"`
+		Length           int64  `json:"length"`
+		ModificationTime int64  `json:"modificationTime"`
+	}
+	hdfsFileStatuses struct {
+		FileStatuses struct {
+			FileStatus []hdfsFileStatus `json:"FileStatus"`
+		} `json:"FileStatuses"`
+	}
+	hdfsDirListing struct {
+		DirectoryListing struct {
+			PartialListing   hdfsFileStatuses `json:"partialListing"`
+			RemainingEntries int              `json:"remainingEntries"`
+		} `json:"DirectoryListing"`
+	}
+	hdfsRemoteException struct {
+		RemoteException struct {
+			Exception string `json:"exception"`
+			Message   string `json:"message"`
+		} `json:"RemoteException"`
+	}
+
+	// recursive listing of the bucket (in lexicographical order of the path
+	// components) that resumes after the continuation token
+	hdfsLister struct {
+		hp    *hdfsProvider
+		ctx   context.Context
+		bck   *cmn.Bck
+		msg   *cmn.SelectMsg
+		token []string // continuation token split into path components
+		list  *cmn.BucketList
+	}
+)
+
+// interface guard
+var _ cluster.CloudProvider = (*hdfsProvider)(nil)
+
+func NewHDFS(t cluster.Target) (cluster.CloudProvider, error) {
+	v, ok := cmn.GCO.Get().Cloud.ProviderConf(cmn.ProviderHDFS)
+	if !ok {
+		return nil, fmt.Errorf("%q cloud is not configured", cmn.ProviderHDFS)
+	}
+	conf, ok := v.(cmn.CloudConfHDFS)
+	if !ok || len(conf.NameNodes) == 0 {
+		return nil, fmt.Errorf("invalid %q cloud configuration: %v", cmn.ProviderHDFS, v)
+	}
+	return newHDFS(t, conf), nil
+}
+
+func newHDFS(t cluster.Target, conf cmn.CloudConfHDFS) *hdfsProvider {
+	hp := &hdfsProvider{t: t, conf: conf, client: cmn.NewClient(cmn.TransportArgs{})}
+	noRedirect := *hp.client
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	hp.noRedirect = &noRedirect
+	return hp
+}
+
+func (hp *hdfsProvider) Provider() string { return cmn.ProviderHDFS }
+
+// default `dfs.ls.limit`
+func (hp *hdfsProvider) MaxPageSize() uint { return 1000 }
+
+func (hp *hdfsProvider) hdfsPath(bckName, objName string) string {
+	return path.Join(hp.conf.Root, bckName, objName)
+}
+
+// do executes the request to the active namenode and fails over to the next
+// one if the namenode is unreachable or in standby.
+func (hp *hdfsProvider) do(ctx context.Context, client *http.Client, method, hpath string,
+	query url.Values) (resp *http.Response, err error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	if hp.conf.User != "" {
+		query.Set("user.name", hp.conf.User)
+	}
+	var (
+		cnt    = len(hp.conf.NameNodes)
+		active = int(hp.active.Load())
+		upath  = (&url.URL{Path: hdfsPathPrefix + hpath}).EscapedPath()
+	)
+	for i := 0; i < cnt; i++ {
+		var (
+			idx = (active + i) % cnt
+			req *http.Request
+		)
+		req, err = http.NewRequestWithContext(ctx, method, hp.conf.NameNodes[idx]+upath+"?"+query.Encode(), nil)
+		if err != nil {
+			return
+		}
+		if resp, err = client.Do(req); err != nil {
+			glog.Warningf("%s: namenode %s: %v", hp.Provider(), hp.conf.NameNodes[idx], err)
+			continue
+		}
+		if resp.StatusCode == http.StatusForbidden {
+			rerr := hdfsReadException(resp)
+			if rerr.RemoteException.Exception == hdfsStandbyException {
+				err = fmt.Errorf("namenode %s is in standby", hp.conf.NameNodes[idx])
+				continue
+			}
+			msg := fmt.Sprintf("%s: %s", rerr.RemoteException.Exception, rerr.RemoteException.Message)
+			return nil, &cmn.HTTPError{Status: http.StatusForbidden, Message: msg}
+		}
+		if idx != active {
+			hp.active.Store(int32(idx))
+		}
+		return
+	}
+	return nil, err
+}
+
+// reads and closes the body of unsuccessful response
+func hdfsReadException(resp *http.Response) (rerr hdfsRemoteException) {
+	jsoniter.NewDecoder(resp.Body).Decode(&rerr)
+	resp.Body.Close()
+	return
+}
+
+func (hp *hdfsProvider) hdfsErrorToAISError(resp *http.Response, bck *cmn.Bck, objName string) (int, error) {
+	var (
+		rerr   = hdfsReadException(resp)
+		status = resp.StatusCode
+	)
+	if status == http.StatusNotFound || rerr.RemoteException.Exception == hdfsNotFoundExcepton {
+		if objName == "" {
+			return http.StatusNotFound, cmn.NewErrorRemoteBucketDoesNotExist(*bck, hp.t.Snode().Name())
+		}
+		msg := fmt.Sprintf("%s/%s not found", bck, objName)
+		return http.StatusNotFound, &cmn.HTTPError{Status: http.StatusNotFound, Message: msg}
+	}
+	if rerr.RemoteException.Exception != "" {
+		return status, fmt.Errorf("%s: %s", rerr.RemoteException.Exception, rerr.RemoteException.Message)
+	}
+	return status, fmt.Errorf("%s/%s: %s", bck, objName, resp.Status)
+}
+
+// executes the request and decodes JSON response
+func (hp *hdfsProvider) call(ctx context.Context, method, hpath string, query url.Values, bck *cmn.Bck,
+	objName string, v interface{}) (errCode int, err error) {
+	resp, err := hp.do(ctx, hp.client, method, hpath, query)
+	if err != nil {
+		return http.StatusServiceUnavailable, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return hp.hdfsErrorToAISError(resp, bck, objName)
+	}
+	defer resp.Body.Close()
+	if v == nil {
+		return 0, nil
+	}
+	if err = jsoniter.NewDecoder(resp.Body).Decode(v); err != nil {
+		return http.StatusBadGateway, fmt.Errorf("%s: invalid response: %v", hp.Provider(), err)
+	}
+	return 0, nil
+}
+
+func (hp *hdfsProvider) fileStatus(ctx context.Context, bck *cmn.Bck, objName string) (st *hdfsFileStatus,
+	errCode int, err error) {
+	var v struct {
+		FileStatus hdfsFileStatus `json:"FileStatus"`
+	}
+	query := url.Values{"op": []string{"GETFILESTATUS"}}
+	if errCode, err = hp.call(ctx, http.MethodGet, hp.hdfsPath(bck.Name, objName), query, bck, objName, &v); err != nil {
+		return
+	}
+	return &v.FileStatus, 0, nil
+}
+
+// lists one batch of the directory (in lexicographical order) starting after a given name
+func (hp *hdfsProvider) listDir(ctx context.Context, bck *cmn.Bck, dir, startAfter string) (entries []hdfsFileStatus,
+	more bool, errCode int, err error) {
+	var (
+		v     hdfsDirListing
+		query = url.Values{"op": []string{"LISTSTATUS_BATCH"}}
+	)
+	if startAfter != "" {
+		query.Set("startAfter", startAfter)
+	}
+	if errCode, err = hp.call(ctx, http.MethodGet, hp.hdfsPath(bck.Name, dir), query, bck, "", &v); err != nil {
+		return
+	}
+	listing := &v.DirectoryListing
+	return listing.PartialListing.FileStatuses.FileStatus, listing.RemainingEntries > 0, 0, nil
+}
+
+func hdfsVersion(st *hdfsFileStatus) string { return strconv.FormatInt(st.ModificationTime, 10) }
+
+/////////////////
+// HEAD BUCKET //
+/////////////////
+
+func (hp *hdfsProvider) HeadBucket(ctx context.Context, bck *cluster.Bck) (bckProps cmn.SimpleKVs, errCode int, err error) {
+	cloudBck := bck.RemoteBck()
+	st, errCode, err := hp.fileStatus(ctx, cloudBck, "")
+	if err != nil {
+		return
+	}
+	if st.Type != hdfsTypeDir {
+		return nil, http.StatusNotFound, cmn.NewErrorRemoteBucketDoesNotExist(*cloudBck, hp.t.Snode().Name())
+	}
+	bckProps = make(cmn.SimpleKVs, 2)
+	bckProps[cmn.HeaderCloudProvider] = cmn.ProviderHDFS
+	// versions are simulated by modification times
+	bckProps[cmn.HeaderBucketVerEnabled] = "true"
+	return
+}
+
+//////////////////
+// BUCKET NAMES //
+//////////////////
+
+func (hp *hdfsProvider) ListBuckets(ctx context.Context, _ cmn.QueryBcks) (buckets cmn.BucketNames, errCode int, err error) {
+	var (
+		startAfter string
+		more       = true
+		bck        = &cmn.Bck{Provider: cmn.ProviderHDFS}
+	)
+	for more {
+		var entries []hdfsFileStatus
+		if entries, more, errCode, err = hp.listDir(ctx, bck, "", startAfter); err != nil {
+			return
+		}
+		for i := range entries {
+			if entries[i].Type == hdfsTypeDir {
+				buckets = append(buckets, cmn.Bck{Name: entries[i].PathSuffix, Provider: cmn.ProviderHDFS})
+			}
+		}
+		if len(entries) == 0 {
+			break
+		}
+		startAfter = entries[len(entries)-1].PathSuffix
+	}
+	return
+}
+
+//////////////////
+// LIST OBJECTS //
+//////////////////
+
+func (hp *hdfsProvider) ListObjects(ctx context.Context, bck *cluster.Bck, msg *cmn.SelectMsg) (bckList *cmn.BucketList, errCode int, err error) {
+	msg.PageSize = calcPageSize(msg.PageSize, hp.MaxPageSize())
+	var (
+		cloudBck = bck.RemoteBck()
+		l        = &hdfsLister{
+			hp:   hp,
+			ctx:  ctx,
+			bck:  cloudBck,
+			msg:  msg,
+			list: &cmn.BucketList{Entries: make([]*cmn.BucketEntry, 0, msg.PageSize)},
+		}
+	)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("list_objects %s", cloudBck.Name)
+	}
+	if msg.ContinuationToken != "" {
+		l.token = strings.Split(msg.ContinuationToken, "/")
+	}
+	if errCode, err = l.walk("", 0, msg.ContinuationToken == ""); err != nil {
+		return
+	}
+	bckList = l.list
+	if uint(len(bckList.Entries)) >= msg.PageSize {
+		bckList.ContinuationToken = bckList.Entries[len(bckList.Entries)-1].Name
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[list_bucket] count %d(marker: %s)", len(bckList.Entries), bckList.ContinuationToken)
+	}
+	return
+}
+
+func (l *hdfsLister) full() bool { return uint(len(l.list.Entries)) >= l.msg.PageSize }
+
+// walk lists a given directory recursively; `after` is true when the walk is
+// already past the continuation token
+func (l *hdfsLister) walk(dir string, depth int, after bool) (errCode int, err error) {
+	var (
+		startAfter string
+		more       = true
+	)
+	if !after && l.token[depth] != "" {
+		// (listing starts right before the token, or the directory that contains it)
+		startAfter = l.token[depth][:len(l.token[depth])-1]
+	}
+	for more && !l.full() {
+		var entries []hdfsFileStatus
+		if entries, more, errCode, err = l.hp.listDir(l.ctx, l.bck, dir, startAfter); err != nil {
+			return
+		}
+		if len(entries) == 0 {
+			break
+		}
+		for i := range entries {
+			var (
+				st      = &entries[i]
+				objName = path.Join(dir, st.PathSuffix)
+				isDir   = st.Type == hdfsTypeDir
+				past    = after
+			)
+			if !after {
+				tc := l.token[depth]
+				switch {
+				case st.PathSuffix < tc:
+					continue
+				case st.PathSuffix > tc:
+					past = true
+				case !isDir: // the token itself
+					after = true
+					continue
+				case depth == len(l.token)-1:
+					past = true
+				}
+			}
+			if isDir {
+				if !l.matchDir(objName) {
+					continue
+				}
+				if errCode, err = l.walk(objName, depth+1, past); err != nil || l.full() {
+					return
+				}
+			} else if strings.HasPrefix(objName, l.msg.Prefix) {
+				l.add(objName, st)
+				if l.full() {
+					return
+				}
+			}
+			after = past
+		}
+		startAfter = entries[len(entries)-1].PathSuffix
+	}
+	return
+}
+
+func (l *hdfsLister) matchDir(dir string) bool {
+	return strings.HasPrefix(dir+"/", l.msg.Prefix) || strings.HasPrefix(l.msg.Prefix, dir+"/")
+}
+
+func (l *hdfsLister) add(objName string, st *hdfsFileStatus) {
+	entry := &cmn.BucketEntry{Name: objName}
+	if l.msg.WantProp(cmn.GetPropsSize) {
+		entry.Size = st.Length
+	}
+	if l.msg.WantProp(cmn.GetPropsVersion) {
+		entry.Version = hdfsVersion(st)
+	}
+	l.list.Entries = append(l.list.Entries, entry)
+}
+
+/////////////////
+// HEAD OBJECT //
+/////////////////
+
+func (hp *hdfsProvider) HeadObj(ctx context.Context, lom *cluster.LOM) (objMeta cmn.SimpleKVs, errCode int, err error) {
+	cloudBck := lom.Bck().RemoteBck()
+	st, errCode, err := hp.fileStatus(ctx, cloudBck, lom.ObjName)
+	if err != nil {
+		return
+	}
+	if st.Type == hdfsTypeDir {
+		msg := fmt.Sprintf("%s/%s not found", cloudBck, lom.ObjName)
+		return nil, http.StatusNotFound, &cmn.HTTPError{Status: http.StatusNotFound, Message: msg}
+	}
+	objMeta = make(cmn.SimpleKVs, 3)
+	objMeta[cmn.HeaderCloudProvider] = cmn.ProviderHDFS
+	objMeta[cmn.HeaderObjSize] = strconv.FormatInt(st.Length, 10)
+	objMeta[cmn.HeaderObjVersion] = hdfsVersion(st)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[head_object] %s/%s", cloudBck, lom.ObjName)
+	}
+	return
+}
+
+////////////////
+// GET OBJECT //
+////////////////
+
+func (hp *hdfsProvider) GetObj(ctx context.Context, lom *cluster.LOM) (workFQN string, errCode int, err error) {
+	r, cksumToUse, errCode, err := hp.GetObjReader(ctx, lom)
+	if err != nil {
+		return "", errCode, err
+	}
+	params := cluster.PutObjectParams{
+		Tag:          fs.WorkfileColdget,
+		Reader:       r,
+		RecvType:     cluster.ColdGet,
+		Cksum:        cksumToUse,
+		WithFinalize: false,
+	}
+	workFQN, err = hp.t.PutObject(lom, params)
+	if err != nil {
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[get_object] %s", lom)
+	}
+	return
+}
+
+// returns the checksum that AIS stored along with the file (if any)
+func (hp *hdfsProvider) getCksum(ctx context.Context, bck *cmn.Bck, objName string) *cmn.Cksum {
+	var (
+		v struct {
+			XAttrs []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"XAttrs"`
+		}
+		query = url.Values{
+			"op":         []string{"GETXATTRS"},
+			"xattr.name": []string{hdfsChecksumType, hdfsChecksumVal},
+			"encoding":   []string{"text"},
+		}
+		cksumType, cksumValue string
+	)
+	if _, err := hp.call(ctx, http.MethodGet, hp.hdfsPath(bck.Name, objName), query, bck, objName, &v); err != nil {
+		return nil // (not stored by AIS)
+	}
+	for _, xattr := range v.XAttrs {
+		value, err := strconv.Unquote(xattr.Value)
+		if err != nil {
+			value = xattr.Value
+		}
+		switch xattr.Name {
+		case hdfsChecksumType:
+			cksumType = value
+		case hdfsChecksumVal:
+			cksumValue = value
+		}
+	}
+	if cksumType == "" || cksumValue == "" || cmn.ValidateCksumType(cksumType) != nil {
+		return nil
+	}
+	return cmn.NewCksum(cksumType, cksumValue)
+}
+
+func (hp *hdfsProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (r io.ReadCloser, expectedCksm *cmn.Cksum, errCode int, err error) {
+	var (
+		resp     *http.Response
+		cloudBck = lom.Bck().RemoteBck()
+		hpath    = hp.hdfsPath(cloudBck.Name, lom.ObjName)
+	)
+	st, errCode, err := hp.fileStatus(ctx, cloudBck, lom.ObjName)
+	if err != nil {
+		return
+	}
+	if st.Type == hdfsTypeDir {
+		msg := fmt.Sprintf("%s/%s not found", cloudBck, lom.ObjName)
+		return nil, nil, http.StatusNotFound, &cmn.HTTPError{Status: http.StatusNotFound, Message: msg}
+	}
+	expectedCksm = hp.getCksum(ctx, cloudBck, lom.ObjName)
+
+	// (namenode redirects to datanode)
+	resp, err = hp.do(ctx, hp.client, http.MethodGet, hpath, url.Values{"op": []string{"OPEN"}})
+	if err != nil {
+		return nil, nil, http.StatusServiceUnavailable, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		errCode, err = hp.hdfsErrorToAISError(resp, cloudBck, lom.ObjName)
+		return nil, nil, errCode, err
+	}
+	version := hdfsVersion(st)
+	lom.SetVersion(version)
+	lom.SetCustomMD(cmn.SimpleKVs{
+		cluster.SourceObjMD:  cluster.SourceHDFSObjMD,
+		cluster.VersionObjMD: version,
+	})
+	setSize(ctx, st.Length)
+	return wrapReader(ctx, resp.Body), expectedCksm, 0, nil
+}
+
+////////////////
+// PUT OBJECT //
+////////////////
+
+func (hp *hdfsProvider) PutObj(ctx context.Context, r io.Reader, lom *cluster.LOM) (version string, errCode int, err error) {
+	var (
+		resp, dnResp *http.Response
+		req          *http.Request
+		cloudBck     = lom.Bck().RemoteBck()
+		hpath        = hp.hdfsPath(cloudBck.Name, lom.ObjName)
+		query        = url.Values{"op": []string{"CREATE"}, "overwrite": []string{"true"}}
+	)
+	// 1. namenode responds with the datanode location to write to
+	if resp, err = hp.do(ctx, hp.noRedirect, http.MethodPut, hpath, query); err != nil {
+		return "", http.StatusServiceUnavailable, err
+	}
+	if resp.StatusCode != http.StatusTemporaryRedirect {
+		if resp.StatusCode >= http.StatusBadRequest {
+			errCode, err = hp.hdfsErrorToAISError(resp, cloudBck, lom.ObjName)
+			return
+		}
+		resp.Body.Close()
+		return "", http.StatusBadGateway, fmt.Errorf("%s: unexpected response to create %s: %s",
+			hp.Provider(), lom, resp.Status)
+	}
+	resp.Body.Close()
+	location := resp.Header.Get(cmn.HeaderLocation)
+	if location == "" {
+		return "", http.StatusBadGateway, errors.New("missing datanode location")
+	}
+
+	// 2. write the content to datanode
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPut, location, r); err != nil {
+		return
+	}
+	req.ContentLength = lom.ContentSize()
+	req.Header.Set(cmn.HeaderContentType, cmn.ContentBinary)
+	if dnResp, err = hp.client.Do(req); err != nil {
+		return "", http.StatusServiceUnavailable, err
+	}
+	if dnResp.StatusCode >= http.StatusBadRequest {
+		errCode, err = hp.hdfsErrorToAISError(dnResp, cloudBck, lom.ObjName)
+		return
+	}
+	dnResp.Body.Close()
+
+	// 3. checksum and version
+	if cksum := lom.ContentCksum(); cksum != nil && cksum.Type() != cmn.ChecksumNone {
+		cksumType, cksumValue := cksum.Get()
+		for name, value := range map[string]string{hdfsChecksumType: cksumType, hdfsChecksumVal: cksumValue} {
+			query := url.Values{
+				"op":          []string{"SETXATTR"},
+				"xattr.name":  []string{name},
+				"xattr.value": []string{strconv.Quote(value)},
+				"flag":        []string{"CREATE"},
+			}
+			if _, err := hp.call(ctx, http.MethodPut, hpath, query, cloudBck, lom.ObjName, nil); err != nil {
+				glog.Warningf("%s: failed to store checksum, err: %v", lom, err)
+				break
+			}
+		}
+	}
+	st, errCode, err := hp.fileStatus(ctx, cloudBck, lom.ObjName)
+	if err != nil {
+		return
+	}
+	version = hdfsVersion(st)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[put_object] %s, version %s", lom, version)
+	}
+	return
+}
+
+///////////////////
+// DELETE OBJECT //
+///////////////////
+
+func (hp *hdfsProvider) DeleteObj(ctx context.Context, lom *cluster.LOM) (errCode int, err error) {
+	var (
+		v struct {
+			Boolean bool `json:"boolean"`
+		}
+		cloudBck = lom.Bck().RemoteBck()
+		hpath    = hp.hdfsPath(cloudBck.Name, lom.ObjName)
+	)
+	if errCode, err = hp.call(ctx, http.MethodDelete, hpath, url.Values{"op": []string{"DELETE"}}, cloudBck,
+		lom.ObjName, &v); err != nil {
+		return
+	}
+	if !v.Boolean {
+		msg := fmt.Sprintf("%s/%s not found", cloudBck, lom.ObjName)
+		return http.StatusNotFound, &cmn.HTTPError{Status: http.StatusNotFound, Message: msg}
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[delete_object] %s", lom)
+	}
+	return
+}
@@ -0,0 +1,16 @@
+// +build !hdfs
+
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func NewHDFS(_ cluster.Target) (cluster.CloudProvider, error) {
+	return nil, newInitCloudErr(cmn.ProviderHDFS)
+}
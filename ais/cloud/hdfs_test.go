@@ -0,0 +1,167 @@
+// +build hdfs
+
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const webhdfsBatch = 2 // (small batches to exercise LISTSTATUS_BATCH pagination)
+
+// WebHDFS stand-in: files under "/data" (the root), listing and status only
+type webhdfs struct {
+	files   map[string]int64 // path => size
+	standby bool
+}
+
+func (fs *webhdfs) children(dir string) (entries []hdfsFileStatus) {
+	seen := make(map[string]bool)
+	for fpath, size := range fs.files {
+		if !strings.HasPrefix(fpath, dir+"/") {
+			continue
+		}
+		rel := strings.TrimPrefix(fpath, dir+"/")
+		if i := strings.IndexByte(rel, '/'); i >= 0 {
+			if name := rel[:i]; !seen[name] {
+				seen[name] = true
+				entries = append(entries, hdfsFileStatus{PathSuffix: name, Type: hdfsTypeDir})
+			}
+			continue
+		}
+		entries = append(entries, hdfsFileStatus{PathSuffix: rel, Type: "FILE", Length: size, ModificationTime: 1})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].PathSuffix < entries[j].PathSuffix })
+	return
+}
+
+func (fs *webhdfs) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if fs.standby {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"RemoteException":{"exception":"StandbyException","message":"standby"}}`))
+		return
+	}
+	var (
+		fpath = strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, hdfsPathPrefix), "/")
+		query = r.URL.Query()
+	)
+	switch query.Get("op") {
+	case "GETFILESTATUS":
+		if size, ok := fs.files[fpath]; ok {
+			jsoniter.NewEncoder(w).Encode(map[string]hdfsFileStatus{"FileStatus": {Type: "FILE", Length: size}})
+			return
+		}
+		if len(fs.children(fpath)) > 0 {
+			jsoniter.NewEncoder(w).Encode(map[string]hdfsFileStatus{"FileStatus": {Type: hdfsTypeDir}})
+			return
+		}
+	case "LISTSTATUS_BATCH":
+		var (
+			v       hdfsDirListing
+			entries []hdfsFileStatus
+		)
+		for _, e := range fs.children(fpath) {
+			if e.PathSuffix > query.Get("startAfter") {
+				entries = append(entries, e)
+			}
+		}
+		if len(entries) > webhdfsBatch {
+			v.DirectoryListing.RemainingEntries = len(entries) - webhdfsBatch
+			entries = entries[:webhdfsBatch]
+		}
+		v.DirectoryListing.PartialListing.FileStatuses.FileStatus = entries
+		jsoniter.NewEncoder(w).Encode(&v)
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+	w.Write([]byte(`{"RemoteException":{"exception":"FileNotFoundException","message":"not found"}}`))
+}
+
+func newWebHDFS() (*hdfsProvider, *cluster.Bck, func()) {
+	var (
+		standby = httptest.NewServer(&webhdfs{standby: true})
+		active  = httptest.NewServer(&webhdfs{files: map[string]int64{
+			"/data/bck/a.txt":       1,
+			"/data/bck/a/b/c.txt":   2,
+			"/data/bck/a/b/d.txt":   3,
+			"/data/bck/a/e.txt":     4,
+			"/data/bck/a-f.txt":     5,
+			"/data/bck/b/g.txt":     6,
+			"/data/bck/b/h.txt":     7,
+			"/data/other/x.txt":     8,
+			"/data/bck/zzz/zzz.txt": 9,
+		}})
+		conf = cmn.CloudConfHDFS{NameNodes: []string{standby.URL, active.URL}, Root: "/data"}
+		bck  = cluster.NewBck("bck", cmn.ProviderHDFS, cmn.NsGlobal)
+	)
+	return newHDFS(nil, conf), bck, func() { standby.Close(); active.Close() }
+}
+
+func TestHDFSListObjects(t *testing.T) {
+	hp, bck, cleanup := newWebHDFS()
+	defer cleanup()
+
+	tests := []struct {
+		prefix   string
+		pageSize uint
+		expected []string
+	}{
+		{"", 1000, []string{"a/b/c.txt", "a/b/d.txt", "a/e.txt", "a-f.txt", "a.txt", "b/g.txt", "b/h.txt", "zzz/zzz.txt"}},
+		{"", 3, []string{"a/b/c.txt", "a/b/d.txt", "a/e.txt", "a-f.txt", "a.txt", "b/g.txt", "b/h.txt", "zzz/zzz.txt"}},
+		{"", 1, []string{"a/b/c.txt", "a/b/d.txt", "a/e.txt", "a-f.txt", "a.txt", "b/g.txt", "b/h.txt", "zzz/zzz.txt"}},
+		{"a/", 2, []string{"a/b/c.txt", "a/b/d.txt", "a/e.txt"}},
+		{"a", 2, []string{"a/b/c.txt", "a/b/d.txt", "a/e.txt", "a-f.txt", "a.txt"}},
+		{"b/h", 2, []string{"b/h.txt"}},
+		{"c", 2, nil},
+	}
+	for _, test := range tests {
+		var (
+			names []string
+			msg   = &cmn.SelectMsg{Prefix: test.prefix, PageSize: test.pageSize, Props: cmn.GetPropsSize}
+		)
+		for pages := 0; ; pages++ {
+			tassert.Fatalf(t, pages <= len(test.expected), "prefix %q: too many pages", test.prefix)
+			bckList, _, err := hp.ListObjects(context.Background(), bck, msg)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, uint(len(bckList.Entries)) <= test.pageSize, "page size exceeded")
+			for _, entry := range bckList.Entries {
+				tassert.Errorf(t, entry.Size > 0, "%s: expected size", entry.Name)
+				names = append(names, entry.Name)
+			}
+			if bckList.ContinuationToken == "" {
+				break
+			}
+			msg.ContinuationToken = bckList.ContinuationToken
+		}
+		tassert.Errorf(t, strings.Join(names, ",") == strings.Join(test.expected, ","),
+			"prefix %q, page size %d: expected %v, got %v", test.prefix, test.pageSize, test.expected, names)
+	}
+}
+
+func TestHDFSBuckets(t *testing.T) {
+	hp, bck, cleanup := newWebHDFS()
+	defer cleanup()
+
+	bckProps, _, err := hp.HeadBucket(context.Background(), bck)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bckProps[cmn.HeaderCloudProvider] == cmn.ProviderHDFS, "unexpected props %v", bckProps)
+	tassert.Errorf(t, hp.active.Load() == 1, "expected failover to the active namenode")
+
+	buckets, _, err := hp.ListBuckets(context.Background(), cmn.QueryBcks{Provider: cmn.ProviderHDFS})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(buckets) == 2 && buckets[0].Name == "bck" && buckets[1].Name == "other",
+		"unexpected buckets %v", buckets)
+}
@@ -0,0 +1,350 @@
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// POSIX provider maps buckets to the directories under the configured root -
+// a shared filesystem (e.g., NFS or Lustre) mounted at the same path on all
+// targets. Objects are the files in those directories (and their
+// subdirectories); the provider is read-through: AIS does not modify the
+// files. The version of an object is derived from the modification time and
+// size of the respective file.
+
+type (
+	posixProvider struct {
+		t    cluster.Target
+		root string
+	}
+
+	// recursive listing of the bucket (in lexicographical order of the path
+	// components) that resumes after the continuation token
+	posixLister struct {
+		msg   *cmn.SelectMsg
+		token []string // continuation token split into path components
+		list  *cmn.BucketList
+	}
+)
+
+// interface guard
+var _ cluster.CloudProvider = (*posixProvider)(nil)
+
+func NewPOSIX(t cluster.Target) (cluster.CloudProvider, error) {
+	v, ok := cmn.GCO.Get().Cloud.ProviderConf(cmn.ProviderPOSIX)
+	if !ok {
+		return nil, fmt.Errorf("%q cloud is not configured", cmn.ProviderPOSIX)
+	}
+	conf, ok := v.(cmn.CloudConfPOSIX)
+	if !ok {
+		return nil, fmt.Errorf("invalid %q cloud configuration: %v", cmn.ProviderPOSIX, v)
+	}
+	finfo, err := os.Stat(conf.Root)
+	if err != nil {
+		return nil, err
+	}
+	if !finfo.IsDir() {
+		return nil, fmt.Errorf("%q cloud: %q is not a directory", cmn.ProviderPOSIX, conf.Root)
+	}
+	return &posixProvider{t: t, root: filepath.Clean(conf.Root)}, nil
+}
+
+func (pp *posixProvider) Provider() string  { return cmn.ProviderPOSIX }
+func (pp *posixProvider) MaxPageSize() uint { return 10000 }
+
+func (pp *posixProvider) bckDir(bck *cmn.Bck) string { return filepath.Join(pp.root, bck.Name) }
+
+// returns the path of the object's file - must be within the bucket's directory
+func (pp *posixProvider) objPath(bck *cmn.Bck, objName string) (string, error) {
+	var (
+		dir   = pp.bckDir(bck)
+		fpath = filepath.Join(dir, objName)
+	)
+	if !strings.HasPrefix(fpath, dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object name %q", objName)
+	}
+	return fpath, nil
+}
+
+func posixVersion(finfo os.FileInfo) string {
+	return strconv.FormatInt(finfo.ModTime().UnixNano(), 10) + "-" + strconv.FormatInt(finfo.Size(), 10)
+}
+
+func (pp *posixProvider) notFound(bck *cmn.Bck, objName string) (int, error) {
+	if objName == "" {
+		return http.StatusNotFound, cmn.NewErrorRemoteBucketDoesNotExist(*bck, pp.t.Snode().Name())
+	}
+	msg := fmt.Sprintf("%s/%s not found", bck, objName)
+	return http.StatusNotFound, &cmn.HTTPError{Status: http.StatusNotFound, Message: msg}
+}
+
+func (pp *posixProvider) statObj(bck *cmn.Bck, objName string) (fpath string, finfo os.FileInfo, errCode int, err error) {
+	if fpath, err = pp.objPath(bck, objName); err != nil {
+		return "", nil, http.StatusBadRequest, err
+	}
+	if finfo, err = os.Stat(fpath); err != nil {
+		if os.IsNotExist(err) {
+			errCode, err = pp.notFound(bck, objName)
+			return
+		}
+		return "", nil, http.StatusInternalServerError, err
+	}
+	if !finfo.Mode().IsRegular() {
+		errCode, err = pp.notFound(bck, objName)
+	}
+	return
+}
+
+/////////////////
+// HEAD BUCKET //
+/////////////////
+
+func (pp *posixProvider) HeadBucket(ctx context.Context, bck *cluster.Bck) (bckProps cmn.SimpleKVs, errCode int, err error) {
+	cloudBck := bck.RemoteBck()
+	finfo, err := os.Stat(pp.bckDir(cloudBck))
+	if err != nil || !finfo.IsDir() {
+		if err == nil || os.IsNotExist(err) {
+			errCode, err = pp.notFound(cloudBck, "")
+			return
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	bckProps = make(cmn.SimpleKVs, 2)
+	bckProps[cmn.HeaderCloudProvider] = cmn.ProviderPOSIX
+	// versions are simulated by modification times and sizes
+	bckProps[cmn.HeaderBucketVerEnabled] = "true"
+	return
+}
+
+//////////////////
+// BUCKET NAMES //
+//////////////////
+
+func (pp *posixProvider) ListBuckets(ctx context.Context, _ cmn.QueryBcks) (buckets cmn.BucketNames, errCode int, err error) {
+	finfos, err := ioutil.ReadDir(pp.root)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	for _, finfo := range finfos {
+		if finfo.IsDir() {
+			buckets = append(buckets, cmn.Bck{Name: finfo.Name(), Provider: cmn.ProviderPOSIX})
+		}
+	}
+	return
+}
+
+//////////////////
+// LIST OBJECTS //
+//////////////////
+
+func (pp *posixProvider) ListObjects(ctx context.Context, bck *cluster.Bck, msg *cmn.SelectMsg) (bckList *cmn.BucketList, errCode int, err error) {
+	msg.PageSize = calcPageSize(msg.PageSize, pp.MaxPageSize())
+	var (
+		cloudBck = bck.RemoteBck()
+		dir      = pp.bckDir(cloudBck)
+		l        = &posixLister{msg: msg, list: &cmn.BucketList{Entries: make([]*cmn.BucketEntry, 0, 64)}}
+	)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("list_objects %s", cloudBck.Name)
+	}
+	if finfo, err := os.Stat(dir); err != nil || !finfo.IsDir() {
+		errCode, err = pp.notFound(cloudBck, "")
+		return nil, errCode, err
+	}
+	if msg.ContinuationToken != "" {
+		l.token = strings.Split(msg.ContinuationToken, "/")
+	}
+	if err = l.walk(dir, "", 0, msg.ContinuationToken == ""); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	bckList = l.list
+	if uint(len(bckList.Entries)) >= msg.PageSize {
+		bckList.ContinuationToken = bckList.Entries[len(bckList.Entries)-1].Name
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[list_bucket] count %d(marker: %s)", len(bckList.Entries), bckList.ContinuationToken)
+	}
+	return
+}
+
+func (l *posixLister) full() bool { return uint(len(l.list.Entries)) >= l.msg.PageSize }
+
+// walk lists a given directory recursively; `after` is true when the walk is
+// already past the continuation token
+func (l *posixLister) walk(fqn, dir string, depth int, after bool) error {
+	finfos, err := ioutil.ReadDir(fqn) // (sorted by name)
+	if err != nil {
+		if depth > 0 && os.IsNotExist(err) {
+			return nil // (removed while listing)
+		}
+		return err
+	}
+	if !after {
+		tc := l.token[depth]
+		finfos = finfos[sort.Search(len(finfos), func(i int) bool { return finfos[i].Name() >= tc }):]
+	}
+	for _, finfo := range finfos {
+		var (
+			name    = finfo.Name()
+			objName = name
+			past    = after
+		)
+		if dir != "" {
+			objName = dir + "/" + name
+		}
+		if finfo.Mode()&os.ModeSymlink != 0 {
+			// follow symlinks to files (but not to directories)
+			if finfo, err = os.Stat(filepath.Join(fqn, name)); err != nil || finfo.IsDir() {
+				continue
+			}
+		}
+		if !after {
+			switch {
+			case name > l.token[depth]:
+				past = true
+			case !finfo.IsDir(): // the token itself
+				after = true
+				continue
+			case depth == len(l.token)-1:
+				past = true
+			}
+		}
+		if finfo.IsDir() {
+			if l.matchDir(objName) {
+				if err := l.walk(filepath.Join(fqn, name), objName, depth+1, past); err != nil {
+					return err
+				}
+			}
+		} else if finfo.Mode().IsRegular() && strings.HasPrefix(objName, l.msg.Prefix) {
+			l.add(objName, finfo)
+		}
+		if l.full() {
+			return nil
+		}
+		after = past
+	}
+	return nil
+}
+
+func (l *posixLister) matchDir(dir string) bool {
+	return strings.HasPrefix(dir+"/", l.msg.Prefix) || strings.HasPrefix(l.msg.Prefix, dir+"/")
+}
+
+func (l *posixLister) add(objName string, finfo os.FileInfo) {
+	entry := &cmn.BucketEntry{Name: objName}
+	if l.msg.WantProp(cmn.GetPropsSize) {
+		entry.Size = finfo.Size()
+	}
+	if l.msg.WantProp(cmn.GetPropsVersion) {
+		entry.Version = posixVersion(finfo)
+	}
+	l.list.Entries = append(l.list.Entries, entry)
+}
+
+/////////////////
+// HEAD OBJECT //
+/////////////////
+
+func (pp *posixProvider) HeadObj(ctx context.Context, lom *cluster.LOM) (objMeta cmn.SimpleKVs, errCode int, err error) {
+	return pp.headObj(lom.Bck().RemoteBck(), lom.ObjName)
+}
+
+func (pp *posixProvider) headObj(bck *cmn.Bck, objName string) (objMeta cmn.SimpleKVs, errCode int, err error) {
+	_, finfo, errCode, err := pp.statObj(bck, objName)
+	if err != nil {
+		return
+	}
+	objMeta = make(cmn.SimpleKVs, 3)
+	objMeta[cmn.HeaderCloudProvider] = cmn.ProviderPOSIX
+	objMeta[cmn.HeaderObjSize] = strconv.FormatInt(finfo.Size(), 10)
+	objMeta[cmn.HeaderObjVersion] = posixVersion(finfo)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[head_object] %s/%s", bck, objName)
+	}
+	return
+}
+
+////////////////
+// GET OBJECT //
+////////////////
+
+func (pp *posixProvider) GetObj(ctx context.Context, lom *cluster.LOM) (workFQN string, errCode int, err error) {
+	r, cksumToUse, errCode, err := pp.GetObjReader(ctx, lom)
+	if err != nil {
+		return "", errCode, err
+	}
+	params := cluster.PutObjectParams{
+		Tag:          fs.WorkfileColdget,
+		Reader:       r,
+		RecvType:     cluster.ColdGet,
+		Cksum:        cksumToUse,
+		WithFinalize: false,
+	}
+	workFQN, err = pp.t.PutObject(lom, params)
+	if err != nil {
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[get_object] %s", lom)
+	}
+	return
+}
+
+func (pp *posixProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (r io.ReadCloser, expectedCksm *cmn.Cksum, errCode int, err error) {
+	var (
+		file     *os.File
+		finfo    os.FileInfo
+		cloudBck = lom.Bck().RemoteBck()
+	)
+	fpath, _, errCode, err := pp.statObj(cloudBck, lom.ObjName)
+	if err != nil {
+		return
+	}
+	if file, err = os.Open(fpath); err != nil {
+		return nil, nil, http.StatusInternalServerError, err
+	}
+	// (version of the file that is actually read)
+	if finfo, err = file.Stat(); err != nil {
+		file.Close()
+		return nil, nil, http.StatusInternalServerError, err
+	}
+	version := posixVersion(finfo)
+	lom.SetVersion(version)
+	lom.SetCustomMD(cmn.SimpleKVs{
+		cluster.SourceObjMD:  cluster.SourcePOSIXObjMD,
+		cluster.VersionObjMD: version,
+	})
+	setSize(ctx, finfo.Size())
+	return wrapReader(ctx, file), nil, 0, nil
+}
+
+////////////////////
+// PUT and DELETE //
+////////////////////
+
+// (read-through: the files are not modified by AIS)
+
+func (pp *posixProvider) PutObj(ctx context.Context, r io.Reader, lom *cluster.LOM) (string, int, error) {
+	return "", http.StatusBadRequest, fmt.Errorf("%q provider doesn't support creating new objects", pp.Provider())
+}
+
+func (pp *posixProvider) DeleteObj(ctx context.Context, lom *cluster.LOM) (int, error) {
+	return http.StatusBadRequest, fmt.Errorf("%q provider doesn't support deleting object", pp.Provider())
+}
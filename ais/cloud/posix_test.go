@@ -0,0 +1,96 @@
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func newPOSIX(t *testing.T, files ...string) (*posixProvider, *cluster.Bck) {
+	root, err := ioutil.TempDir("", "posix")
+	tassert.CheckFatal(t, err)
+	t.Cleanup(func() { os.RemoveAll(root) })
+	for _, name := range files {
+		fqn := filepath.Join(root, name)
+		tassert.CheckFatal(t, os.MkdirAll(filepath.Dir(fqn), 0o755))
+		tassert.CheckFatal(t, ioutil.WriteFile(fqn, []byte(name), 0o644))
+	}
+	pp := &posixProvider{t: cluster.NewTargetMock(nil), root: root}
+	return pp, cluster.NewBck("bck", cmn.ProviderPOSIX, cmn.NsGlobal)
+}
+
+func TestPOSIXListObjects(t *testing.T) {
+	pp, bck := newPOSIX(t, "bck/a.txt", "bck/a/b/c.txt", "bck/a/b/d.txt", "bck/a/e.txt", "bck/a-f.txt",
+		"bck/b/g.txt", "bck/b/h.txt", "bck/zzz/zzz.txt", "other/x.txt")
+	all := []string{"a/b/c.txt", "a/b/d.txt", "a/e.txt", "a-f.txt", "a.txt", "b/g.txt", "b/h.txt", "zzz/zzz.txt"}
+	tests := []struct {
+		prefix   string
+		pageSize uint
+		expected []string
+	}{
+		{"", 0, all},
+		{"", 3, all},
+		{"", 1, all},
+		{"a/", 2, []string{"a/b/c.txt", "a/b/d.txt", "a/e.txt"}},
+		{"a", 2, []string{"a/b/c.txt", "a/b/d.txt", "a/e.txt", "a-f.txt", "a.txt"}},
+		{"b/h", 2, []string{"b/h.txt"}},
+		{"c", 2, nil},
+	}
+	for _, test := range tests {
+		var (
+			names []string
+			msg   = &cmn.SelectMsg{Prefix: test.prefix, PageSize: test.pageSize, Props: cmn.GetPropsVersion}
+		)
+		for pages := 0; ; pages++ {
+			tassert.Fatalf(t, pages <= len(test.expected), "prefix %q: too many pages", test.prefix)
+			bckList, _, err := pp.ListObjects(context.Background(), bck, msg)
+			tassert.CheckFatal(t, err)
+			for _, entry := range bckList.Entries {
+				tassert.Errorf(t, entry.Version != "", "%s: expected version", entry.Name)
+				names = append(names, entry.Name)
+			}
+			if bckList.ContinuationToken == "" {
+				break
+			}
+			msg.ContinuationToken = bckList.ContinuationToken
+		}
+		tassert.Errorf(t, strings.Join(names, ",") == strings.Join(test.expected, ","),
+			"prefix %q, page size %d: expected %v, got %v", test.prefix, test.pageSize, test.expected, names)
+	}
+
+	buckets, _, err := pp.ListBuckets(context.Background(), cmn.QueryBcks{Provider: cmn.ProviderPOSIX})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(buckets) == 2, "expected 2 buckets, got %v", buckets)
+}
+
+func TestPOSIXVersion(t *testing.T) {
+	pp, bck := newPOSIX(t, "bck/obj")
+
+	objMeta, _, err := pp.headObj(bck.Bck.RemoteBck(), "obj")
+	tassert.CheckFatal(t, err)
+	version := objMeta[cmn.HeaderObjVersion]
+	tassert.Errorf(t, objMeta[cmn.HeaderObjSize] == "7", "unexpected size %s", objMeta[cmn.HeaderObjSize])
+
+	// the file is modified out of band
+	tassert.CheckFatal(t, ioutil.WriteFile(filepath.Join(pp.root, "bck", "obj"), []byte("modified"), 0o644))
+	objMeta, _, err = pp.headObj(bck.Bck.RemoteBck(), "obj")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, objMeta[cmn.HeaderObjVersion] != version, "expected version to change")
+
+	_, errCode, err := pp.headObj(bck.Bck.RemoteBck(), "nonexistent")
+	tassert.Errorf(t, err != nil && errCode == http.StatusNotFound, "expected not found, got %d: %v", errCode, err)
+	_, errCode, err = pp.headObj(bck.Bck.RemoteBck(), "../other/x.txt")
+	tassert.Errorf(t, err != nil && errCode == http.StatusBadRequest, "expected bad request, got %d: %v", errCode, err)
+}
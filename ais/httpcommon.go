@@ -175,6 +175,11 @@ type (
 		si       *cluster.Snode
 		from, to string
 	}
+	// records the status of a (reverse-proxied) response
+	statusWriter struct {
+		http.ResponseWriter
+		status int
+	}
 )
 
 var allHTTPverbs = []string{
@@ -184,6 +189,11 @@ var allHTTPverbs = []string{
 
 var errNoBMD = errors.New("no bucket metadata")
 
+func (sw *statusWriter) WriteHeader(status int) {
+	sw.status = status
+	sw.ResponseWriter.WriteHeader(status)
+}
+
 // BMD uuid errs
 func (e *errTgtBmdUUIDDiffer) Error() string { return e.detail }
 func (e *errBmdUUIDSplit) Error() string     { return e.detail }
@@ -1680,6 +1690,11 @@ func (h *httprunner) attachDetachRemoteAIS(query url.Values, action string) erro
 		aisConf, ok = v.(cmn.CloudConfAIS)
 		cmn.Assert(ok)
 	}
+	// (copy-on-write)
+	tokens := make(map[string]string, len(config.Auth.RemoteTokens))
+	for alias, token := range config.Auth.RemoteTokens {
+		tokens[alias] = token
+	}
 	// detach
 	if action == cmn.ActDetach {
 		for alias := range query {
@@ -1689,13 +1704,14 @@ func (h *httprunner) attachDetachRemoteAIS(query url.Values, action string) erro
 			if _, ok := aisConf[alias]; ok {
 				changed = true
 				delete(aisConf, alias)
+				delete(tokens, alias)
 			}
 		}
 		goto rret
 	}
 	// attach
 	for alias, urls := range query {
-		if alias == cmn.URLParamWhat {
+		if alias == cmn.URLParamWhat || alias == cmn.URLParamRemoteToken {
 			continue
 		}
 		for _, u := range urls {
@@ -1711,6 +1727,9 @@ func (h *httprunner) attachDetachRemoteAIS(query url.Values, action string) erro
 			} else {
 				aisConf[alias] = urls
 			}
+			if token := query.Get(cmn.URLParamRemoteToken); token != "" {
+				tokens[alias] = token
+			}
 		}
 	}
 rret:
@@ -1719,6 +1738,11 @@ rret:
 		return fmt.Errorf("%s: request to %s remote cluster - nothing to do", h.si, action)
 	}
 	config.Cloud.ProviderConf(cmn.ProviderAIS, aisConf)
+	if len(tokens) > 0 {
+		config.Auth.RemoteTokens = tokens
+	} else {
+		config.Auth.RemoteTokens = nil
+	}
 	cmn.GCO.CommitUpdate(config)
 	return nil
 }
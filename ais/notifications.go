@@ -681,7 +681,9 @@ func isDLType(t string) bool {
 	return t == string(downloader.DlTypeMulti) ||
 		t == string(downloader.DlTypeCloud) ||
 		t == string(downloader.DlTypeSingle) ||
-		t == string(downloader.DlTypeRange)
+		t == string(downloader.DlTypeRange) ||
+		t == string(downloader.DlTypeManifest) ||
+		t == string(downloader.DlTypeCrawl)
 }
 
 //
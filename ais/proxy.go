@@ -27,6 +27,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/jsp"
 	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/downloader"
 	"github.com/NVIDIA/aistore/dsort"
 	"github.com/NVIDIA/aistore/etl"
 	"github.com/NVIDIA/aistore/memsys"
@@ -73,7 +74,8 @@ type (
 		notifs     notifs
 		ic         ic
 		qm         queryMem
-		gmm        *memsys.MMSA // system pagesize-based memory manager and slab allocator
+		dls        *downloader.Scheduler // scheduled (recurring) downloads
+		gmm        *memsys.MMSA          // system pagesize-based memory manager and slab allocator
 	}
 )
 
@@ -113,6 +115,7 @@ func (p *proxyrunner) Run() error {
 	p.notifs.init(p)
 	p.ic.init(p)
 	p.qm.init()
+	p.initDlSchedules(config)
 
 	//
 	// REST API: register proxy handlers and start listening
@@ -123,7 +126,7 @@ func (p *proxyrunner) Run() error {
 		// pubnet handlers: cluster must be started
 		{r: cmn.Buckets, h: p.bucketHandler, net: []string{cmn.NetworkPublic}},
 		{r: cmn.Objects, h: p.objectHandler, net: []string{cmn.NetworkPublic}},
-		{r: cmn.Download, h: p.downloadHandler, net: []string{cmn.NetworkPublic}},
+		{r: cmn.Download, h: p.downloadHandler, net: []string{cmn.NetworkPublic, cmn.NetworkIntraControl}},
 		{r: cmn.Query, h: p.queryHandler, net: []string{cmn.NetworkPublic}},
 		{r: cmn.ETL, h: p.etlHandler, net: []string{cmn.NetworkPublic}},
 		{r: cmn.Sort, h: p.dsortHandler, net: []string{cmn.NetworkPublic}},
@@ -467,6 +470,10 @@ func (p *proxyrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
 		return
 	}
+	if err := p.checkBypassGovernance(r, &bck.Bck); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	if err = bck.Allow(cmn.AccessObjDELETE); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
@@ -525,10 +532,9 @@ func (p *proxyrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
 			return
 		}
-		if bck.IsRemoteAIS() {
-			if err := p.reverseReqRemote(w, r, &msg, bck.Bck); err != nil {
-				return
-			}
+		if msg.Action == cmn.ActDestroyLB && bck.IsRemoteAIS() {
+			p.destroyRemoteAIS(w, r, &msg, bck)
+			return
 		}
 		if err := p.destroyBucket(&msg, bck); err != nil {
 			if _, ok := err.(*cmn.ErrorBucketDoesNotExist); ok { // race
@@ -917,6 +923,30 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 			return
 		}
 		w.Write([]byte(xactID))
+	case cmn.ActSyncBucket:
+		if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessSYNC); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if !bck.IsRemote() {
+			p.invalmsghdlrf(w, r, fmtNotCloud, bucket)
+			return
+		}
+		if err = bck.Allow(cmn.AccessSYNC); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			return
+		}
+		syncMsg := &cmn.SyncBckMsg{}
+		if err := cmn.MorphMarshal(msg.Value, syncMsg); err != nil {
+			p.invalmsghdlrf(w, r, "invalid %s action message: %v", msg.Action, err)
+			return
+		}
+		var xactID string
+		if xactID, err = p.doListRange(http.MethodPost, bucket, msg, query); err != nil {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		w.Write([]byte(xactID))
 	case cmn.ActListObjects:
 		begin := mono.NanoTime()
 		if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessObjLIST); err != nil {
@@ -1282,6 +1312,10 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
 			return
 		}
+		if err := p.checkBypassGovernance(r, &bck.Bck); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
 		if bck.IsRemote() {
 			p.invalmsghdlrf(w, r, "%q is not supported for remote buckets (%s)", msg.Action, bck)
 			return
@@ -1429,6 +1463,14 @@ func (p *proxyrunner) httpbckpatch(w http.ResponseWriter, r *http.Request) {
 	if err = cmn.ReadJSON(w, r, msg); err != nil {
 		return
 	}
+	if bck.IsRemoteAIS() && cmn.IsParseBool(query.Get(cmn.URLParamRemoteProps)) {
+		if err := p.checkPermissions(r.Header, &bck.Bck, cmn.AccessPATCH); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		p.reverseReqRemote(w, r, msg, bck.Bck)
+		return
+	}
 	if err = bck.InitNoBackend(p.owner.bmd, p.si); err != nil {
 		args := remBckAddArgs{p: p, w: w, r: r, queryBck: bck, err: err, msg: msg}
 		if bck, err = args.try(); err != nil {
@@ -1590,8 +1632,9 @@ func (p *proxyrunner) reverseReqRemote(w http.ResponseWriter, r *http.Request, m
 	var (
 		remoteUUID = bck.Ns.UUID
 		query      = r.URL.Query()
+		config     = cmn.GCO.Get()
 
-		v, configured = cmn.GCO.Get().Cloud.ProviderConf(cmn.ProviderAIS)
+		v, configured = config.Cloud.ProviderConf(cmn.ProviderAIS)
 	)
 
 	if !configured {
@@ -1619,6 +1662,11 @@ func (p *proxyrunner) reverseReqRemote(w http.ResponseWriter, r *http.Request, m
 		r.Body = ioutil.NopCloser(bytes.NewReader(body))
 	}
 
+	// the remote cluster may have its own AuthN - forward the token configured for the alias
+	if token, ok := config.Auth.RemoteTokens[remoteUUID]; ok {
+		r.Header.Set(cmn.HeaderAuthorization, cmn.MakeHeaderAuthnToken(token))
+	}
+
 	bck.Ns.UUID = ""
 	query = cmn.DelBckFromQuery(query)
 	query = cmn.AddBckToQuery(query, bck)
@@ -1627,6 +1675,20 @@ func (p *proxyrunner) reverseReqRemote(w http.ResponseWriter, r *http.Request, m
 	return nil
 }
 
+// destroy the bucket in the remote cluster and, upon success, remove it from the local BMD
+func (p *proxyrunner) destroyRemoteAIS(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg, bck *cluster.Bck) {
+	sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+	if err := p.reverseReqRemote(sw, r, msg, bck.Bck); err != nil || sw.status != http.StatusOK {
+		return
+	}
+	// (the response has been already written)
+	if err := p.destroyBucket(msg, bck); err != nil {
+		if _, ok := err.(*cmn.ErrorBucketDoesNotExist); !ok {
+			glog.Errorf("%s: remote %s destroyed but failed to remove it from BMD: %v", p.si, bck, err)
+		}
+	}
+}
+
 func (p *proxyrunner) listBuckets(w http.ResponseWriter, r *http.Request, query cmn.QueryBcks) {
 	bmd := p.owner.bmd.get()
 	if query.IsAIS() {
@@ -1737,7 +1799,7 @@ func (p *proxyrunner) listObjectsAIS(bck *cluster.Bck, smsg cmn.SelectMsg) (allE
 
 		hasEnough bool
 		entries   []*cmn.BucketEntry
-		cacheID   = cacheReqID{bck: bck.Bck, prefix: smsg.Prefix}
+		cacheID   = cacheReqID{bck: bck.Bck, prefix: smsg.Prefix, delimiter: smsg.Delimiter}
 		token     = smsg.ContinuationToken
 		pageSize  = smsg.PageSize
 		props     = smsg.PropsSet()
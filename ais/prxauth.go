@@ -8,8 +8,10 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3compat"
 	"github.com/NVIDIA/aistore/cmn"
 )
 
@@ -49,6 +51,11 @@ func (p *proxyrunner) validateToken(hdr http.Header) (*cmn.AuthToken, error) {
 		glog.Errorf("invalid token: %v", err)
 		return nil, errInvalidToken
 	}
+	// S3 access key is not a secret, so it cannot be used as a bearer token
+	if auth.S3Key {
+		glog.Errorf("invalid token: S3 access key of %q used as a bearer token", auth.UserID)
+		return nil, errInvalidToken
+	}
 
 	return auth, nil
 }
@@ -68,3 +75,42 @@ func (p *proxyrunner) checkPermissions(hdr http.Header, bck *cmn.Bck, perms cmn.
 	uid := p.owner.smap.Get().UUID
 	return token.CheckPermissions(uid, bck, perms)
 }
+
+// Bypassing governance mode retention (see cmn.LockModeGovernance) requires
+// admin user or admin permissions for the given bucket.
+func (p *proxyrunner) checkBypassGovernance(r *http.Request, bck *cmn.Bck) error {
+	if cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamBypassGovernance)) {
+		return p.checkPermissions(r.Header, bck, cmn.AccessADMIN)
+	}
+	if cmn.IsParseBool(r.Header.Get(s3compat.HeaderBypassGovernance)) {
+		return p.checkS3Permissions(r, bck, cmn.AccessADMIN)
+	}
+	return nil
+}
+
+// Validates AWS Signature V4 of an S3 request (header or presigned URL).
+// The request must be signed with S3 credentials issued by AuthN: the access
+// key is a token that holds user's permissions, and the secret key is derived
+// from the access key (see `cmn.S3SecretKey`).
+func (p *proxyrunner) checkS3Permissions(r *http.Request, bck *cmn.Bck, perms cmn.AccessAttrs) error {
+	if isIntraCall(r.Header) {
+		return nil
+	}
+	cfg := cmn.GCO.Get()
+	if !cfg.Auth.Enabled {
+		return nil
+	}
+	sig, err := s3compat.ParseSigV4(r)
+	if err != nil {
+		return err
+	}
+	token, err := p.authn.validateToken(sig.AccessKey)
+	if err != nil || !token.S3Key {
+		return errInvalidToken
+	}
+	if err := sig.Verify(r, cmn.S3SecretKey(sig.AccessKey, cfg.Auth.Secret), time.Now()); err != nil {
+		return err
+	}
+	uid := p.owner.smap.Get().UUID
+	return token.CheckPermissions(uid, bck, perms)
+}
@@ -5,16 +5,21 @@
 package ais
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/jsp"
 	"github.com/NVIDIA/aistore/downloader"
 	jsoniter "github.com/json-iterator/go"
 )
@@ -99,6 +104,9 @@ func (p *proxyrunner) broadcastDownloadAdminRequest(method, path string, msg *do
 				}
 			}
 
+			if err := p.listDlSchedules(msg, aggregate); err != nil {
+				return nil, http.StatusBadRequest, err
+			}
 			listDownloads := make(downloader.DlJobInfos, 0, len(aggregate))
 			for _, v := range aggregate {
 				listDownloads = append(listDownloads, v)
@@ -126,11 +134,10 @@ func (p *proxyrunner) broadcastDownloadAdminRequest(method, path string, msg *do
 	}
 }
 
-func (p *proxyrunner) broadcastStartDownloadRequest(r *http.Request, id string, body []byte) (errCode int, err error) {
-	query := r.URL.Query()
+func (p *proxyrunner) broadcastStartDownloadRequest(query url.Values, id string, body []byte) (errCode int, err error) {
 	query.Set(cmn.URLParamUUID, id)
 
-	responses := p.broadcastDownloadRequest(http.MethodPost, r.URL.Path, body, query)
+	responses := p.broadcastDownloadRequest(http.MethodPost, cmn.JoinWords(cmn.Version, cmn.Download), body, query)
 	failures := make([]error, 0, len(responses))
 	for resp := range responses {
 		if resp.err != nil {
@@ -151,6 +158,10 @@ func (p *proxyrunner) downloadHandler(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusServiceUnavailable)
 		return
 	}
+	if r.Method == http.MethodPut {
+		p.syncDlSchedules(w, r)
+		return
+	}
 	if err := p.checkPermissions(r.Header, nil, cmn.AccessDOWNLOAD); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
 		return
@@ -197,6 +208,10 @@ func (p *proxyrunner) httpDownloadAdmin(w http.ResponseWriter, r *http.Request)
 			cmn.InvalidHandlerWithMsg(w, r, s)
 			return
 		}
+		if items[0] == cmn.Remove && p.dls.Exists(payload.ID) {
+			p.removeDlSchedule(w, r, payload)
+			return
+		}
 	}
 
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -250,18 +265,170 @@ func (p *proxyrunner) httpDownloadPost(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// recurring jobs are run by the primary on schedule (each run being a separate job)
+	if dlBase.Schedule != "" {
+		r.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+		if p.forwardCP(w, r, nil, "schedule download") {
+			return
+		}
+		id := cmn.GenUUID()
+		if err := p.dls.Add(id, dlb); err != nil {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		p.respondWithID(w, id)
+		return
+	}
+
 	id := cmn.GenUUID()
 	smap := p.owner.smap.get()
 
-	if errCode, err := p.broadcastStartDownloadRequest(r, id, body); err != nil {
+	if errCode, err := p.broadcastStartDownloadRequest(r.URL.Query(), id, body); err != nil {
 		p.invalmsghdlrstatusf(w, r, errCode, "Error starting download: %v.", err.Error())
 		return
 	}
+	p.regDownloadNL(id, dlb, progressInterval, smap)
+
+	p.respondWithID(w, id)
+}
+
+func (p *proxyrunner) regDownloadNL(id string, dlb downloader.DlBody, progressInterval time.Duration, smap *smapX) {
 	nl := downloader.NewDownloadNL(id, string(dlb.Type), &smap.Smap, progressInterval)
 	nl.SetOwner(equalIC)
 	p.ic.registerEqual(regIC{nl: nl, smap: smap})
+}
 
-	p.respondWithID(w, id)
+/////////////////////////
+// scheduled downloads //
+/////////////////////////
+
+// Recurring download jobs (see downloader.Scheduler) are kept by all proxies:
+// the primary persists and replicates the jobs to the other proxies every
+// time they change and, on schedule, starts each run on the targets of the
+// current Smap. Each run is a regular download job registered with IC.
+
+const dlSchedFname = ".ais.dlsched" // persistent recurring download jobs
+
+type dlSchedRunner struct {
+	p *proxyrunner
+}
+
+// interface guard
+var _ downloader.SchedRunner = (*dlSchedRunner)(nil)
+
+func (p *proxyrunner) initDlSchedules(config *cmn.Config) {
+	var sjs downloader.ScheduledJobs
+	p.dls = downloader.NewScheduler(&dlSchedRunner{p: p})
+	if _, err := jsp.Load(filepath.Join(config.Confdir, dlSchedFname), &sjs, jsp.Plain()); err != nil {
+		if !os.IsNotExist(err) {
+			glog.Errorf("%s: failed to load scheduled downloads, err: %v", p.si, err)
+		}
+		return
+	}
+	p.dls.Sync(sjs)
+	glog.Infof("%s: loaded %d scheduled download(s)", p.si, len(sjs))
+}
+
+func (p *proxyrunner) saveDlSchedules(sjs downloader.ScheduledJobs) {
+	fpath := filepath.Join(cmn.GCO.Get().Confdir, dlSchedFname)
+	if err := jsp.Save(fpath, sjs, jsp.Plain()); err != nil {
+		glog.Errorf("%s: failed to persist scheduled downloads, err: %v", p.si, err)
+	}
+}
+
+// list scheduled downloads along with their most recent runs (regular jobs)
+func (p *proxyrunner) listDlSchedules(msg *downloader.DlAdminBody, jobs map[string]*downloader.DlJobInfo) error {
+	var regex *regexp.Regexp
+	if msg.Regex != "" {
+		var err error
+		if regex, err = regexp.CompilePOSIX(msg.Regex); err != nil {
+			return err
+		}
+	}
+	for _, info := range p.dls.List(regex) {
+		info := info
+		if last := info.Schedule.LastJob; last != nil {
+			if job, ok := jobs[last.ID]; ok {
+				lastJob := *job
+				info.Schedule.LastJob = &lastJob
+			}
+		}
+		jobs[info.ID] = &info
+	}
+	return nil
+}
+
+// DELETE /v1/download/remove (scheduled download)
+func (p *proxyrunner) removeDlSchedule(w http.ResponseWriter, r *http.Request, msg *downloader.DlAdminBody) {
+	r.Body = ioutil.NopCloser(bytes.NewBuffer(cmn.MustMarshal(msg)))
+	if p.forwardCP(w, r, nil, "remove scheduled download") {
+		return
+	}
+	if !p.dls.Remove(msg.ID) {
+		p.invalmsghdlr(w, r, fmt.Sprintf("download job %q not found", msg.ID), http.StatusNotFound)
+	}
+}
+
+// PUT /v1/download (intra-cluster): scheduled downloads updated by the primary
+func (p *proxyrunner) syncDlSchedules(w http.ResponseWriter, r *http.Request) {
+	var (
+		sjs  downloader.ScheduledJobs
+		smap = p.owner.smap.get()
+	)
+	if !isIntraCall(r.Header) || smap.isPrimary(p.si) || r.Header.Get(cmn.HeaderCallerID) != smap.Primary.ID() {
+		p.invalmsghdlrf(w, r, "%s: scheduled downloads are expected to be updated by the primary", p.si)
+		return
+	}
+	if err := cmn.ReadJSON(w, r, &sjs); err != nil {
+		return
+	}
+	p.dls.Sync(sjs)
+	p.saveDlSchedules(sjs)
+}
+
+func (r *dlSchedRunner) IsPrimary() bool { return r.p.owner.smap.get().isPrimary(r.p.si) }
+
+func (r *dlSchedRunner) StartRun(childID string, dlb downloader.DlBody) error {
+	var (
+		dlBase           downloader.DlBase
+		progressInterval = downloader.DownloadProgressInterval
+		smap             = r.p.owner.smap.get()
+	)
+	if err := jsoniter.Unmarshal(dlb.RawMessage, &dlBase); err != nil {
+		return err
+	}
+	if dur, err := time.ParseDuration(dlBase.ProgressInterval); err == nil {
+		progressInterval = dur
+	}
+	if _, err := r.p.broadcastStartDownloadRequest(url.Values{}, childID, cmn.MustMarshal(dlb)); err != nil {
+		return err
+	}
+	r.p.regDownloadNL(childID, dlb, progressInterval, smap)
+	return nil
+}
+
+func (r *dlSchedRunner) RunInProgress(childID string) bool {
+	nl, exists := r.p.notifs.entry(childID)
+	return exists && !nl.Finished()
+}
+
+func (r *dlSchedRunner) SaveSchedules(sjs downloader.ScheduledJobs) {
+	r.p.saveDlSchedules(sjs)
+	if !r.IsPrimary() {
+		return
+	}
+	body := cmn.MustMarshal(sjs)
+	go func() {
+		results := r.p.bcastToGroup(bcastArgs{
+			req: cmn.ReqArgs{Method: http.MethodPut, Path: cmn.JoinWords(cmn.Version, cmn.Download), Body: body},
+			to:  cluster.Proxies,
+		})
+		for res := range results {
+			if res.err != nil {
+				glog.Errorf("%s: failed to update scheduled downloads at %s, err: %v", r.p.si, res.si, res.err)
+			}
+		}
+	}()
 }
 
 // Helper methods
@@ -278,6 +445,10 @@ func (p *proxyrunner) validateStartDownloadRequest(w http.ResponseWriter, r *htt
 		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := dlBase.Validate(); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
 	bck := cluster.NewBckEmbed(dlBase.Bck)
 	if err := bck.Init(p.owner.bmd, p.si); err != nil {
 		args := remBckAddArgs{p: p, w: w, r: r, queryBck: bck, err: err}
@@ -85,8 +85,9 @@ type (
 	// Cache request ID. This identifies and splits requests into
 	// multiple caches that these requests can use.
 	cacheReqID struct {
-		bck    cmn.Bck
-		prefix string
+		bck       cmn.Bck
+		prefix    string
+		delimiter string
 	}
 
 	// Single (contiguous) interval of entries.
@@ -170,6 +171,17 @@ func (b *queryBuffer) mergeTargetBuffers() (filled bool) {
 
 	cmn.SortBckEntries(entries)
 
+	// When rolling up by delimiter the same "directory" may come from many targets.
+	j := 0
+	for _, entry := range entries {
+		if j > 0 && entry.IsDir() && entries[j-1].Name == entry.Name {
+			continue
+		}
+		entries[j] = entry
+		j++
+	}
+	entries = entries[:j]
+
 	if minObj != "" {
 		idx := sort.Search(len(entries), func(i int) bool {
 			return entries[i].Name > minObj
@@ -460,8 +472,9 @@ func (c *queryCaches) get(reqID cacheReqID, token string, objCnt uint) (entries
 	}
 
 	// When `prefix` is requested we must also check if there is enough entries
-	// in the "main" (whole bucket) cache with given prefix.
-	if reqID.prefix != "" {
+	// in the "main" (whole bucket) cache with given prefix. NOTE: the entries
+	// rolled up by delimiter cannot be found in the "main" cache.
+	if reqID.prefix != "" && reqID.delimiter == "" {
 		// We must adjust parameters and cache id.
 		params := reqParams{prefix: reqID.prefix}
 		reqID = cacheReqID{bck: reqID.bck}
@@ -451,5 +451,22 @@ var _ = Describe("QueryCache+QueryBuffer", func() {
 			_, hasEnough = buffer.get(id, "f", 1)
 			Expect(hasEnough).To(BeFalse())
 		})
+
+		It("should deduplicate directories returned by many targets", func() {
+			makeDirs := func(xs ...string) []*cmn.BucketEntry {
+				entries := makeEntries(xs...)
+				for _, entry := range entries {
+					entry.Flags = cmn.EntryIsDir
+				}
+				return entries
+			}
+			buffer.set(id, "target1", append(makeEntries("a"), makeDirs("b/", "c/")...), 4)
+			buffer.set(id, "target2", makeDirs("b/", "c/", "d/"), 4)
+			buffer.set(id, "target3", makeDirs("c/"), 4)
+
+			entries, hasEnough := buffer.get(id, "", 5)
+			Expect(hasEnough).To(BeTrue())
+			Expect(extractNames(entries)).To(Equal([]string{"a", "b/", "c/", "d/"}))
+		})
 	})
 })
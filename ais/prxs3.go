@@ -32,6 +32,30 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 	// TODO: Fix the hack, https://github.com/tensorflow/tensorflow/issues/41798
 	cmn.ReparseQuery(r)
 
+	if len(apiItems) > 1 {
+		q := r.URL.Query()
+		_, tagging := q[s3compat.URLParamTagging]
+		_, retention := q[s3compat.URLParamRetention]
+		_, legalHold := q[s3compat.URLParamLegalHold]
+		if tagging || retention || legalHold {
+			p.objMetaS3(w, r, apiItems)
+			return
+		}
+	}
+	if len(apiItems) == 1 {
+		if _, lifecycle := r.URL.Query()[s3compat.URLParamLifecycle]; lifecycle {
+			p.bckLifecycleS3(w, r, apiItems[0])
+			return
+		}
+		if _, objLock := r.URL.Query()[s3compat.URLParamObjectLock]; objLock {
+			p.bckObjectLockS3(w, r, apiItems[0])
+			return
+		}
+		if _, encryption := r.URL.Query()[s3compat.URLParamEncryption]; encryption {
+			p.bckEncryptionS3(w, r, apiItems[0])
+			return
+		}
+	}
 	switch r.Method {
 	case http.MethodHead:
 		if len(apiItems) == 0 {
@@ -46,7 +70,7 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet:
 		if len(apiItems) == 0 {
 			// nothing  - list all the buckets
-			p.bckNamesToS3(w)
+			p.bckNamesToS3(w, r)
 			return
 		}
 		if len(apiItems) == 1 {
@@ -56,6 +80,10 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.getBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if _, location := q[s3compat.URLParamLocation]; location {
+				p.getBckLocationS3(w, r, apiItems[0])
+				return
+			}
 			// only bucket name - list objects in the bucket
 			p.bckListS3(w, r, apiItems[0])
 			return
@@ -79,11 +107,21 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 		}
 		p.putObjS3(w, r, apiItems)
 	case http.MethodPost:
+		q := r.URL.Query()
+		if len(apiItems) > 1 {
+			_, start := q[s3compat.URLParamMultipartUploads]
+			_, complete := q[s3compat.URLParamMultipartUploadID]
+			if !start && !complete {
+				p.invalmsghdlr(w, r, "invalid request")
+				return
+			}
+			p.mptUploadS3(w, r, apiItems)
+			return
+		}
 		if len(apiItems) != 1 {
 			p.invalmsghdlr(w, r, "bucket name expected")
 			return
 		}
-		q := r.URL.Query()
 		if _, multiple := q[s3compat.URLParamMultiDelete]; !multiple {
 			p.invalmsghdlr(w, r, "invalid request")
 			return
@@ -111,7 +149,11 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 }
 
 // GET s3/
-func (p *proxyrunner) bckNamesToS3(w http.ResponseWriter) {
+func (p *proxyrunner) bckNamesToS3(w http.ResponseWriter, r *http.Request) {
+	if err := p.checkS3Permissions(r, nil, cmn.AccessBckLIST); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	bmd := p.owner.bmd.get()
 	query := cmn.QueryBcks{Provider: cmn.ProviderAIS}
 	cp := &query.Provider
@@ -136,6 +178,10 @@ func (p *proxyrunner) putBckS3(w http.ResponseWriter, r *http.Request, bucket st
 		p.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if err := p.checkS3Permissions(r, nil, cmn.AccessBckCREATE); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	msg := cmn.ActionMsg{Action: cmn.ActCreateLB}
 	if p.forwardCP(w, r, &msg, bucket) {
 		return
@@ -158,6 +204,10 @@ func (p *proxyrunner) delBckS3(w http.ResponseWriter, r *http.Request, bucket st
 		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
 		return
 	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessBckDELETE); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	if err := bck.Allow(cmn.AccessBckDELETE); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
@@ -184,10 +234,18 @@ func (p *proxyrunner) delMultipleObjs(w http.ResponseWriter, r *http.Request, bu
 		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
 		return
 	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessObjDELETE); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	if err := bck.Allow(cmn.AccessObjDELETE); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
+	if err := s3compat.VerifyPayload(r); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
 	decoder := xml.NewDecoder(r.Body)
 	objList := &s3compat.Delete{}
 	if err := decoder.Decode(objList); err != nil {
@@ -223,6 +281,10 @@ func (p *proxyrunner) headBckS3(w http.ResponseWriter, r *http.Request, bucket s
 		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
 		return
 	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessBckHEAD); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	if err := bck.Allow(cmn.AccessBckHEAD); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
@@ -246,9 +308,16 @@ func (p *proxyrunner) bckListS3(w http.ResponseWriter, r *http.Request, bucket s
 		p.invalmsghdlr(w, r, err.Error())
 		return
 	}
-	smsg := cmn.SelectMsg{TimeFormat: time.RFC3339}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessObjLIST); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	var (
+		query = r.URL.Query()
+		smsg  = cmn.SelectMsg{TimeFormat: time.RFC3339}
+	)
 	smsg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum, cmn.GetPropsAtime, cmn.GetPropsVersion)
-	s3compat.FillMsgFromS3Query(r.URL.Query(), &smsg)
+	s3compat.FillMsgFromS3Query(query, &smsg)
 
 	objList, err := p.listObjectsAIS(bck, smsg)
 	if err != nil {
@@ -256,8 +325,7 @@ func (p *proxyrunner) bckListS3(w http.ResponseWriter, r *http.Request, bucket s
 		return
 	}
 
-	resp := s3compat.NewListObjectResult()
-	resp.ContinuationToken = smsg.ContinuationToken
+	resp := s3compat.NewListObjectResult(bucket, query)
 	resp.FillFromAisBckList(objList)
 	b := resp.MustMarshal()
 	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
@@ -279,6 +347,10 @@ func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []
 		p.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if err := p.checkS3Permissions(r, &bckSrc.Bck, cmn.AccessGET); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	if err := bckSrc.Allow(cmn.AccessGET); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
@@ -297,6 +369,10 @@ func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []
 		smap = p.owner.smap.get()
 		err  error
 	)
+	if err := p.checkS3Permissions(r, &bckDst.Bck, cmn.AccessPUT); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	if err = bckDst.Allow(cmn.AccessPUT); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
@@ -335,17 +411,25 @@ func (p *proxyrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, ite
 		p.invalmsghdlr(w, r, "object name is undefined")
 		return
 	}
+	if err := s3compat.CheckPayload(r.Header); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotImplemented)
+		return
+	}
 	var (
 		si   *cluster.Snode
 		smap = p.owner.smap.get()
 		err  error
 	)
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessPUT); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	if err = bck.Allow(cmn.AccessPUT); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
 	objName := path.Join(items[1:]...)
-	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	si, err = mptTargetS3(r, bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
 		p.invalmsghdlr(w, r, err.Error())
 		return
@@ -383,13 +467,17 @@ func (p *proxyrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []s
 		smap = p.owner.smap.get()
 		err  error
 	)
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessGET); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	if err = bck.Allow(cmn.AccessGET); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
 	objName := path.Join(items[1:]...)
 
-	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	si, err = mptTargetS3(r, bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
 		p.invalmsghdlr(w, r, err.Error())
 		return
@@ -413,6 +501,10 @@ func (p *proxyrunner) headObjS3(w http.ResponseWriter, r *http.Request, items []
 		p.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessObjHEAD); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	if err := bck.Allow(cmn.AccessObjHEAD); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
@@ -430,6 +522,52 @@ func (p *proxyrunner) headObjS3(w http.ResponseWriter, r *http.Request, items []
 	s3Redirect(w, redirectURL, bck.Name)
 }
 
+// Requests of a multipart upload go to the target the upload has started on
+// (see s3compat.NewUploadID), while the target is in the cluster.
+func mptTargetS3(r *http.Request, uname string, smap *cluster.Smap) (*cluster.Snode, error) {
+	if uploadID := r.URL.Query().Get(s3compat.URLParamMultipartUploadID); uploadID != "" {
+		if si := smap.GetTarget(s3compat.UploadTarget(uploadID)); si != nil {
+			return si, nil
+		}
+	}
+	return cluster.HrwTarget(uname, smap)
+}
+
+// POST s3/bckName/objName?uploads
+// POST s3/bckName/objName?uploadId=ID
+// Start or complete multipart upload (the rest of multipart requests are
+// redirected as regular PUT, GET, and DELETE object requests)
+func (p *proxyrunner) mptUploadS3(w http.ResponseWriter, r *http.Request, items []string) {
+	started := time.Now()
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessPUT); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := bck.Allow(cmn.AccessPUT); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	var (
+		smap    = p.owner.smap.get()
+		objName = path.Join(items[1:]...)
+	)
+	si, err := mptTargetS3(r, bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("AISS3: %s %s/%s => %s", r.Method, bck, objName, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
+	s3Redirect(w, redirectURL, bck.Name)
+}
+
 // DEL s3/bckName/objName
 func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	started := time.Now()
@@ -447,12 +585,68 @@ func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []s
 		smap = p.owner.smap.get()
 		err  error
 	)
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessObjDELETE); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := p.checkBypassGovernance(r, &bck.Bck); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	if err = bck.Allow(cmn.AccessObjDELETE); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
 	objName := path.Join(items[1:]...)
-	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	si, err = mptTargetS3(r, bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("AISS3: %s %s/%s => %s", r.Method, bck, objName, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
+	s3Redirect(w, redirectURL, bck.Name)
+}
+
+// [GET|PUT|DEL] s3/bckName/objName?tagging
+// [GET|PUT] s3/bckName/objName?retention
+// [GET|PUT] s3/bckName/objName?legal-hold
+func (p *proxyrunner) objMetaS3(w http.ResponseWriter, r *http.Request, items []string) {
+	var perms int
+	switch r.Method {
+	case http.MethodGet:
+		perms = cmn.AccessObjHEAD
+	case http.MethodPut, http.MethodDelete:
+		perms = cmn.AccessPUT
+	default:
+		p.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
+		return
+	}
+	started := time.Now()
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessAttrs(perms)); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := p.checkBypassGovernance(r, &bck.Bck); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := bck.Allow(perms); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	var (
+		smap    = p.owner.smap.get()
+		objName = path.Join(items[1:]...)
+	)
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
 		p.invalmsghdlr(w, r, err.Error())
 		return
@@ -464,6 +658,23 @@ func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []s
 	s3Redirect(w, redirectURL, bck.Name)
 }
 
+// GET s3/bk-name?location
+func (p *proxyrunner) getBckLocationS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessBckHEAD); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	resp := s3compat.NewLocationConstraint()
+	b := resp.MustMarshal()
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(b)
+}
+
 // GET s3/bk-name?versioning
 func (p *proxyrunner) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucket string) {
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
@@ -471,6 +682,10 @@ func (p *proxyrunner) getBckVersioningS3(w http.ResponseWriter, r *http.Request,
 		p.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessBckHEAD); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	resp := s3compat.NewVersioningConfiguration(bck.Props.Versioning.Enabled)
 	b := resp.MustMarshal()
 	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
@@ -493,6 +708,14 @@ func (p *proxyrunner) putBckVersioningS3(w http.ResponseWriter, r *http.Request,
 		p.invalmsghdlr(w, r, "bucket does not exists", http.StatusNotFound)
 		return
 	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessPATCH); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := s3compat.VerifyPayload(r); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
 	decoder := xml.NewDecoder(r.Body)
 	defer cmn.Close(r.Body)
 	vconf := &s3compat.VersioningConfiguration{}
@@ -508,3 +731,217 @@ func (p *proxyrunner) putBckVersioningS3(w http.ResponseWriter, r *http.Request,
 		p.invalmsghdlr(w, r, err.Error())
 	}
 }
+
+// [GET|PUT|DEL] s3/bk-name?lifecycle
+func (p *proxyrunner) bckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	switch r.Method {
+	case http.MethodGet:
+		p.getBckLifecycleS3(w, r, bucket)
+	case http.MethodPut, http.MethodDelete:
+		p.setBckLifecycleS3(w, r, bucket)
+	default:
+		p.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
+	}
+}
+
+// GET s3/bk-name?lifecycle
+func (p *proxyrunner) getBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessBckHEAD); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	lifecycle := &bck.Props.Lifecycle
+	if !lifecycle.Enabled || len(lifecycle.Rules) == 0 {
+		p.invalmsghdlrsilent(w, r, s3compat.ErrNoLifecycle.Error(), http.StatusNotFound)
+		return
+	}
+	resp := s3compat.NewLifecycleConfiguration(lifecycle.Rules)
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(resp.MustMarshal())
+}
+
+// PUT s3/bk-name?lifecycle - replace the lifecycle rules
+// DELETE s3/bk-name?lifecycle - remove all the rules
+func (p *proxyrunner) setBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &cmn.ActionMsg{Action: cmn.ActSetBprops}
+	if p.forwardCP(w, r, msg, bucket) {
+		return
+	}
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessPATCH); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	var (
+		rules   = cmn.LifecycleRules{}
+		enabled = r.Method == http.MethodPut
+	)
+	if enabled {
+		var err error
+		if err := s3compat.VerifyPayload(r); err != nil {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		rules, err = s3compat.ParseLifecycle(r.Body)
+		cmn.Close(r.Body)
+		if err != nil {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+	}
+	propsToUpdate := cmn.BucketPropsToUpdate{
+		Lifecycle: &cmn.LifecycleConfToUpdate{Rules: &rules, Enabled: &enabled},
+	}
+	if _, err := p.setBucketProps(w, r, msg, bck, propsToUpdate); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if !enabled {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// [GET|PUT] s3/bk-name?object-lock
+func (p *proxyrunner) bckObjectLockS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	switch r.Method {
+	case http.MethodGet:
+		p.getBckObjectLockS3(w, r, bucket)
+	case http.MethodPut:
+		p.putBckObjectLockS3(w, r, bucket)
+	default:
+		p.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
+	}
+}
+
+// GET s3/bk-name?object-lock
+func (p *proxyrunner) getBckObjectLockS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessBckHEAD); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if !bck.Props.ObjectLock.Enabled {
+		p.invalmsghdlrsilent(w, r, s3compat.ErrNoObjectLock.Error(), http.StatusNotFound)
+		return
+	}
+	resp := s3compat.NewObjectLockConfiguration(&bck.Props.ObjectLock)
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(resp.MustMarshal())
+}
+
+// PUT s3/bk-name?object-lock - enable object lock and set default retention
+func (p *proxyrunner) putBckObjectLockS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &cmn.ActionMsg{Action: cmn.ActSetBprops}
+	if p.forwardCP(w, r, msg, bucket) {
+		return
+	}
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessPATCH); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := s3compat.VerifyPayload(r); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	objLock, err := s3compat.ParseObjectLock(r.Body)
+	cmn.Close(r.Body)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	propsToUpdate := cmn.BucketPropsToUpdate{ObjectLock: objLock}
+	if _, err := p.setBucketProps(w, r, msg, bck, propsToUpdate); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+	}
+}
+
+// [GET|PUT|DEL] s3/bk-name?encryption
+func (p *proxyrunner) bckEncryptionS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	switch r.Method {
+	case http.MethodGet:
+		p.getBckEncryptionS3(w, r, bucket)
+	case http.MethodPut, http.MethodDelete:
+		p.setBckEncryptionS3(w, r, bucket)
+	default:
+		p.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
+	}
+}
+
+// GET s3/bk-name?encryption
+func (p *proxyrunner) getBckEncryptionS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessBckHEAD); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if !bck.Props.Encryption.Enabled {
+		p.invalmsghdlrsilent(w, r, s3compat.ErrNoEncryption.Error(), http.StatusNotFound)
+		return
+	}
+	resp := s3compat.NewEncryptionConfiguration(&bck.Props.Encryption)
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(resp.MustMarshal())
+}
+
+// PUT s3/bk-name?encryption - enable default encryption of new objects
+// DELETE s3/bk-name?encryption - disable it (existing objects remain encrypted)
+func (p *proxyrunner) setBckEncryptionS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &cmn.ActionMsg{Action: cmn.ActSetBprops}
+	if p.forwardCP(w, r, msg, bucket) {
+		return
+	}
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err := p.checkS3Permissions(r, &bck.Bck, cmn.AccessPATCH); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	enabled := false
+	encryption := &cmn.EncryptionConfToUpdate{Enabled: &enabled}
+	if r.Method == http.MethodPut {
+		var err error
+		if err := s3compat.VerifyPayload(r); err != nil {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		encryption, err = s3compat.ParseEncryption(r.Body)
+		cmn.Close(r.Body)
+		if err != nil {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+	}
+	propsToUpdate := cmn.BucketPropsToUpdate{Encryption: encryption}
+	if _, err := p.setBucketProps(w, r, msg, bck, propsToUpdate); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
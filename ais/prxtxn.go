@@ -256,6 +256,15 @@ func (p *proxyrunner) setBucketProps(w http.ResponseWriter, r *http.Request, msg
 			}
 		}
 
+		// Make sure that replication destination exists (see cmn.RemoteReplConf).
+		if nprops.Replication.Enabled {
+			dstBck := cluster.NewBckEmbed(nprops.Replication.DstBck())
+			args := remBckAddArgs{p: p, w: w, r: r, queryBck: dstBck, err: err, msg: msg}
+			if _, err = args.initAndTry(dstBck.Name); err != nil {
+				return
+			}
+		}
+
 		// Make sure that backend bucket was initialized correctly.
 		if err = p.checkBackendBck(nprops); err != nil {
 			return
@@ -862,6 +871,23 @@ func (p *proxyrunner) makeNprops(bck *cluster.Bck, propsToUpdate cmn.BucketProps
 			return
 		}
 	}
+	if !bprops.Versioning.KeepPrevious && nprops.Versioning.KeepPrevious && !bck.IsAIS() {
+		err = fmt.Errorf("%s: keeping previous object versions is supported only for ais buckets (%s)", p.si, bck)
+		return
+	}
+	if nprops.ObjectLock.Enabled {
+		if !bprops.ObjectLock.Enabled && !bck.IsAIS() {
+			err = fmt.Errorf("%s: object lock is supported only for ais buckets (%s)", p.si, bck)
+			return
+		}
+	} else if bprops.ObjectLock.Enabled {
+		err = fmt.Errorf("%s: once enabled, object lock cannot be disabled (%s)", p.si, bck)
+		return
+	}
+	if nprops.Encryption.Enabled && cfg.KMS.Provider == "" {
+		err = fmt.Errorf("%s: server-side encryption requires KMS to be configured (%s)", p.si, bck)
+		return
+	}
 	if bprops.EC.Enabled && nprops.EC.Enabled {
 		if !reflect.DeepEqual(bprops.EC, nprops.EC) {
 			err = fmt.Errorf("%s: once enabled, EC configuration can be only disabled but cannot change", p.si)
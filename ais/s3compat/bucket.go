@@ -32,6 +32,13 @@ type (
 		Created string `xml:"CreationDate"`
 	}
 
+	// Bucket location (region)
+	LocationConstraint struct {
+		XMLName  xml.Name `xml:"LocationConstraint"`
+		Ns       string   `xml:"xmlns,attr"`
+		Location string   `xml:",chardata"`
+	}
+
 	// Bucket versioning
 	VersioningConfiguration struct {
 		Status string `xml:"Status"`
@@ -50,15 +57,19 @@ type (
 
 func NewListBucketResult() *ListBucketResult {
 	return &ListBucketResult{
-		Ns: s3Namespace,
-		Owner: BckOwner{ // TODO:
-			ID:   "1",
-			Name: "ais",
-		},
+		Ns:      s3Namespace,
+		Owner:   *newBckOwner(),
 		Buckets: make([]*Bucket, 0),
 	}
 }
 
+func newBckOwner() *BckOwner {
+	return &BckOwner{ // TODO:
+		ID:   "1",
+		Name: "ais",
+	}
+}
+
 func bckToS3(bck *cluster.Bck) *Bucket {
 	created := time.Unix(0, bck.Props.Created)
 	if created.Before(defaultDate) {
@@ -83,6 +94,16 @@ func (r *ListBucketResult) Add(bck *cluster.Bck) {
 	r.Buckets = append(r.Buckets, bckToS3(bck))
 }
 
+func NewLocationConstraint() *LocationConstraint {
+	return &LocationConstraint{Ns: s3Namespace, Location: AISRegion}
+}
+
+func (r *LocationConstraint) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
 func NewVersioningConfiguration(enabled bool) *VersioningConfiguration {
 	if enabled {
 		return &VersioningConfiguration{Status: versioningEnabled}
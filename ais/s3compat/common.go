@@ -17,9 +17,29 @@ const (
 	// versioning
 	URLParamVersioning  = "versioning" // URL parameter
 	URLParamMultiDelete = "delete"
+	URLParamLocation    = "location"
 	versioningEnabled   = "Enabled"
 	versioningDisabled  = "Suspended"
 
+	// multipart upload
+	URLParamMultipartUploads  = "uploads"
+	URLParamMultipartUploadID = "uploadId"
+	URLParamMultipartPartNo   = "partNumber"
+	URLParamMultipartMaxParts = "max-parts"
+	URLParamMultipartMarker   = "part-number-marker"
+
+	// list objects
+	URLParamListType          = "list-type"
+	URLParamPrefix            = "prefix"
+	URLParamDelimiter         = "delimiter"
+	URLParamMaxKeys           = "max-keys"
+	URLParamMarker            = "marker"
+	URLParamContinuationToken = "continuation-token"
+	URLParamStartAfter        = "start-after"
+	URLParamFetchOwner        = "fetch-owner"
+	listTypeV2                = "2"
+	defaultMaxKeys            = 1000
+
 	s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01"
 	// TODO: can it be omitted? // storageClass = "STANDARD"
 
@@ -29,6 +49,12 @@ const (
 	HeaderObjSrc  = "x-amz-copy-source"
 
 	headerAtime = "Last-Modified"
+
+	// conditional requests
+	headerIfMatch           = "If-Match"
+	headerIfNoneMatch       = "If-None-Match"
+	headerIfModifiedSince   = "If-Modified-Since"
+	headerIfUnmodifiedSince = "If-Unmodified-Since"
 )
 
 // ExtractEndpoint extracts an S3 endpoint from the full URL path.
@@ -0,0 +1,172 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// S3 bucket lifecycle configuration is mapped to `lifecycle` bucket
+// properties (see cmn.LifecycleConf):
+//   - Expiration.Days                            => expire_days
+//   - NoncurrentVersionExpiration.NoncurrentDays => noncurrent_days
+//   - Transition.Days                            => evict_days (the storage class is
+//     ignored: AIS "transitions" an object by evicting its cached copy, so that
+//     the object remains only in the backend bucket)
+// Filtering by tags, expiration and transition dates, and aborting incomplete
+// multipart uploads are not supported.
+
+const (
+	URLParamLifecycle = "lifecycle"
+
+	lifecycleEnabled  = "Enabled"
+	lifecycleDisabled = "Disabled"
+)
+
+type (
+	// GetBucketLifecycleConfiguration response and PutBucketLifecycleConfiguration request body
+	LifecycleConfiguration struct {
+		XMLName xml.Name         `xml:"LifecycleConfiguration"`
+		Ns      string           `xml:"xmlns,attr,omitempty"`
+		Rules   []*LifecycleRule `xml:"Rule"`
+	}
+	LifecycleRule struct {
+		ID     string           `xml:"ID,omitempty"`
+		Prefix *string          `xml:"Prefix"` // deprecated by S3, same as Filter.Prefix
+		Filter *LifecycleFilter `xml:"Filter"`
+		Status string           `xml:"Status"`
+
+		Expiration                  *LifecycleExpiration         `xml:"Expiration"`
+		NoncurrentVersionExpiration *NoncurrentVersionExpiration `xml:"NoncurrentVersionExpiration"`
+		Transitions                 []*LifecycleTransition       `xml:"Transition"`
+
+		// not supported
+		AbortIncompleteMultipartUpload *struct{} `xml:"AbortIncompleteMultipartUpload"`
+	}
+	LifecycleFilter struct {
+		Prefix string `xml:"Prefix"`
+		// not supported
+		Tag *Tag      `xml:"Tag"`
+		And *struct{} `xml:"And"`
+	}
+	LifecycleExpiration struct {
+		Days int    `xml:"Days,omitempty"`
+		Date string `xml:"Date,omitempty"` // not supported
+	}
+	NoncurrentVersionExpiration struct {
+		NoncurrentDays int `xml:"NoncurrentDays"`
+	}
+	LifecycleTransition struct {
+		Days         int    `xml:"Days,omitempty"`
+		Date         string `xml:"Date,omitempty"` // not supported
+		StorageClass string `xml:"StorageClass,omitempty"`
+	}
+)
+
+var ErrNoLifecycle = errors.New("the lifecycle configuration does not exist")
+
+// ParseLifecycle decodes PutBucketLifecycleConfiguration request body and
+// converts it to the bucket lifecycle rules.
+func ParseLifecycle(r io.Reader) (cmn.LifecycleRules, error) {
+	lc := &LifecycleConfiguration{}
+	if err := xml.NewDecoder(r).Decode(lc); err != nil {
+		return nil, err
+	}
+	if len(lc.Rules) == 0 {
+		return nil, errors.New("lifecycle configuration must contain at least one rule")
+	}
+	rules := make(cmn.LifecycleRules, 0, len(lc.Rules))
+	for _, r := range lc.Rules {
+		rule, err := r.toAIS()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (r *LifecycleRule) toAIS() (rule cmn.LifecycleRule, err error) {
+	rule.ID = r.ID
+	switch r.Status {
+	case lifecycleEnabled:
+	case lifecycleDisabled:
+		rule.Disabled = true
+	default:
+		return rule, fmt.Errorf("rule %q: invalid status %q", r.ID, r.Status)
+	}
+	if r.AbortIncompleteMultipartUpload != nil {
+		return rule, fmt.Errorf("rule %q: AbortIncompleteMultipartUpload is not supported", r.ID)
+	}
+	if r.Prefix != nil {
+		rule.Prefix = *r.Prefix
+	}
+	if f := r.Filter; f != nil {
+		if f.Tag != nil || f.And != nil {
+			return rule, fmt.Errorf("rule %q: only prefix filter is supported", r.ID)
+		}
+		rule.Prefix = f.Prefix
+	}
+	if e := r.Expiration; e != nil {
+		if e.Date != "" {
+			return rule, fmt.Errorf("rule %q: expiration date is not supported, use days", r.ID)
+		}
+		rule.ExpireDays = e.Days
+	}
+	if e := r.NoncurrentVersionExpiration; e != nil {
+		rule.NoncurrentDays = e.NoncurrentDays
+	}
+	switch len(r.Transitions) {
+	case 0:
+	case 1:
+		if r.Transitions[0].Date != "" {
+			return rule, fmt.Errorf("rule %q: transition date is not supported, use days", r.ID)
+		}
+		rule.EvictDays = r.Transitions[0].Days
+	default:
+		return rule, fmt.Errorf("rule %q: only one transition is supported", r.ID)
+	}
+	return rule, nil
+}
+
+// NewLifecycleConfiguration converts the bucket lifecycle rules to S3 format.
+func NewLifecycleConfiguration(rules cmn.LifecycleRules) *LifecycleConfiguration {
+	lc := &LifecycleConfiguration{Ns: s3Namespace, Rules: make([]*LifecycleRule, 0, len(rules))}
+	for i := range rules {
+		var (
+			rule = &rules[i]
+			r    = &LifecycleRule{
+				ID:     rule.ID,
+				Filter: &LifecycleFilter{Prefix: rule.Prefix},
+				Status: lifecycleEnabled,
+			}
+		)
+		if rule.Disabled {
+			r.Status = lifecycleDisabled
+		}
+		if rule.ExpireDays > 0 {
+			r.Expiration = &LifecycleExpiration{Days: rule.ExpireDays}
+		}
+		if rule.NoncurrentDays > 0 {
+			r.NoncurrentVersionExpiration = &NoncurrentVersionExpiration{NoncurrentDays: rule.NoncurrentDays}
+		}
+		if rule.EvictDays > 0 {
+			r.Transitions = []*LifecycleTransition{{Days: rule.EvictDays}}
+		}
+		lc.Rules = append(lc.Rules, r)
+	}
+	return lc
+}
+
+func (lc *LifecycleConfiguration) MustMarshal() []byte {
+	b, err := xml.Marshal(lc)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
@@ -0,0 +1,60 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestParseLifecycle(t *testing.T) {
+	const body = `<LifecycleConfiguration>
+		<Rule>
+			<ID>logs</ID>
+			<Filter><Prefix>logs/</Prefix></Filter>
+			<Status>Enabled</Status>
+			<Expiration><Days>7</Days></Expiration>
+			<NoncurrentVersionExpiration><NoncurrentDays>1</NoncurrentDays></NoncurrentVersionExpiration>
+		</Rule>
+		<Rule>
+			<ID>cold</ID>
+			<Prefix>data/</Prefix>
+			<Status>Disabled</Status>
+			<Transition><Days>30</Days><StorageClass>GLACIER</StorageClass></Transition>
+		</Rule>
+	</LifecycleConfiguration>`
+	rules, err := ParseLifecycle(strings.NewReader(body))
+	tassert.CheckFatal(t, err)
+	expected := cmn.LifecycleRules{
+		{ID: "logs", Prefix: "logs/", ExpireDays: 7, NoncurrentDays: 1},
+		{ID: "cold", Prefix: "data/", EvictDays: 30, Disabled: true},
+	}
+	tassert.Fatalf(t, len(rules) == len(expected), "expected %d rules, got %d", len(expected), len(rules))
+	for i := range expected {
+		tassert.Errorf(t, rules[i] == expected[i], "expected %+v, got %+v", expected[i], rules[i])
+	}
+
+	// round trip
+	b := NewLifecycleConfiguration(rules).MustMarshal()
+	parsed, err := ParseLifecycle(bytes.NewReader(b))
+	tassert.CheckFatal(t, err)
+	for i := range expected {
+		tassert.Errorf(t, parsed[i] == expected[i], "expected %+v, got %+v", expected[i], parsed[i])
+	}
+
+	for _, invalid := range []string{
+		`<LifecycleConfiguration></LifecycleConfiguration>`,
+		`<LifecycleConfiguration><Rule><Status>On</Status></Rule></LifecycleConfiguration>`,
+		`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter><Tag><Key>k</Key><Value>v</Value></Tag></Filter></Rule></LifecycleConfiguration>`,
+		`<LifecycleConfiguration><Rule><Status>Enabled</Status><Expiration><Date>2021-01-01T00:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`,
+	} {
+		_, err := ParseLifecycle(strings.NewReader(invalid))
+		tassert.Errorf(t, err != nil, "expected error for %q", invalid)
+	}
+}
@@ -0,0 +1,208 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// User-defined metadata (`x-amz-meta-*` headers) and object tags are kept in
+// LOM custom metadata: every metadata entry is stored under its own key with
+// `userMDPrefix`, and all tags are stored under `TaggingMD` key as a single
+// URL-encoded string (the same format as `x-amz-tagging` header uses).
+// NOTE: LOM metadata must fit into a single xattr, hence the limits below are
+// stricter than S3 ones.
+
+type (
+	// PutObjectTagging request body and GetObjectTagging response
+	Tagging struct {
+		XMLName xml.Name `xml:"Tagging"`
+		Ns      string   `xml:"xmlns,attr,omitempty"`
+		TagSet  TagSet   `xml:"TagSet"`
+	}
+	TagSet struct {
+		Tags []Tag `xml:"Tag"`
+	}
+	Tag struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	}
+)
+
+const (
+	URLParamTagging = "tagging"
+
+	headerMetaPrefix   = "X-Amz-Meta-"
+	HeaderTagging      = "x-amz-tagging"
+	headerTaggingCount = "x-amz-tagging-count"
+
+	// Custom metadata keys
+	userMDPrefix = "s3-meta-"
+	TaggingMD    = "s3-tagging"
+
+	maxUserMDSize  = 2 * cmn.KiB // total size of user-defined metadata
+	maxTags        = 10
+	maxTagKeyLen   = 128
+	maxTagValueLen = 256
+	maxTaggingSize = cmn.KiB // encoded tag set
+)
+
+// CustomMDFromHeader extracts user-defined metadata and object tags from
+// PUT (or CreateMultipartUpload) request header. The result must be stored in
+// LOM custom metadata.
+func CustomMDFromHeader(hdr http.Header) (cmn.SimpleKVs, error) {
+	var (
+		md   = make(cmn.SimpleKVs, 4)
+		size int
+	)
+	for key, values := range hdr {
+		if !strings.HasPrefix(key, headerMetaPrefix) || len(key) == len(headerMetaPrefix) {
+			continue
+		}
+		name := strings.ToLower(key[len(headerMetaPrefix):])
+		value := strings.Join(values, ",")
+		size += len(name) + len(value)
+		md[userMDPrefix+name] = value
+	}
+	if size > maxUserMDSize {
+		return nil, fmt.Errorf("user-defined metadata size %d exceeds the limit %d", size, maxUserMDSize)
+	}
+	if s := hdr.Get(HeaderTagging); s != "" {
+		tagging, err := parseTagsQuery(s)
+		if err != nil {
+			return nil, err
+		}
+		if err := tagging.validate(); err != nil {
+			return nil, err
+		}
+		md[TaggingMD] = tagging.encode()
+	}
+	if len(md) == 0 {
+		return nil, nil
+	}
+	return md, nil
+}
+
+// Adds user-defined metadata and the number of tags to the response header
+func setUserMDHeader(header http.Header, lom *cluster.LOM) {
+	for key, value := range lom.CustomMD() {
+		if strings.HasPrefix(key, userMDPrefix) {
+			header.Set(headerMetaPrefix+key[len(userMDPrefix):], value)
+		}
+	}
+	if tagging := TaggingFromLOM(lom); len(tagging.TagSet.Tags) != 0 {
+		header.Set(headerTaggingCount, strconv.Itoa(len(tagging.TagSet.Tags)))
+	}
+}
+
+// TaggingFromLOM returns the tags of the object (empty set if the object has no tags).
+func TaggingFromLOM(lom *cluster.LOM) *Tagging {
+	tagging := &Tagging{Ns: s3Namespace}
+	if s, ok := lom.GetCustomMD(TaggingMD); ok {
+		if parsed, err := parseTagsQuery(s); err == nil {
+			tagging.TagSet = parsed.TagSet
+		}
+	}
+	if tagging.TagSet.Tags == nil {
+		tagging.TagSet.Tags = []Tag{}
+	}
+	return tagging
+}
+
+// SetLOMTagging replaces the tags of the object. Nil or empty `tagging`
+// removes all tags. The caller is responsible for persisting LOM metadata.
+func SetLOMTagging(lom *cluster.LOM, tagging *Tagging) {
+	md := make(cmn.SimpleKVs, len(lom.CustomMD())+1)
+	for k, v := range lom.CustomMD() {
+		md[k] = v
+	}
+	if tagging == nil || len(tagging.TagSet.Tags) == 0 {
+		delete(md, TaggingMD)
+	} else {
+		md[TaggingMD] = tagging.encode()
+	}
+	lom.SetCustomMD(md)
+}
+
+// ParseTagging decodes and validates PutObjectTagging request body.
+func ParseTagging(r io.Reader) (*Tagging, error) {
+	tagging := &Tagging{}
+	if err := xml.NewDecoder(r).Decode(tagging); err != nil {
+		return nil, err
+	}
+	if err := tagging.validate(); err != nil {
+		return nil, err
+	}
+	return tagging, nil
+}
+
+func parseTagsQuery(s string) (*Tagging, error) {
+	query, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag set %q: %v", s, err)
+	}
+	tagging := &Tagging{}
+	for key, values := range query {
+		if len(values) != 1 {
+			return nil, fmt.Errorf("duplicate tag key %q", key)
+		}
+		tagging.TagSet.Tags = append(tagging.TagSet.Tags, Tag{Key: key, Value: values[0]})
+	}
+	return tagging, nil
+}
+
+func (t *Tagging) validate() error {
+	tags := t.TagSet.Tags
+	if len(tags) > maxTags {
+		return fmt.Errorf("object tags cannot be greater than %d", maxTags)
+	}
+	keys := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		if tag.Key == "" || len(tag.Key) > maxTagKeyLen {
+			return fmt.Errorf("invalid tag key %q", tag.Key)
+		}
+		if len(tag.Value) > maxTagValueLen {
+			return fmt.Errorf("the value of tag %q is too long", tag.Key)
+		}
+		if _, ok := keys[tag.Key]; ok {
+			return fmt.Errorf("duplicate tag key %q", tag.Key)
+		}
+		keys[tag.Key] = struct{}{}
+	}
+	if size := len(t.encode()); size > maxTaggingSize {
+		return fmt.Errorf("object tags size %d exceeds the limit %d", size, maxTaggingSize)
+	}
+	return nil
+}
+
+// Encodes the tags as URL query sorted by key
+func (t *Tagging) encode() string {
+	query := make(url.Values, len(t.TagSet.Tags))
+	for _, tag := range t.TagSet.Tags {
+		query.Set(tag.Key, tag.Value)
+	}
+	return query.Encode()
+}
+
+func (t *Tagging) sort() {
+	sort.Slice(t.TagSet.Tags, func(i, j int) bool { return t.TagSet.Tags[i].Key < t.TagSet.Tags[j].Key })
+}
+
+func (t *Tagging) MustMarshal() []byte {
+	t.sort()
+	b, err := xml.Marshal(t)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
@@ -0,0 +1,63 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestCustomMDFromHeader(t *testing.T) {
+	hdr := http.Header{}
+	hdr.Set("x-amz-meta-Project", "imagenet")
+	hdr.Set("x-amz-meta-split", "train")
+	hdr.Set("Content-Type", "application/octet-stream")
+	hdr.Set(HeaderTagging, "label=cat&source=s3")
+
+	md, err := CustomMDFromHeader(hdr)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(md) == 3, "expected 3 entries, got %v", md)
+	tassert.Errorf(t, md[userMDPrefix+"project"] == "imagenet", "invalid metadata: %v", md)
+	tassert.Errorf(t, md[userMDPrefix+"split"] == "train", "invalid metadata: %v", md)
+	tassert.Errorf(t, md[TaggingMD] == "label=cat&source=s3", "invalid tags: %v", md)
+
+	md, err = CustomMDFromHeader(http.Header{})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, md == nil, "expected no metadata, got %v", md)
+
+	hdr = http.Header{}
+	hdr.Set("x-amz-meta-large", strings.Repeat("a", maxUserMDSize))
+	_, err = CustomMDFromHeader(hdr)
+	tassert.Errorf(t, err != nil, "expected error for too large metadata")
+}
+
+func TestParseTagging(t *testing.T) {
+	const body = `<Tagging><TagSet>
+		<Tag><Key>source</Key><Value>s3</Value></Tag>
+		<Tag><Key>label</Key><Value>a cat</Value></Tag>
+	</TagSet></Tagging>`
+	tagging, err := ParseTagging(strings.NewReader(body))
+	tassert.CheckFatal(t, err)
+	encoded := tagging.encode()
+	tassert.Errorf(t, encoded == "label=a+cat&source=s3", "invalid encoding %q", encoded)
+
+	parsed, err := parseTagsQuery(encoded)
+	tassert.CheckFatal(t, err)
+	parsed.sort()
+	tassert.Errorf(t, len(parsed.TagSet.Tags) == 2 && parsed.TagSet.Tags[0] == Tag{Key: "label", Value: "a cat"},
+		"invalid tags %v", parsed.TagSet.Tags)
+
+	for _, invalid := range []string{
+		`<Tagging><TagSet><Tag><Key></Key><Value>v</Value></Tag></TagSet></Tagging>`,
+		`<Tagging><TagSet><Tag><Key>k</Key><Value>1</Value></Tag><Tag><Key>k</Key><Value>2</Value></Tag></TagSet></Tagging>`,
+		`<Tagging><TagSet>` + strings.Repeat(`<Tag><Key>k</Key><Value>v</Value></Tag>`, maxTags+1) + `</TagSet></Tagging>`,
+	} {
+		_, err := ParseTagging(strings.NewReader(invalid))
+		tassert.Errorf(t, err != nil, "expected error for %q", invalid)
+	}
+}
@@ -0,0 +1,618 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/sse"
+)
+
+// Multipart upload state is kept in memory of the target the upload has started
+// on and is persisted along with the uploaded parts: every upload has its own
+// directory (fs.MptType content) on the mountpath of the resulting object that
+// contains the upload manifest and the parts named "<part number>.<MD5>".
+// The uploads get reloaded when the target restarts (see LoadUploads).
+//
+// Upload ID includes the ID of the target (see NewUploadID), so that the
+// requests of the upload are routed to the target that has the parts even if
+// the resulting object is now owned (HRW) by another target. In the latter case
+// the upload gets handed over to the owner when it completes (see HandoverUpload).
+//
+// Uploads with no activity (no parts uploaded) within the configured time
+// (see `timeout.mpt_upload_time`) expire and get aborted (see ExpireUploads).
+
+type (
+	// Internal representation of an uploaded part
+	MptPart struct {
+		MD5  string // MD5 of the part (S3 ETag of the part)
+		FQN  string // FQN of the part in the upload directory
+		Size int64  // part size in bytes
+		Num  int64  // part number (1..10000)
+	}
+	// MptManifest is the persistent state of the upload (except the parts)
+	MptManifest struct {
+		ID    string        `json:"id"`
+		Bck   string        `json:"bucket"`
+		Obj   string        `json:"object"`
+		Ctime int64         `json:"ctime"`
+		MD    cmn.SimpleKVs `json:"md,omitempty"`  // user-defined metadata and tags of the resulting object
+		Enc   *MptEnc       `json:"enc,omitempty"` // encryption of the resulting object (nil - as per bucket)
+	}
+	MptEnc struct {
+		KeyID  string `json:"key_id,omitempty"`  // KMS master key
+		KeyMD5 string `json:"key_md5,omitempty"` // SSE-C: MD5 of the key (the key itself is never stored)
+	}
+	// MptHandover is sent to the target the upload is handed over to
+	MptHandover struct {
+		MptManifest
+		CustomerKey []byte `json:"customer_key,omitempty"`
+	}
+	mptUpload struct {
+		mu sync.Mutex // serializes adding parts, completion, and removal of the upload
+		MptManifest
+		dir   string
+		mtime atomic.Int64 // last activity
+		parts map[int64]*MptPart
+		enc   *sse.Params // nil if reloaded with SSE-C - the key must be provided again
+		done  bool        // completed, aborted, expired, or handed over
+	}
+	mptUploads struct {
+		sync.RWMutex
+		m map[string]*mptUpload // by upload ID
+	}
+
+	ErrUploadNotFound struct {
+		id string
+	}
+
+	// Response for CreateMultipartUpload request
+	InitiateMptUploadResult struct {
+		XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+		Ns       string   `xml:"xmlns,attr"`
+		Bucket   string   `xml:"Bucket"`
+		Key      string   `xml:"Key"`
+		UploadID string   `xml:"UploadId"`
+	}
+
+	// CompleteMultipartUpload request body
+	CompleteMptUpload struct {
+		XMLName xml.Name    `xml:"CompleteMultipartUpload"`
+		Parts   []*PartInfo `xml:"Part"`
+	}
+	PartInfo struct {
+		PartNumber   int64  `xml:"PartNumber"`
+		ETag         string `xml:"ETag"`
+		Size         int64  `xml:"Size,omitempty"`
+		LastModified string `xml:"LastModified,omitempty"`
+	}
+
+	// Response for CompleteMultipartUpload request
+	CompleteMptUploadResult struct {
+		XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+		Ns      string   `xml:"xmlns,attr"`
+		Bucket  string   `xml:"Bucket"`
+		Key     string   `xml:"Key"`
+		ETag    string   `xml:"ETag"`
+	}
+
+	// Response for ListParts request
+	ListPartsResult struct {
+		XMLName              xml.Name    `xml:"ListPartsResult"`
+		Ns                   string      `xml:"xmlns,attr"`
+		Bucket               string      `xml:"Bucket"`
+		Key                  string      `xml:"Key"`
+		UploadID             string      `xml:"UploadId"`
+		PartNumberMarker     int64       `xml:"PartNumberMarker"`
+		NextPartNumberMarker int64       `xml:"NextPartNumberMarker"`
+		MaxParts             int         `xml:"MaxParts"`
+		IsTruncated          bool        `xml:"IsTruncated"`
+		Parts                []*PartInfo `xml:"Part"`
+	}
+)
+
+const (
+	mptMaxPartNum    = 10000
+	mptDefMaxParts   = 1000
+	mptETagSuffixSep = "-"
+
+	// Custom metadata key to keep the composite ETag of an object that was
+	// assembled from multipart upload.
+	MptETagMD = "s3-mpt-etag"
+
+	uploadIDSepa    = "." // (UUIDs do not contain dots - see cmn.GenUUID)
+	mptManifestName = "manifest"
+	mptWorkSepa     = "~" // "<part number>~<tie>": the part (or manifest) being written
+)
+
+var ups = &mptUploads{m: make(map[string]*mptUpload)}
+
+func (e *ErrUploadNotFound) Error() string { return fmt.Sprintf("upload %q not found", e.id) }
+
+func IsErrUploadNotFound(err error) bool {
+	_, ok := err.(*ErrUploadNotFound)
+	return ok
+}
+
+// NewUploadID generates a new upload ID that includes the ID of the target
+// the upload starts on.
+func NewUploadID(tid string) string { return cmn.GenUUID() + uploadIDSepa + tid }
+
+// UploadTarget returns the ID of the target the upload has started on.
+func UploadTarget(id string) string {
+	if i := strings.Index(id, uploadIDSepa); i >= 0 {
+		return id[i+1:]
+	}
+	return ""
+}
+
+// InitUpload registers a new multipart upload and persists it in a given
+// directory. The custom metadata `md` and encryption `enc` are applied to
+// the resulting object when the upload completes.
+func InitUpload(id, dir, bckName, objName string, md cmn.SimpleKVs, enc *sse.Params) error {
+	m := MptManifest{ID: id, Bck: bckName, Obj: objName, Ctime: time.Now().UnixNano(), MD: md}
+	if enc != nil {
+		m.Enc = &MptEnc{KeyID: enc.KeyID}
+		if enc.CustomerKey != nil {
+			m.Enc.KeyMD5 = sse.CustomerKeyMD5(enc.CustomerKey)
+		}
+	}
+	return addUpload(&m, dir, enc)
+}
+
+// AcceptUpload registers the upload handed over by another target (see
+// HandoverUpload); the parts follow.
+func AcceptUpload(dir string, hdv *MptHandover) error {
+	if getUpload(hdv.ID) != nil {
+		return nil // retransmitted
+	}
+	var enc *sse.Params
+	if m := hdv.MptManifest; m.Enc != nil && (m.Enc.KeyMD5 == "" || hdv.CustomerKey != nil) {
+		enc = &sse.Params{KeyID: m.Enc.KeyID, CustomerKey: hdv.CustomerKey}
+	}
+	return addUpload(&hdv.MptManifest, dir, enc)
+}
+
+func addUpload(m *MptManifest, dir string, enc *sse.Params) error {
+	up := &mptUpload{MptManifest: *m, dir: dir, parts: make(map[int64]*MptPart), enc: enc}
+	up.mtime.Store(time.Now().UnixNano())
+	if err := up.persist(); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	ups.Lock()
+	ups.m[m.ID] = up
+	ups.Unlock()
+	return nil
+}
+
+// LoadUploads loads all the uploads persisted in a given directory - the
+// multipart upload content directory of a bucket on a given mountpath.
+func LoadUploads(ctDir string) (n int, err error) {
+	dirents, err := ioutil.ReadDir(ctDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	for _, dirent := range dirents {
+		if !dirent.IsDir() {
+			continue
+		}
+		dir := filepath.Join(ctDir, dirent.Name())
+		up, errLoad := loadUpload(dir)
+		if errLoad != nil {
+			if os.IsNotExist(errLoad) { // failed to start
+				os.RemoveAll(dir)
+			} else {
+				err = errLoad
+			}
+			continue
+		}
+		ups.Lock()
+		if _, ok := ups.m[up.ID]; !ok {
+			ups.m[up.ID] = up
+			n++
+		}
+		ups.Unlock()
+	}
+	return
+}
+
+func loadUpload(dir string) (*mptUpload, error) {
+	up := &mptUpload{dir: dir, parts: make(map[int64]*MptPart)}
+	b, err := ioutil.ReadFile(filepath.Join(dir, mptManifestName))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &up.MptManifest); err != nil {
+		return nil, fmt.Errorf("upload %s: invalid manifest: %v", dir, err)
+	}
+	if up.ID != filepath.Base(dir) {
+		return nil, fmt.Errorf("upload %s: manifest of another upload %q", dir, up.ID)
+	}
+	if up.Enc != nil && up.Enc.KeyMD5 == "" {
+		up.enc = &sse.Params{KeyID: up.Enc.KeyID}
+	}
+	finfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	mtime := time.Unix(0, up.Ctime)
+	for _, finfo := range finfos {
+		name := finfo.Name()
+		if name == mptManifestName {
+			continue
+		}
+		fqn := filepath.Join(dir, name)
+		num, etag, ok := parsePartName(name)
+		if !ok { // partially received part
+			os.Remove(fqn)
+			continue
+		}
+		if prev, ok := up.parts[num]; ok { // keep the part uploaded last
+			prevInfo, err := os.Stat(prev.FQN)
+			if err == nil && prevInfo.ModTime().After(finfo.ModTime()) {
+				os.Remove(fqn)
+				continue
+			}
+			os.Remove(prev.FQN)
+		}
+		up.parts[num] = &MptPart{MD5: etag, FQN: fqn, Size: finfo.Size(), Num: num}
+		if finfo.ModTime().After(mtime) {
+			mtime = finfo.ModTime()
+		}
+	}
+	up.mtime.Store(mtime.UnixNano())
+	return up, nil
+}
+
+func getUpload(id string) *mptUpload {
+	ups.RLock()
+	up := ups.m[id]
+	ups.RUnlock()
+	return up
+}
+
+// PartWorkFQN returns FQN to store a part of the upload being received.
+func PartWorkFQN(id string, num int64) (string, error) {
+	up := getUpload(id)
+	if up == nil {
+		return "", &ErrUploadNotFound{id}
+	}
+	return filepath.Join(up.dir, strconv.FormatInt(num, 10)+mptWorkSepa+cmn.GenTie()), nil
+}
+
+// AddPart adds (or replaces) a part of the upload received into `workFQN`
+// (see PartWorkFQN).
+func AddPart(id, workFQN string, num int64, etag string, size int64) (*MptPart, error) {
+	up := getUpload(id)
+	if up == nil {
+		return nil, &ErrUploadNotFound{id}
+	}
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	if up.done {
+		return nil, &ErrUploadNotFound{id}
+	}
+	part := &MptPart{MD5: etag, FQN: filepath.Join(up.dir, partName(num, etag)), Size: size, Num: num}
+	if err := os.Rename(workFQN, part.FQN); err != nil {
+		return nil, err
+	}
+	if prev, ok := up.parts[num]; ok && prev.FQN != part.FQN {
+		if err := cmn.RemoveFile(prev.FQN); err != nil {
+			glog.Errorf("upload %q: failed to remove replaced part %s, err: %v", id, prev.FQN, err)
+		}
+	}
+	up.parts[num] = part
+	up.mtime.Store(time.Now().UnixNano())
+	return part, nil
+}
+
+// UploadExists checks that the upload exists and belongs to the given object.
+func UploadExists(id, bckName, objName string) bool {
+	up := getUpload(id)
+	return up != nil && up.Bck == bckName && up.Obj == objName
+}
+
+// UploadCustomMD returns a copy of the custom metadata of the upload.
+func UploadCustomMD(id string) cmn.SimpleKVs {
+	up := getUpload(id)
+	if up == nil {
+		return nil
+	}
+	md := make(cmn.SimpleKVs, len(up.MD)+1)
+	for k, v := range up.MD {
+		md[k] = v
+	}
+	return md
+}
+
+// UploadEncryption returns the encryption requested at the start of the upload.
+// The customer-provided key (SSE-C) is not persisted, and so it must be provided
+// again to complete the upload that has been reloaded.
+func UploadEncryption(id string, customerKey []byte) (*sse.Params, error) {
+	up := getUpload(id)
+	if up == nil {
+		return nil, &ErrUploadNotFound{id}
+	}
+	if up.enc != nil || up.Enc == nil {
+		return up.enc, nil
+	}
+	if customerKey == nil {
+		return nil, fmt.Errorf("upload %q: %s is required", id, headerSSECKey)
+	}
+	if sse.CustomerKeyMD5(customerKey) != up.Enc.KeyMD5 {
+		return nil, sse.ErrCustomerKeyMismatch
+	}
+	return &sse.Params{CustomerKey: customerKey}, nil
+}
+
+// CompleteUpload validates the list of parts sent with CompleteMultipartUpload
+// request against the uploaded ones and calls `cb` with the parts in the order
+// they must be concatenated. The upload gets removed if `cb` succeeds.
+// Parts uploaded concurrently are not lost: they either make it before the
+// completion or fail as the upload no longer exists.
+func CompleteUpload(id string, parts []*PartInfo, cb func(parts []*MptPart) error) error {
+	up := getUpload(id)
+	if up == nil {
+		return &ErrUploadNotFound{id}
+	}
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	if up.done {
+		return &ErrUploadNotFound{id}
+	}
+	up.mtime.Store(time.Now().UnixNano())
+	mparts, err := up.checkParts(parts)
+	if err != nil {
+		return err
+	}
+	if err := cb(mparts); err != nil {
+		return err
+	}
+	return up.remove()
+}
+
+// HandoverUpload calls `cb` to transfer the upload, with all its parts, to
+// another target and removes the upload if `cb` succeeds.
+func HandoverUpload(id string, cb func(hdv *MptHandover, parts []*MptPart) error) error {
+	up := getUpload(id)
+	if up == nil {
+		return &ErrUploadNotFound{id}
+	}
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	if up.done {
+		return &ErrUploadNotFound{id}
+	}
+	hdv := &MptHandover{MptManifest: up.MptManifest}
+	if up.enc != nil {
+		hdv.CustomerKey = up.enc.CustomerKey
+	}
+	parts := make([]*MptPart, 0, len(up.parts))
+	for _, part := range up.parts {
+		parts = append(parts, part)
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Num < parts[j].Num })
+	if err := cb(hdv, parts); err != nil {
+		return err
+	}
+	return up.remove()
+}
+
+// AbortUpload removes the upload along with all its parts.
+func AbortUpload(id string) error {
+	up := getUpload(id)
+	if up == nil {
+		return &ErrUploadNotFound{id}
+	}
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	if up.done {
+		return &ErrUploadNotFound{id}
+	}
+	return up.remove()
+}
+
+// ExpireUploads aborts the uploads with no activity for more than `ttl` and
+// returns their IDs.
+func ExpireUploads(ttl time.Duration) (expired []string, err error) {
+	var (
+		candidates []*mptUpload
+		now        = time.Now()
+	)
+	ups.RLock()
+	for _, up := range ups.m {
+		if now.Sub(time.Unix(0, up.mtime.Load())) >= ttl {
+			candidates = append(candidates, up)
+		}
+	}
+	ups.RUnlock()
+	for _, up := range candidates {
+		up.mu.Lock()
+		if !up.done && now.Sub(time.Unix(0, up.mtime.Load())) >= ttl {
+			if errRm := up.remove(); errRm != nil {
+				err = errRm
+			}
+			expired = append(expired, up.ID)
+		}
+		up.mu.Unlock()
+	}
+	return
+}
+
+// ListParts returns the sorted list of uploaded parts with numbers greater
+// than `marker`.
+func ListParts(id string, marker int64, maxParts int) (*ListPartsResult, error) {
+	up := getUpload(id)
+	if up == nil {
+		return nil, &ErrUploadNotFound{id}
+	}
+	if maxParts <= 0 || maxParts > mptDefMaxParts {
+		maxParts = mptDefMaxParts
+	}
+	result := &ListPartsResult{
+		Ns:               s3Namespace,
+		Bucket:           up.Bck,
+		Key:              up.Obj,
+		UploadID:         id,
+		PartNumberMarker: marker,
+		MaxParts:         maxParts,
+	}
+	up.mu.Lock()
+	result.Parts = make([]*PartInfo, 0, len(up.parts))
+	for num, part := range up.parts {
+		if num <= marker {
+			continue
+		}
+		result.Parts = append(result.Parts, &PartInfo{PartNumber: num, ETag: part.MD5, Size: part.Size})
+	}
+	up.mu.Unlock()
+
+	sort.Slice(result.Parts, func(i, j int) bool { return result.Parts[i].PartNumber < result.Parts[j].PartNumber })
+	if len(result.Parts) > maxParts {
+		result.Parts = result.Parts[:maxParts]
+		result.IsTruncated = true
+	}
+	if l := len(result.Parts); l > 0 {
+		result.NextPartNumberMarker = result.Parts[l-1].PartNumber
+	}
+	return result, nil
+}
+
+func (up *mptUpload) checkParts(parts []*PartInfo) ([]*MptPart, error) {
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("upload %q: empty list of parts", up.ID)
+	}
+	res := make([]*MptPart, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+			return nil, fmt.Errorf("upload %q: parts must be listed in ascending order", up.ID)
+		}
+		mpart, ok := up.parts[part.PartNumber]
+		if !ok {
+			return nil, fmt.Errorf("upload %q: part %d not found", up.ID, part.PartNumber)
+		}
+		if etag := strings.Trim(part.ETag, "\""); etag != "" && etag != mpart.MD5 {
+			return nil, fmt.Errorf("upload %q: part %d ETag mismatch", up.ID, part.PartNumber)
+		}
+		res = append(res, mpart)
+	}
+	return res, nil
+}
+
+func (up *mptUpload) persist() error {
+	b, err := json.Marshal(&up.MptManifest)
+	if err != nil {
+		return err
+	}
+	if err := cmn.CreateDir(up.dir); err != nil {
+		return err
+	}
+	var (
+		fqn     = filepath.Join(up.dir, mptManifestName)
+		workFQN = fqn + mptWorkSepa + cmn.GenTie()
+	)
+	if err := ioutil.WriteFile(workFQN, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(workFQN, fqn)
+}
+
+// must be called under the upload lock
+func (up *mptUpload) remove() error {
+	up.done = true
+	ups.Lock()
+	delete(ups.m, up.ID)
+	ups.Unlock()
+	return os.RemoveAll(up.dir)
+}
+
+func partName(num int64, etag string) string { return strconv.FormatInt(num, 10) + "." + etag }
+
+func parsePartName(name string) (num int64, etag string, ok bool) {
+	i := strings.IndexByte(name, '.')
+	if i < 0 {
+		return
+	}
+	etag = name[i+1:]
+	if len(etag) != 2*md5.Size {
+		return
+	}
+	if _, err := hex.DecodeString(etag); err != nil {
+		return
+	}
+	num, err := strconv.ParseInt(name[:i], 10, 64)
+	return num, etag, err == nil
+}
+
+// ParsePartNum parses and validates S3 part number.
+func ParsePartNum(s string) (int64, error) {
+	partNum, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid part number %q: %v", s, err)
+	}
+	if partNum < 1 || partNum > mptMaxPartNum {
+		return 0, fmt.Errorf("invalid part number %d, must be in the range [1, %d]", partNum, mptMaxPartNum)
+	}
+	return partNum, nil
+}
+
+// MptETag computes S3-compatible ETag of an object assembled from the parts:
+// MD5 of concatenated binary MD5s of the parts followed by the number of parts.
+func MptETag(parts []*MptPart) (string, error) {
+	h := md5.New()
+	for _, part := range parts {
+		b, err := hex.DecodeString(part.MD5)
+		if err != nil {
+			return "", fmt.Errorf("invalid MD5 %q of part %d: %v", part.MD5, part.Num, err)
+		}
+		h.Write(b)
+	}
+	return hex.EncodeToString(h.Sum(nil)) + mptETagSuffixSep + strconv.Itoa(len(parts)), nil
+}
+
+func NewInitiateMptUploadResult(bckName, objName, id string) *InitiateMptUploadResult {
+	return &InitiateMptUploadResult{Ns: s3Namespace, Bucket: bckName, Key: objName, UploadID: id}
+}
+
+func NewCompleteMptUploadResult(bckName, objName, etag string) *CompleteMptUploadResult {
+	return &CompleteMptUploadResult{Ns: s3Namespace, Bucket: bckName, Key: objName, ETag: etag}
+}
+
+func (r *InitiateMptUploadResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+func (r *CompleteMptUploadResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+func (r *ListPartsResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
@@ -0,0 +1,149 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+	"github.com/NVIDIA/aistore/sse"
+)
+
+func putTestPart(t *testing.T, id string, num int64, data string) *MptPart {
+	workFQN, err := PartWorkFQN(id, num)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, ioutil.WriteFile(workFQN, []byte(data), 0o644))
+	sum := md5.Sum([]byte(data))
+	part, err := AddPart(id, workFQN, num, hex.EncodeToString(sum[:]), int64(len(data)))
+	tassert.CheckFatal(t, err)
+	return part
+}
+
+func TestUploadTarget(t *testing.T) {
+	cmn.InitShortID(0)
+	id := NewUploadID("t1")
+	tassert.Errorf(t, UploadTarget(id) == "t1", "expected target %q, got %q", "t1", UploadTarget(id))
+	tassert.Errorf(t, UploadTarget("unknown") == "", "expected no target")
+}
+
+func TestExpireUploads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mpt")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+
+	tassert.CheckFatal(t, InitUpload("old", filepath.Join(dir, "old"), "bck", "obj-old", nil, nil))
+	tassert.CheckFatal(t, InitUpload("new", filepath.Join(dir, "new"), "bck", "obj-new", nil, nil))
+	tassert.CheckFatal(t, InitUpload("active", filepath.Join(dir, "active"), "bck", "obj-active", nil, nil))
+	putTestPart(t, "old", 1, "part1")
+	putTestPart(t, "old", 2, "part2")
+	putTestPart(t, "active", 1, "part1")
+	ups.Lock()
+	ups.m["old"].mtime.Store(time.Now().Add(-2 * time.Hour).UnixNano())
+	ups.m["active"].Ctime = time.Now().Add(-2 * time.Hour).UnixNano() // started long ago but is still active
+	ups.Unlock()
+
+	expired, err := ExpireUploads(time.Hour)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(expired) == 1 && expired[0] == "old", "expected one upload to expire, got %v", expired)
+	tassert.Errorf(t, !UploadExists("old", "bck", "obj-old"), "expected expired upload to be removed")
+	_, err = os.Stat(filepath.Join(dir, "old"))
+	tassert.Errorf(t, os.IsNotExist(err), "expected parts of expired upload to be removed, err: %v", err)
+	tassert.Errorf(t, UploadExists("new", "bck", "obj-new"), "expected recent upload to be kept")
+	tassert.Errorf(t, UploadExists("active", "bck", "obj-active"), "expected active upload to be kept")
+	expired, _ = ExpireUploads(time.Hour)
+	tassert.Errorf(t, len(expired) == 0, "expected no more uploads to expire, got %v", expired)
+
+	tassert.CheckError(t, AbortUpload("new"))
+	tassert.CheckError(t, AbortUpload("active"))
+}
+
+func TestLoadUploads(t *testing.T) {
+	ctDir, err := ioutil.TempDir("", "mpt")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(ctDir)
+
+	var (
+		id  = "upload"
+		key = make([]byte, sse.KeySize)
+		md  = cmn.SimpleKVs{userMDPrefix + "color": "blue"}
+	)
+	tassert.CheckFatal(t, InitUpload(id, filepath.Join(ctDir, id), "bck", "obj", md, &sse.Params{CustomerKey: key}))
+	putTestPart(t, id, 1, "first")
+	putTestPart(t, id, 2, "second")
+	part := putTestPart(t, id, 1, "replaced")
+	// part being received when the target goes down
+	workFQN, err := PartWorkFQN(id, 3)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, ioutil.WriteFile(workFQN, []byte("partial"), 0o644))
+
+	// restart
+	ups.Lock()
+	ups.m = make(map[string]*mptUpload)
+	ups.Unlock()
+	n, err := LoadUploads(ctDir)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, n == 1, "expected 1 upload to be loaded, got %d", n)
+	tassert.Fatalf(t, UploadExists(id, "bck", "obj"), "expected upload to be loaded")
+	tassert.Errorf(t, UploadCustomMD(id)[userMDPrefix+"color"] == "blue", "expected custom metadata to be loaded")
+
+	result, err := ListParts(id, 0, 0)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(result.Parts) == 2, "expected 2 parts, got %d", len(result.Parts))
+	tassert.Errorf(t, result.Parts[0].ETag == part.MD5 && result.Parts[0].Size == int64(len("replaced")),
+		"expected replaced part, got %+v", result.Parts[0])
+	_, err = os.Stat(workFQN)
+	tassert.Errorf(t, os.IsNotExist(err), "expected partially received part to be removed, err: %v", err)
+
+	// the customer-provided key is not persisted
+	_, err = UploadEncryption(id, nil)
+	tassert.Errorf(t, err != nil, "expected customer key to be required")
+	_, err = UploadEncryption(id, make([]byte, sse.KeySize-1))
+	tassert.Errorf(t, err != nil, "expected customer key mismatch")
+	enc, err := UploadEncryption(id, key)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, enc != nil && enc.CustomerKey != nil, "expected customer key encryption, got %+v", enc)
+
+	tassert.CheckError(t, AbortUpload(id))
+}
+
+func TestCompleteUploadLatePart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mpt")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+
+	id := "upload"
+	tassert.CheckFatal(t, InitUpload(id, filepath.Join(dir, id), "bck", "obj", nil, nil))
+	part := putTestPart(t, id, 1, "first")
+
+	var (
+		started = make(chan struct{})
+		added   = make(chan error, 1)
+	)
+	err = CompleteUpload(id, []*PartInfo{{PartNumber: 1, ETag: part.MD5}}, func(parts []*MptPart) error {
+		tassert.Fatalf(t, len(parts) == 1 && parts[0].Num == 1, "expected part 1, got %v", parts)
+		close(started)
+		workFQN, err := PartWorkFQN(id, 2)
+		tassert.CheckFatal(t, err)
+		tassert.CheckFatal(t, ioutil.WriteFile(workFQN, []byte("late"), 0o644))
+		go func() {
+			_, err := AddPart(id, workFQN, 2, part.MD5, 4)
+			added <- err
+		}()
+		time.Sleep(10 * time.Millisecond) // let the part wait for the completion
+		return nil
+	})
+	tassert.CheckFatal(t, err)
+	<-started
+	err = <-added
+	tassert.Errorf(t, IsErrUploadNotFound(err), "expected late part to fail with upload not found, got %v", err)
+	tassert.Errorf(t, !UploadExists(id, "bck", "obj"), "expected completed upload to be removed")
+}
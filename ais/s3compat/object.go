@@ -17,23 +17,37 @@ import (
 )
 
 type (
-	// List objects response
+	// List objects response (both ListObjects and ListObjectsV2)
 	ListObjectResult struct {
-		Ns                    string     `xml:"xmlns,attr"`
-		Prefix                string     `xml:"Prefix"`
-		KeyCount              int        `xml:"KeyCount"` // number of objects in the response
-		MaxKeys               int        `xml:"MaxKeys"`
-		IsTruncated           bool       `xml:"IsTruncated"`           // true if there are more pages to read
-		ContinuationToken     string     `xml:"ContinuationToken"`     // original ContinuationToken
-		NextContinuationToken string     `xml:"NextContinuationToken"` // NextContinuationToken to read the next page
-		Contents              []*ObjInfo `xml:"Contents"`              // list of objects
+		XMLName               xml.Name        `xml:"ListBucketResult"`
+		Ns                    string          `xml:"xmlns,attr"`
+		Name                  string          `xml:"Name"` // bucket name
+		Prefix                string          `xml:"Prefix"`
+		Delimiter             string          `xml:"Delimiter,omitempty"`
+		KeyCount              int             `xml:"KeyCount,omitempty"` // number of objects and common prefixes in the response (V2)
+		MaxKeys               int             `xml:"MaxKeys"`
+		IsTruncated           bool            `xml:"IsTruncated"`                     // true if there are more pages to read
+		Marker                string          `xml:"Marker,omitempty"`                // original Marker (V1)
+		NextMarker            string          `xml:"NextMarker,omitempty"`            // NextMarker to read the next page (V1)
+		StartAfter            string          `xml:"StartAfter,omitempty"`            // original StartAfter (V2)
+		ContinuationToken     string          `xml:"ContinuationToken,omitempty"`     // original ContinuationToken (V2)
+		NextContinuationToken string          `xml:"NextContinuationToken,omitempty"` // NextContinuationToken to read the next page (V2)
+		Contents              []*ObjInfo      `xml:"Contents"`                        // list of objects
+		CommonPrefixes        []*CommonPrefix `xml:"CommonPrefixes"`                  // list of "directories" (when delimiter is set)
+
+		v2         bool // ListObjectsV2 request
+		fetchOwner bool // fill object owner (always true for V1)
 	}
 	ObjInfo struct {
-		Key          string `xml:"Key"`
-		LastModified string `xml:"LastModified"`
-		ETag         string `xml:"ETag"`
-		Size         int64  `xml:"Size"`
-		Class        string `xml:"StorageClass"`
+		Key          string    `xml:"Key"`
+		LastModified string    `xml:"LastModified"`
+		ETag         string    `xml:"ETag"`
+		Size         int64     `xml:"Size"`
+		Class        string    `xml:"StorageClass"`
+		Owner        *BckOwner `xml:"Owner,omitempty"`
+	}
+	CommonPrefix struct {
+		Prefix string `xml:"Prefix"`
 	}
 
 	// Response for object copy request
@@ -44,30 +58,58 @@ type (
 )
 
 func FillMsgFromS3Query(query url.Values, msg *cmn.SelectMsg) {
-	mxStr := query.Get("max-keys")
+	mxStr := query.Get(URLParamMaxKeys)
 	if pageSize, err := strconv.Atoi(mxStr); err == nil && pageSize > 0 {
 		msg.PageSize = uint(pageSize)
 	}
-	if prefix := query.Get("prefix"); prefix != "" {
+	if prefix := query.Get(URLParamPrefix); prefix != "" {
 		msg.Prefix = prefix
 	}
+	if delimiter := query.Get(URLParamDelimiter); delimiter != "" {
+		msg.Delimiter = delimiter
+	}
+	if query.Get(URLParamListType) != listTypeV2 {
+		// ListObjects (V1): marker is the last key of the previous page - the
+		// same as AIS continuation token
+		if marker := query.Get(URLParamMarker); marker != "" {
+			msg.ContinuationToken = marker
+		}
+		return
+	}
 	var token string
-	if token = query.Get("continuation-token"); token != "" {
+	if token = query.Get(URLParamContinuationToken); token != "" {
 		msg.ContinuationToken = token
 	}
 	// start-after makes sense only on first call. For the next call,
 	// when continuation-token is set, start-after is ignored
-	if after := query.Get("start-after"); after != "" && token == "" {
+	if after := query.Get(URLParamStartAfter); after != "" && token == "" {
 		msg.StartAfter = after
 	}
 }
 
-func NewListObjectResult() *ListObjectResult {
-	return &ListObjectResult{
-		Ns:       s3Namespace,
-		MaxKeys:  1000,
-		Contents: make([]*ObjInfo, 0),
+func NewListObjectResult(bckName string, query url.Values) *ListObjectResult {
+	r := &ListObjectResult{
+		Ns:             s3Namespace,
+		Name:           bckName,
+		Prefix:         query.Get(URLParamPrefix),
+		Delimiter:      query.Get(URLParamDelimiter),
+		MaxKeys:        defaultMaxKeys,
+		Contents:       make([]*ObjInfo, 0),
+		CommonPrefixes: make([]*CommonPrefix, 0),
+		v2:             query.Get(URLParamListType) == listTypeV2,
+	}
+	if maxKeys, err := strconv.Atoi(query.Get(URLParamMaxKeys)); err == nil && maxKeys > 0 {
+		r.MaxKeys = maxKeys
+	}
+	if r.v2 {
+		r.ContinuationToken = query.Get(URLParamContinuationToken)
+		r.StartAfter = query.Get(URLParamStartAfter)
+		r.fetchOwner, _ = cmn.ParseBool(query.Get(URLParamFetchOwner))
+	} else {
+		r.Marker = query.Get(URLParamMarker)
+		r.fetchOwner = true
 	}
+	return r
 }
 
 func (r *ListObjectResult) MustMarshal() []byte {
@@ -77,7 +119,15 @@ func (r *ListObjectResult) MustMarshal() []byte {
 }
 
 func (r *ListObjectResult) Add(entry *cmn.BucketEntry) {
-	r.Contents = append(r.Contents, entryToS3(entry))
+	if entry.IsDir() {
+		r.CommonPrefixes = append(r.CommonPrefixes, &CommonPrefix{Prefix: entry.Name})
+		return
+	}
+	info := entryToS3(entry)
+	if r.fetchOwner {
+		info.Owner = newBckOwner()
+	}
+	r.Contents = append(r.Contents, info)
 }
 
 func entryToS3(entry *cmn.BucketEntry) *ObjInfo {
@@ -90,12 +140,18 @@ func entryToS3(entry *cmn.BucketEntry) *ObjInfo {
 }
 
 func (r *ListObjectResult) FillFromAisBckList(bckList *cmn.BucketList) {
-	r.KeyCount = len(bckList.Entries)
 	r.IsTruncated = bckList.ContinuationToken != ""
-	r.ContinuationToken = bckList.ContinuationToken
 	for _, e := range bckList.Entries {
 		r.Add(e)
 	}
+	if r.v2 {
+		r.KeyCount = len(r.Contents) + len(r.CommonPrefixes)
+		r.NextContinuationToken = bckList.ContinuationToken
+	} else if r.Delimiter != "" {
+		// V1 returns NextMarker only if delimiter is specified, otherwise
+		// clients use the last key in the response
+		r.NextMarker = bckList.ContinuationToken
+	}
 }
 
 func FormatTime(t time.Time) string {
@@ -103,16 +159,88 @@ func FormatTime(t time.Time) string {
 	return strings.Replace(s, "UTC", "GMT", 1) // expects: "%a, %d %b %Y %H:%M:%S GMT"
 }
 
-func SetHeaderFromLOM(header http.Header, lom *cluster.LOM, size int64) {
+// ETag returns S3 entity tag of the object: MD5 for objects that came from
+// Amazon, composite ETag for objects assembled from multipart upload, and the
+// object's checksum otherwise.
+func ETag(lom *cluster.LOM) string {
 	if v, exists := lom.GetCustomMD(cluster.SourceObjMD); exists && v == cluster.SourceAmazonObjMD {
 		if v, exists := lom.GetCustomMD(cluster.MD5ObjMD); exists {
-			header.Set(headerETag, v)
+			return v
+		}
+	} else if v, exists := lom.GetCustomMD(MptETagMD); exists {
+		return v
+	}
+	if cksum := lom.ContentCksum(); !cksum.IsEmpty() {
+		return cksum.Value()
+	}
+	if cksum := lom.Cksum(); !cksum.IsEmpty() {
+		return cksum.Value()
+	}
+	return ""
+}
+
+// CheckPreconditions evaluates conditional headers of GET and HEAD requests
+// as per RFC 7232, section 6. Returns `http.StatusPreconditionFailed` or
+// `http.StatusNotModified` if the object must not be sent, and 0 otherwise.
+// NOTE: modification time of an object is its access time - the same as
+// `Last-Modified` header returns.
+func CheckPreconditions(hdr http.Header, lom *cluster.LOM) int {
+	var (
+		etag  = ETag(lom)
+		mtime = lom.Atime().Truncate(time.Second) // HTTP dates have one-second resolution
+	)
+	if v := hdr.Get(headerIfMatch); v != "" {
+		if !etagMatch(v, etag) {
+			return http.StatusPreconditionFailed
+		}
+	} else if t, err := http.ParseTime(hdr.Get(headerIfUnmodifiedSince)); err == nil && mtime.After(t) {
+		return http.StatusPreconditionFailed
+	}
+	if v := hdr.Get(headerIfNoneMatch); v != "" {
+		if etagMatch(v, etag) {
+			return http.StatusNotModified
+		}
+	} else if t, err := http.ParseTime(hdr.Get(headerIfModifiedSince)); err == nil && !mtime.After(t) {
+		return http.StatusNotModified
+	}
+	return 0
+}
+
+// Checks if the list of entity tags from a conditional header contains `etag`
+func etagMatch(list, etag string) bool {
+	for _, v := range strings.Split(list, ",") {
+		v = strings.TrimSpace(v)
+		if v == "*" {
+			return true
+		}
+		v = strings.Trim(strings.TrimPrefix(v, "W/"), "\"")
+		if etag != "" && v == etag {
+			return true
 		}
 	}
+	return false
+}
+
+// SetNotModifiedHeader sets the headers of 304 (Not Modified) response
+func SetNotModifiedHeader(header http.Header, lom *cluster.LOM) {
+	if etag := ETag(lom); etag != "" {
+		header.Set(headerETag, etag)
+	}
+	header.Set(headerAtime, FormatTime(lom.Atime()))
+}
+
+func SetHeaderFromLOM(header http.Header, lom *cluster.LOM, size int64) {
+	if etag := ETag(lom); etag != "" {
+		header.Set(headerETag, etag)
+	}
 	header.Set(headerAtime, FormatTime(lom.Atime()))
+	header.Set(cmn.HeaderAcceptRanges, "bytes")
 	header.Set(cmn.HeaderContentLength, strconv.FormatInt(size, 10))
 	header.Set(cmn.HeaderContentType, cmn.ContentBinary)
 	header.Set(headerVersion, lom.Version())
+	setUserMDHeader(header, lom)
+	setObjLockHeader(header, lom)
+	SetSSEHeader(header, lom)
 }
 
 func SetETLHeader(header http.Header, lom *cluster.LOM) {
@@ -0,0 +1,34 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestETagMatch(t *testing.T) {
+	const etag = "7a9388fc22cac381fecfd9e32cd0bdc7-2"
+	tests := []struct {
+		list  string
+		etag  string
+		match bool
+	}{
+		{list: `"` + etag + `"`, etag: etag, match: true},
+		{list: etag, etag: etag, match: true},
+		{list: `W/"` + etag + `"`, etag: etag, match: true},
+		{list: `"abc", "` + etag + `"`, etag: etag, match: true},
+		{list: "*", etag: etag, match: true},
+		{list: "*", etag: "", match: true},
+		{list: `"abc"`, etag: etag, match: false},
+		{list: `""`, etag: "", match: false},
+	}
+	for _, test := range tests {
+		match := etagMatch(test.list, test.etag)
+		tassert.Errorf(t, match == test.match, "etagMatch(%q, %q) = %t, expected %t",
+			test.list, test.etag, match, test.match)
+	}
+}
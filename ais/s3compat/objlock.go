@@ -0,0 +1,255 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// S3 object lock is mapped to AIS object lock (see cmn.ObjectLockConf):
+//   - bucket `?object-lock` configuration => `object_lock` bucket properties
+//     (the default retention in years is converted to days);
+//   - object `?retention` and `?legal-hold`, as well as the respective
+//     `x-amz-object-lock-*` headers of PUT request => object retention and
+//     legal hold stored in the object's metadata (see cluster/lom_retention.go).
+// Unlike S3, AIS does not require versioning to be enabled in the bucket.
+
+const (
+	URLParamObjectLock = "object-lock"
+	URLParamRetention  = "retention"
+	URLParamLegalHold  = "legal-hold"
+
+	HeaderBypassGovernance = "x-amz-bypass-governance-retention"
+	headerLockMode         = "x-amz-object-lock-mode"
+	headerLockRetainUntil  = "x-amz-object-lock-retain-until-date"
+	headerLockLegalHold    = "x-amz-object-lock-legal-hold"
+
+	objectLockEnabled = "Enabled"
+	legalHoldOn       = "ON"
+	legalHoldOff      = "OFF"
+)
+
+type (
+	// GetObjectLockConfiguration response and PutObjectLockConfiguration request body
+	ObjectLockConfiguration struct {
+		XMLName           xml.Name        `xml:"ObjectLockConfiguration"`
+		Ns                string          `xml:"xmlns,attr,omitempty"`
+		ObjectLockEnabled string          `xml:"ObjectLockEnabled,omitempty"`
+		Rule              *ObjectLockRule `xml:"Rule,omitempty"`
+	}
+	ObjectLockRule struct {
+		DefaultRetention DefaultRetention `xml:"DefaultRetention"`
+	}
+	DefaultRetention struct {
+		Mode  string `xml:"Mode"`
+		Days  int    `xml:"Days,omitempty"`
+		Years int    `xml:"Years,omitempty"`
+	}
+
+	// GetObjectRetention response and PutObjectRetention request body
+	Retention struct {
+		XMLName         xml.Name `xml:"Retention"`
+		Ns              string   `xml:"xmlns,attr,omitempty"`
+		Mode            string   `xml:"Mode,omitempty"`
+		RetainUntilDate string   `xml:"RetainUntilDate,omitempty"`
+	}
+
+	// GetObjectLegalHold response and PutObjectLegalHold request body
+	LegalHold struct {
+		XMLName xml.Name `xml:"LegalHold"`
+		Ns      string   `xml:"xmlns,attr,omitempty"`
+		Status  string   `xml:"Status"`
+	}
+)
+
+var ErrNoObjectLock = errors.New("object lock configuration does not exist for this bucket")
+
+// ParseObjectLock decodes PutObjectLockConfiguration request body and
+// converts it to the bucket properties update.
+func ParseObjectLock(r io.Reader) (*cmn.ObjectLockConfToUpdate, error) {
+	lc := &ObjectLockConfiguration{}
+	if err := xml.NewDecoder(r).Decode(lc); err != nil {
+		return nil, err
+	}
+	if lc.ObjectLockEnabled != objectLockEnabled {
+		return nil, fmt.Errorf("invalid ObjectLockEnabled %q (expected %q)", lc.ObjectLockEnabled, objectLockEnabled)
+	}
+	var (
+		enabled = true
+		mode    string
+		days    int
+	)
+	if lc.Rule != nil {
+		dr := &lc.Rule.DefaultRetention
+		if (dr.Days == 0) == (dr.Years == 0) {
+			return nil, errors.New("default retention must specify either days or years")
+		}
+		var err error
+		if mode, err = parseLockMode(dr.Mode); err != nil {
+			return nil, err
+		}
+		days = dr.Days + 365*dr.Years
+	}
+	return &cmn.ObjectLockConfToUpdate{Enabled: &enabled, Mode: &mode, Days: &days}, nil
+}
+
+// NewObjectLockConfiguration converts the bucket object lock properties to S3 format.
+func NewObjectLockConfiguration(conf *cmn.ObjectLockConf) *ObjectLockConfiguration {
+	lc := &ObjectLockConfiguration{Ns: s3Namespace, ObjectLockEnabled: objectLockEnabled}
+	if conf.Days > 0 {
+		lc.Rule = &ObjectLockRule{
+			DefaultRetention: DefaultRetention{Mode: strings.ToUpper(conf.Mode), Days: conf.Days},
+		}
+	}
+	return lc
+}
+
+func (lc *ObjectLockConfiguration) MustMarshal() []byte {
+	b, err := xml.Marshal(lc)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// ParseRetention decodes PutObjectRetention request body. Empty mode means
+// that the retention is to be removed.
+func ParseRetention(r io.Reader) (mode string, until time.Time, err error) {
+	ret := &Retention{}
+	if err = xml.NewDecoder(r).Decode(ret); err != nil {
+		return
+	}
+	return parseRetention(ret.Mode, ret.RetainUntilDate)
+}
+
+// NewRetention returns the retention of the object in S3 format.
+func NewRetention(lom *cluster.LOM) *Retention {
+	ret := &Retention{Ns: s3Namespace}
+	if mode, until := lom.Retention(); mode != "" {
+		ret.Mode = strings.ToUpper(mode)
+		ret.RetainUntilDate = until.UTC().Format(time.RFC3339)
+	}
+	return ret
+}
+
+func (ret *Retention) MustMarshal() []byte {
+	b, err := xml.Marshal(ret)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// ParseLegalHold decodes PutObjectLegalHold request body.
+func ParseLegalHold(r io.Reader) (on bool, err error) {
+	lh := &LegalHold{}
+	if err = xml.NewDecoder(r).Decode(lh); err != nil {
+		return
+	}
+	return parseLegalHold(lh.Status)
+}
+
+// NewLegalHold returns the legal hold status of the object in S3 format.
+func NewLegalHold(lom *cluster.LOM) *LegalHold {
+	lh := &LegalHold{Ns: s3Namespace, Status: legalHoldOff}
+	if lom.LegalHold() {
+		lh.Status = legalHoldOn
+	}
+	return lh
+}
+
+func (lh *LegalHold) MustMarshal() []byte {
+	b, err := xml.Marshal(lh)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// ObjLockFromHeader applies retention and legal hold specified in the PUT
+// request header (if any) to a new object.
+func ObjLockFromHeader(hdr http.Header, lom *cluster.LOM) error {
+	var (
+		mode      = hdr.Get(headerLockMode)
+		untilStr  = hdr.Get(headerLockRetainUntil)
+		legalHold = hdr.Get(headerLockLegalHold)
+	)
+	if mode == "" && untilStr == "" && legalHold == "" {
+		return nil
+	}
+	if !lom.Bprops().ObjectLock.Enabled {
+		return fmt.Errorf("bucket %s does not have object lock enabled", lom.Bck())
+	}
+	if mode != "" || untilStr != "" {
+		if mode == "" || untilStr == "" {
+			return fmt.Errorf("both %s and %s headers must be specified", headerLockMode, headerLockRetainUntil)
+		}
+		lockMode, until, err := parseRetention(mode, untilStr)
+		if err != nil {
+			return err
+		}
+		if err := lom.SetRetention(lockMode, until, false /*bypass governance*/); err != nil {
+			return err
+		}
+	}
+	if legalHold != "" {
+		on, err := parseLegalHold(legalHold)
+		if err != nil {
+			return err
+		}
+		lom.SetLegalHold(on)
+	}
+	return nil
+}
+
+// Adds retention and legal hold of the object (if any) to the response header
+func setObjLockHeader(header http.Header, lom *cluster.LOM) {
+	if mode, until := lom.Retention(); mode != "" {
+		header.Set(headerLockMode, strings.ToUpper(mode))
+		header.Set(headerLockRetainUntil, until.UTC().Format(time.RFC3339))
+	}
+	if lom.LegalHold() {
+		header.Set(headerLockLegalHold, legalHoldOn)
+	}
+}
+
+func parseLockMode(s string) (string, error) {
+	mode := strings.ToLower(s)
+	if mode != cmn.LockModeGovernance && mode != cmn.LockModeCompliance {
+		return "", fmt.Errorf("invalid object lock mode %q", s)
+	}
+	return mode, nil
+}
+
+func parseRetention(modeStr, untilStr string) (mode string, until time.Time, err error) {
+	if modeStr == "" && untilStr == "" {
+		return
+	}
+	if mode, err = parseLockMode(modeStr); err != nil {
+		return
+	}
+	if until, err = time.Parse(time.RFC3339, untilStr); err != nil {
+		err = fmt.Errorf("invalid retain until date %q: %v", untilStr, err)
+		return
+	}
+	if !until.After(time.Now()) {
+		err = fmt.Errorf("retain until date %q must be in the future", untilStr)
+	}
+	return
+}
+
+func parseLegalHold(s string) (bool, error) {
+	switch s {
+	case legalHoldOn:
+		return true, nil
+	case legalHoldOff:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid legal hold status %q", s)
+	}
+}
@@ -0,0 +1,67 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestParseObjectLock(t *testing.T) {
+	const body = `<ObjectLockConfiguration>
+		<ObjectLockEnabled>Enabled</ObjectLockEnabled>
+		<Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Years>1</Years></DefaultRetention></Rule>
+	</ObjectLockConfiguration>`
+	conf, err := ParseObjectLock(strings.NewReader(body))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, *conf.Enabled && *conf.Mode == cmn.LockModeCompliance && *conf.Days == 365,
+		"invalid object lock: (%t, %s, %d)", *conf.Enabled, *conf.Mode, *conf.Days)
+
+	conf, err = ParseObjectLock(strings.NewReader(
+		`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, *conf.Enabled && *conf.Mode == "" && *conf.Days == 0,
+		"expected no default retention, got (%s, %d)", *conf.Mode, *conf.Days)
+
+	for _, invalid := range []string{
+		`<ObjectLockConfiguration></ObjectLockConfiguration>`,
+		`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled>
+			<Rule><DefaultRetention><Mode>GOVERNANCE</Mode></DefaultRetention></Rule></ObjectLockConfiguration>`,
+		`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled>
+			<Rule><DefaultRetention><Mode>STRICT</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`,
+	} {
+		_, err := ParseObjectLock(strings.NewReader(invalid))
+		tassert.Errorf(t, err != nil, "expected error for %q", invalid)
+	}
+}
+
+func TestParseRetention(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	body := `<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>` + future.Format(time.RFC3339) +
+		`</RetainUntilDate></Retention>`
+	mode, until, err := ParseRetention(strings.NewReader(body))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, mode == cmn.LockModeGovernance && until.Equal(future),
+		"invalid retention: (%s, %v)", mode, until)
+
+	mode, _, err = ParseRetention(strings.NewReader(`<Retention></Retention>`))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, mode == "", "expected empty retention, got %q", mode)
+
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	_, _, err = ParseRetention(strings.NewReader(
+		`<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>` + past + `</RetainUntilDate></Retention>`))
+	tassert.Errorf(t, err != nil, "expected error for retention in the past")
+
+	on, err := ParseLegalHold(strings.NewReader(`<LegalHold><Status>ON</Status></LegalHold>`))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, on, "expected legal hold")
+	_, err = ParseLegalHold(strings.NewReader(`<LegalHold><Status>on</Status></LegalHold>`))
+	tassert.Errorf(t, err != nil, "expected error for invalid legal hold status")
+}
@@ -0,0 +1,383 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// AWS Signature Version 4: both variants - `Authorization` header and
+// presigned URL (query parameters) - are supported.
+// See: https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+//
+// Payload hash (`x-amz-content-sha256`) is included into the signature as is
+// and gets verified against the body by the node that reads it (see
+// `VerifyPayload`). `UNSIGNED-PAYLOAD` (always the case with presigned URLs)
+// is accepted, as in S3.
+// Payloads signed chunk by chunk (`aws-chunked` encoding) are not supported
+// and get rejected (see `CheckPayload`).
+
+const (
+	signV4Algorithm = "AWS4-HMAC-SHA256"
+	signV4Request   = "aws4_request"
+	signV4Service   = "s3"
+	signV4TimeFmt   = "20060102T150405Z"
+	signV4DateFmt   = "20060102"
+
+	headerAmzDate       = "X-Amz-Date"
+	headerContentSHA256 = "X-Amz-Content-Sha256"
+	unsignedPayload     = "UNSIGNED-PAYLOAD"
+	streamingPayload    = "STREAMING-" // e.g. STREAMING-AWS4-HMAC-SHA256-PAYLOAD
+	awsChunkedEncoding  = "aws-chunked"
+
+	// presigned URL query parameters
+	URLParamAlgorithm     = "X-Amz-Algorithm"
+	URLParamCredential    = "X-Amz-Credential"
+	URLParamDate          = "X-Amz-Date"
+	URLParamExpires       = "X-Amz-Expires"
+	URLParamSignedHeaders = "X-Amz-SignedHeaders"
+	URLParamSignature     = "X-Amz-Signature"
+
+	maxClockSkew      = 15 * time.Minute
+	maxPresignExpires = 7 * 24 * time.Hour
+
+	// larger payloads are verified while being read (see VerifyPayload)
+	maxBufferedPayload = cmn.MiB
+)
+
+type (
+	// Parsed AWS Signature V4 of a request
+	SigV4 struct {
+		AccessKey     string
+		scopeDate     string
+		region        string
+		service       string
+		signedHeaders []string
+		signature     string
+		date          time.Time
+		expires       time.Duration // presigned URL only
+		presigned     bool
+	}
+)
+
+var (
+	ErrNoSignature      = errors.New("request is not signed")
+	ErrSignatureInvalid = errors.New("the request signature does not match")
+	ErrStreamingPayload = errors.New("aws-chunked (streaming) payload is not supported, " +
+		"use UNSIGNED-PAYLOAD or SHA256 of the entire payload instead")
+	ErrContentSHA256Mismatch = errors.New("the provided 'x-amz-content-sha256' header does not match what was computed")
+)
+
+type (
+	// verifies payload hash once the body is read in its entirety
+	payloadReader struct {
+		io.ReadCloser
+		h        hash.Hash
+		expected []byte
+	}
+)
+
+// ParseSigV4 reads the signature either from `Authorization` header or from
+// the query parameters of a presigned URL. Returns `ErrNoSignature` if the
+// request is not signed.
+func ParseSigV4(r *http.Request) (*SigV4, error) {
+	query := r.URL.Query()
+	if query.Get(URLParamAlgorithm) != "" {
+		return parsePresigned(query)
+	}
+	auth := r.Header.Get(cmn.HeaderAuthorization)
+	if auth == "" {
+		return nil, ErrNoSignature
+	}
+	return parseAuthHeader(auth, r.Header)
+}
+
+// CheckPayload returns `ErrStreamingPayload` if the request body is encoded
+// with `aws-chunked` - the chunks (along with their signatures) would be
+// otherwise stored as the object content.
+func CheckPayload(hdr http.Header) error {
+	if strings.HasPrefix(hdr.Get(headerContentSHA256), streamingPayload) {
+		return ErrStreamingPayload
+	}
+	for _, enc := range strings.Split(hdr.Get(cmn.HeaderContentEncoding), ",") {
+		if strings.TrimSpace(enc) == awsChunkedEncoding {
+			return ErrStreamingPayload
+		}
+	}
+	return nil
+}
+
+// VerifyPayload makes sure that the request body matches its SHA256 provided
+// in `x-amz-content-sha256` (if any). Small payloads are read and verified
+// right away, while larger ones - as they are read: in the latter case, the
+// body returns `ErrContentSHA256Mismatch` instead of `io.EOF`, thus failing
+// the write before the object is finalized.
+func VerifyPayload(r *http.Request) error {
+	value := r.Header.Get(headerContentSHA256)
+	if value == "" || value == unsignedPayload || strings.HasPrefix(value, streamingPayload) {
+		return nil
+	}
+	expected, err := hex.DecodeString(value)
+	if err != nil || len(expected) != sha256.Size {
+		return fmt.Errorf("invalid %s %q", headerContentSHA256, value)
+	}
+	if r.ContentLength < 0 || r.ContentLength > maxBufferedPayload {
+		r.Body = &payloadReader{ReadCloser: r.Body, h: sha256.New(), expected: expected}
+		return nil
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	cmn.Close(r.Body)
+	if err != nil {
+		return err
+	}
+	if sum := sha256.Sum256(body); !bytes.Equal(sum[:], expected) {
+		return ErrContentSHA256Mismatch
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return nil
+}
+
+func IsErrContentSHA256Mismatch(err error) bool { return errors.Is(err, ErrContentSHA256Mismatch) }
+
+func (pr *payloadReader) Read(b []byte) (n int, err error) {
+	n, err = pr.ReadCloser.Read(b)
+	pr.h.Write(b[:n])
+	if err == io.EOF && !bytes.Equal(pr.h.Sum(nil), pr.expected) {
+		err = ErrContentSHA256Mismatch
+	}
+	return
+}
+
+// Header format:
+// AWS4-HMAC-SHA256 Credential=AKID/20130524/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-date, Signature=abc
+func parseAuthHeader(auth string, hdr http.Header) (sig *SigV4, err error) {
+	if !strings.HasPrefix(auth, signV4Algorithm+" ") {
+		return nil, fmt.Errorf("unsupported authorization type, expected %q", signV4Algorithm)
+	}
+	sig = &SigV4{}
+	for _, field := range strings.Split(auth[len(signV4Algorithm)+1:], ",") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid authorization field %q", field)
+		}
+		switch kv[0] {
+		case "Credential":
+			err = sig.parseCredential(kv[1])
+		case "SignedHeaders":
+			sig.signedHeaders = strings.Split(kv[1], ";")
+		case "Signature":
+			sig.signature = kv[1]
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if sig.AccessKey == "" || sig.signature == "" || len(sig.signedHeaders) == 0 {
+		return nil, errors.New("incomplete authorization header")
+	}
+	if sig.date, err = parseSigDate(hdr); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+func parsePresigned(query url.Values) (sig *SigV4, err error) {
+	if alg := query.Get(URLParamAlgorithm); alg != signV4Algorithm {
+		return nil, fmt.Errorf("unsupported algorithm %q, expected %q", alg, signV4Algorithm)
+	}
+	sig = &SigV4{
+		signedHeaders: strings.Split(query.Get(URLParamSignedHeaders), ";"),
+		signature:     query.Get(URLParamSignature),
+		presigned:     true,
+	}
+	if err = sig.parseCredential(query.Get(URLParamCredential)); err != nil {
+		return nil, err
+	}
+	if sig.signature == "" {
+		return nil, errors.New("missing signature")
+	}
+	if sig.date, err = time.Parse(signV4TimeFmt, query.Get(URLParamDate)); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", URLParamDate, err)
+	}
+	expires, err := strconv.ParseInt(query.Get(URLParamExpires), 10, 64)
+	if err != nil || expires <= 0 {
+		return nil, fmt.Errorf("invalid %s %q", URLParamExpires, query.Get(URLParamExpires))
+	}
+	sig.expires = time.Duration(expires) * time.Second
+	if sig.expires > maxPresignExpires {
+		return nil, fmt.Errorf("%s must be less than %v", URLParamExpires, maxPresignExpires)
+	}
+	return sig, nil
+}
+
+// Credential format: AKID/20130524/us-east-1/s3/aws4_request
+func (sig *SigV4) parseCredential(cred string) error {
+	parts := strings.Split(cred, "/")
+	if len(parts) != 5 || parts[0] == "" || parts[4] != signV4Request {
+		return fmt.Errorf("invalid credential %q", cred)
+	}
+	if parts[3] != signV4Service {
+		return fmt.Errorf("invalid service %q in credential, expected %q", parts[3], signV4Service)
+	}
+	sig.AccessKey, sig.scopeDate, sig.region, sig.service = parts[0], parts[1], parts[2], parts[3]
+	return nil
+}
+
+func parseSigDate(hdr http.Header) (time.Time, error) {
+	if s := hdr.Get(headerAmzDate); s != "" {
+		return time.Parse(signV4TimeFmt, s)
+	}
+	if s := hdr.Get("Date"); s != "" {
+		return http.ParseTime(s)
+	}
+	return time.Time{}, errors.New("missing request date")
+}
+
+// Verify checks that the request is signed with the given secret key and
+// that the signature has not expired yet.
+func (sig *SigV4) Verify(r *http.Request, secretKey string, now time.Time) error {
+	if sig.presigned {
+		if now.Before(sig.date.Add(-maxClockSkew)) || now.After(sig.date.Add(sig.expires)) {
+			return errors.New("request has expired")
+		}
+	} else if d := now.Sub(sig.date); d > maxClockSkew || d < -maxClockSkew {
+		return errors.New("the difference between the request time and the current time is too large")
+	}
+	if sig.scopeDate != sig.date.UTC().Format(signV4DateFmt) {
+		return fmt.Errorf("credential date %q does not match request date", sig.scopeDate)
+	}
+	expected := sig.calcSignature(r, secretKey)
+	if !hmac.Equal([]byte(expected), []byte(sig.signature)) {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+func (sig *SigV4) calcSignature(r *http.Request, secretKey string) string {
+	var (
+		scope        = strings.Join([]string{sig.scopeDate, sig.region, sig.service, signV4Request}, "/")
+		canonicalReq = sig.canonicalRequest(r)
+		hash         = sha256.Sum256([]byte(canonicalReq))
+		stringToSign = strings.Join([]string{
+			signV4Algorithm,
+			sig.date.UTC().Format(signV4TimeFmt),
+			scope,
+			hex.EncodeToString(hash[:]),
+		}, "\n")
+	)
+	key := hmacSHA256([]byte("AWS4"+secretKey), sig.scopeDate)
+	key = hmacSHA256(key, sig.region)
+	key = hmacSHA256(key, sig.service)
+	key = hmacSHA256(key, signV4Request)
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func (sig *SigV4) canonicalRequest(r *http.Request) string {
+	var (
+		sb          strings.Builder
+		payloadHash = unsignedPayload
+	)
+	if !sig.presigned {
+		if v := r.Header.Get(headerContentSHA256); v != "" {
+			payloadHash = v
+		}
+	}
+	sb.WriteString(r.Method)
+	sb.WriteByte('\n')
+	path := r.URL.Path
+	if path == "" {
+		path = "/"
+	}
+	sb.WriteString(uriEncode(path, false))
+	sb.WriteByte('\n')
+	sb.WriteString(canonicalQuery(r.URL.RawQuery, sig.presigned))
+	sb.WriteByte('\n')
+	for _, name := range sig.signedHeaders {
+		sb.WriteString(name)
+		sb.WriteByte(':')
+		sb.WriteString(canonicalHeaderValue(r, name))
+		sb.WriteByte('\n')
+	}
+	sb.WriteByte('\n')
+	sb.WriteString(strings.Join(sig.signedHeaders, ";"))
+	sb.WriteByte('\n')
+	sb.WriteString(payloadHash)
+	return sb.String()
+}
+
+func canonicalQuery(rawQuery string, presigned bool) string {
+	query, _ := url.ParseQuery(rawQuery)
+	params := make([]string, 0, len(query))
+	for key, values := range query {
+		if presigned && key == URLParamSignature {
+			continue
+		}
+		ekey := uriEncode(key, true)
+		for _, value := range values {
+			params = append(params, ekey+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+func canonicalHeaderValue(r *http.Request, name string) string {
+	switch name {
+	case "host":
+		return r.Host
+	case "content-length":
+		if v := r.Header.Get(name); v != "" {
+			return v
+		}
+		return strconv.FormatInt(r.ContentLength, 10)
+	}
+	values := r.Header.Values(name)
+	trimmed := make([]string, 0, len(values))
+	for _, v := range values {
+		trimmed = append(trimmed, strings.Join(strings.Fields(v), " "))
+	}
+	return strings.Join(trimmed, ",")
+}
+
+// URI-encode every byte except the unreserved characters: 'A'-'Z', 'a'-'z',
+// '0'-'9', '-', '.', '_', and '~'. Slash is encoded only in query.
+func uriEncode(s string, encodeSlash bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~':
+			sb.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
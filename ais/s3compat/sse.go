@@ -0,0 +1,188 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/sse"
+)
+
+// S3 server-side encryption is mapped to AIS server-side encryption (see
+// cmn.EncryptionConf and package sse):
+//   - bucket `?encryption` configuration => `encryption` bucket properties
+//     (AES256 - the KMS default key, aws:kms - a given or the default key);
+//   - SSE-S3 and SSE-KMS headers of PUT request => the key to encrypt the
+//     object with (overriding the bucket's properties);
+//   - SSE-C headers of PUT, GET, and HEAD requests => the client's key that
+//     the object is (to be) encrypted with; the key itself is never stored.
+// Multipart uploads are encrypted as specified at CreateMultipartUpload.
+
+const (
+	URLParamEncryption = "encryption"
+
+	headerSSE           = "x-amz-server-side-encryption"
+	headerSSEKeyID      = "x-amz-server-side-encryption-aws-kms-key-id"
+	headerSSECAlgorithm = "x-amz-server-side-encryption-customer-algorithm"
+	headerSSECKey       = "x-amz-server-side-encryption-customer-key"
+	headerSSECKeyMD5    = "x-amz-server-side-encryption-customer-key-MD5"
+
+	sseAES256 = "AES256"
+	sseKMS    = "aws:kms"
+)
+
+type (
+	// GetBucketEncryption response and PutBucketEncryption request body
+	ServerSideEncryptionConfiguration struct {
+		XMLName xml.Name          `xml:"ServerSideEncryptionConfiguration"`
+		Ns      string            `xml:"xmlns,attr,omitempty"`
+		Rules   []*EncryptionRule `xml:"Rule"`
+	}
+	EncryptionRule struct {
+		Default EncryptionByDefault `xml:"ApplyServerSideEncryptionByDefault"`
+	}
+	EncryptionByDefault struct {
+		SSEAlgorithm   string `xml:"SSEAlgorithm"`
+		KMSMasterKeyID string `xml:"KMSMasterKeyID,omitempty"`
+	}
+)
+
+var ErrNoEncryption = errors.New("server side encryption configuration was not found")
+
+// ParseEncryption decodes PutBucketEncryption request body and converts it
+// to the bucket properties update.
+func ParseEncryption(r io.Reader) (*cmn.EncryptionConfToUpdate, error) {
+	ec := &ServerSideEncryptionConfiguration{}
+	if err := xml.NewDecoder(r).Decode(ec); err != nil {
+		return nil, err
+	}
+	if len(ec.Rules) != 1 {
+		return nil, fmt.Errorf("expected exactly one encryption rule, got %d", len(ec.Rules))
+	}
+	params, err := parseSSE(ec.Rules[0].Default.SSEAlgorithm, ec.Rules[0].Default.KMSMasterKeyID)
+	if err != nil {
+		return nil, err
+	}
+	enabled := true
+	return &cmn.EncryptionConfToUpdate{Enabled: &enabled, KeyID: &params.KeyID}, nil
+}
+
+// NewEncryptionConfiguration converts the bucket encryption properties to S3 format.
+func NewEncryptionConfiguration(conf *cmn.EncryptionConf) *ServerSideEncryptionConfiguration {
+	rule := &EncryptionRule{Default: EncryptionByDefault{SSEAlgorithm: sseAES256}}
+	if conf.KeyID != "" {
+		rule.Default = EncryptionByDefault{SSEAlgorithm: sseKMS, KMSMasterKeyID: conf.KeyID}
+	}
+	return &ServerSideEncryptionConfiguration{Ns: s3Namespace, Rules: []*EncryptionRule{rule}}
+}
+
+func (ec *ServerSideEncryptionConfiguration) MustMarshal() []byte {
+	b, err := xml.Marshal(ec)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// SSEFromHeader returns the encryption requested by PUT request header,
+// or nil if the object is to be encrypted as per bucket properties.
+func SSEFromHeader(hdr http.Header) (*sse.Params, error) {
+	customerKey, err := CustomerKeyFromHeader(hdr)
+	if err != nil {
+		return nil, err
+	}
+	alg := hdr.Get(headerSSE)
+	if customerKey != nil {
+		if alg != "" {
+			return nil, fmt.Errorf("%s cannot be specified along with %s", headerSSE, headerSSECKey)
+		}
+		return &sse.Params{CustomerKey: customerKey}, nil
+	}
+	if alg == "" {
+		if hdr.Get(headerSSEKeyID) != "" {
+			return nil, fmt.Errorf("%s requires %s", headerSSEKeyID, headerSSE)
+		}
+		return nil, nil
+	}
+	return parseSSE(alg, hdr.Get(headerSSEKeyID))
+}
+
+// CustomerKeyFromHeader returns the client-provided key (SSE-C), if any.
+func CustomerKeyFromHeader(hdr http.Header) ([]byte, error) {
+	var (
+		alg    = hdr.Get(headerSSECAlgorithm)
+		keyStr = hdr.Get(headerSSECKey)
+		keyMD5 = hdr.Get(headerSSECKeyMD5)
+	)
+	if alg == "" && keyStr == "" && keyMD5 == "" {
+		return nil, nil
+	}
+	if alg != sseAES256 {
+		return nil, fmt.Errorf("invalid %s %q (expected %q)", headerSSECAlgorithm, alg, sseAES256)
+	}
+	key, err := base64.StdEncoding.DecodeString(keyStr)
+	if err != nil || len(key) != sse.KeySize {
+		return nil, fmt.Errorf("invalid %s (expected base64-encoded %d-byte key)", headerSSECKey, sse.KeySize)
+	}
+	if keyMD5 != sse.CustomerKeyMD5(key) {
+		return nil, fmt.Errorf("%s does not match the key", headerSSECKeyMD5)
+	}
+	return key, nil
+}
+
+// SetSSEHeader adds encryption of the object (if any) to the response header.
+func SetSSEHeader(header http.Header, lom *cluster.LOM) {
+	if !lom.Encrypted() {
+		return
+	}
+	info, err := sse.ParseInfo(lom.SSE())
+	if err != nil {
+		return
+	}
+	switch {
+	case info.IsCustomer():
+		header.Set(headerSSECAlgorithm, sseAES256)
+		header.Set(headerSSECKeyMD5, info.KeyMD5)
+	case info.KeyID == sse.DefaultKeyID():
+		header.Set(headerSSE, sseAES256)
+	default:
+		header.Set(headerSSE, sseKMS)
+		header.Set(headerSSEKeyID, info.KeyID)
+	}
+}
+
+// CheckCustomerKey validates the client-provided key (SSE-C) of HEAD request
+// against the key the object is encrypted with.
+func CheckCustomerKey(hdr http.Header, lom *cluster.LOM) error {
+	customerKey, err := CustomerKeyFromHeader(hdr)
+	if err != nil || !lom.Encrypted() {
+		return err
+	}
+	info, err := sse.ParseInfo(lom.SSE())
+	if err != nil || !info.IsCustomer() {
+		return err
+	}
+	_, err = info.Key(customerKey)
+	return err
+}
+
+func parseSSE(alg, keyID string) (*sse.Params, error) {
+	switch alg {
+	case sseAES256:
+		if keyID != "" {
+			return nil, fmt.Errorf("KMS key ID cannot be specified with %q encryption", sseAES256)
+		}
+		return &sse.Params{}, nil
+	case sseKMS:
+		return &sse.Params{KeyID: keyID}, nil
+	default:
+		return nil, fmt.Errorf("invalid server-side encryption algorithm %q", alg)
+	}
+}
@@ -0,0 +1,75 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+	"github.com/NVIDIA/aistore/sse"
+)
+
+func TestParseEncryption(t *testing.T) {
+	conf, err := ParseEncryption(strings.NewReader(`<ServerSideEncryptionConfiguration>
+		<Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm>
+		<KMSMasterKeyID>k1</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule>
+	</ServerSideEncryptionConfiguration>`))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, *conf.Enabled && *conf.KeyID == "k1", "invalid encryption: (%t, %q)", *conf.Enabled, *conf.KeyID)
+
+	for _, invalid := range []string{
+		`<ServerSideEncryptionConfiguration></ServerSideEncryptionConfiguration>`,
+		`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault>
+			<SSEAlgorithm>DES</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+		`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault>
+			<SSEAlgorithm>AES256</SSEAlgorithm><KMSMasterKeyID>k1</KMSMasterKeyID>
+			</ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+	} {
+		_, err := ParseEncryption(strings.NewReader(invalid))
+		tassert.Errorf(t, err != nil, "expected error for %q", invalid)
+	}
+}
+
+func TestSSEFromHeader(t *testing.T) {
+	var (
+		key    = bytes.Repeat([]byte{7}, sse.KeySize)
+		keyStr = base64.StdEncoding.EncodeToString(key)
+		hdr    = http.Header{}
+	)
+	params, err := SSEFromHeader(hdr)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, params == nil, "expected no encryption, got %+v", params)
+
+	hdr.Set(headerSSE, sseKMS)
+	hdr.Set(headerSSEKeyID, "k1")
+	params, err = SSEFromHeader(hdr)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, params.KeyID == "k1" && params.CustomerKey == nil, "invalid encryption: %+v", params)
+
+	hdr = http.Header{}
+	hdr.Set(headerSSECAlgorithm, sseAES256)
+	hdr.Set(headerSSECKey, keyStr)
+	hdr.Set(headerSSECKeyMD5, sse.CustomerKeyMD5(key))
+	params, err = SSEFromHeader(hdr)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(params.CustomerKey, key), "invalid customer key")
+
+	hdr.Set(headerSSE, sseAES256)
+	_, err = SSEFromHeader(hdr)
+	tassert.Errorf(t, err != nil, "expected error for SSE-C along with SSE-S3")
+
+	hdr.Del(headerSSE)
+	hdr.Set(headerSSECKeyMD5, sse.CustomerKeyMD5(key[1:]))
+	_, err = CustomerKeyFromHeader(hdr)
+	tassert.Errorf(t, err != nil, "expected error for mismatching key MD5")
+
+	hdr.Set(headerSSECKey, base64.StdEncoding.EncodeToString(key[1:]))
+	_, err = CustomerKeyFromHeader(hdr)
+	tassert.Errorf(t, err != nil, "expected error for invalid key size")
+}
@@ -30,12 +30,18 @@ import (
 	"github.com/NVIDIA/aistore/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/health"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/lifecycle"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/query"
 	"github.com/NVIDIA/aistore/reb"
+	"github.com/NVIDIA/aistore/replication"
+	"github.com/NVIDIA/aistore/sse"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/writeback"
 	"github.com/NVIDIA/aistore/xaction"
 	"github.com/NVIDIA/aistore/xaction/xreg"
 	jsoniter "github.com/json-iterator/go"
@@ -206,16 +212,26 @@ func (t *targetrunner) Run() error {
 
 	t.checkRestarted()
 
-	// register object type and workfile type
+	// register object type, workfile type, object version type, and multipart upload type
 	if err := fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{}); err != nil {
 		cmn.ExitLogf("%v", err)
 	}
 	if err := fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{}); err != nil {
 		cmn.ExitLogf("%v", err)
 	}
+	if err := fs.CSM.RegisterContentType(fs.VersionType, &fs.VersionContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.RegisterContentType(fs.MptType, &fs.MptContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
 
 	dryRunInit()
 
+	if err := sse.Init(&config.KMS); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+
 	// Init meta-owners and load local instances
 	t.owner.bmd.init()
 
@@ -311,6 +327,16 @@ func (t *targetrunner) Run() error {
 	dsort.InitManagers(driver)
 	dsort.RegisterNode(t.owner.smap, t.owner.bmd, t.si, t, t.statsT)
 
+	// resume replicating the operations that were queued before restart
+	go func() {
+		t.pollClusterStarted(config.Timeout.CplaneOperation)
+		replication.Resume(t, t.statsT)
+	}()
+
+	hk.Reg(cmn.ActLifecycle, t.lifecycleHK, lifecycle.Interval)
+	t.loadMptUploads()
+	hk.Reg("s3-mpt-uploads", t.mptHK, mptHKInterval)
+
 	defer etl.StopAll(t) // Always try to stop running ETLs.
 
 	err = t.httprunner.run()
@@ -346,6 +372,10 @@ func (c clouds) initExt(t *targetrunner) (err error) {
 			c[provider], err = cloud.NewGCP(t)
 		case cmn.ProviderAzure:
 			c[provider], err = cloud.NewAzure(t)
+		case cmn.ProviderHDFS:
+			c[provider], err = cloud.NewHDFS(t)
+		case cmn.ProviderPOSIX:
+			c[provider], err = cloud.NewPOSIX(t)
 		default:
 			err = fmt.Errorf("unknown cloud provider: %q", provider)
 		}
@@ -548,6 +578,7 @@ func (t *targetrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 		var (
 			rangeMsg = &cmn.RangeMsg{}
 			listMsg  = &cmn.ListMsg{}
+			queryMsg = &query.DefMsg{}
 		)
 		args := &xreg.DeletePrefetchArgs{
 			Ctx:   context.Background(),
@@ -558,6 +589,8 @@ func (t *targetrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 			args.RangeMsg = rangeMsg
 		} else if err := cmn.MorphMarshal(msg.Value, &listMsg); err == nil {
 			args.ListMsg = listMsg
+		} else if err := cmn.MorphMarshal(msg.Value, &queryMsg); err == nil {
+			args.QueryMsg = queryMsg
 		} else {
 			t.invalmsghdlrf(w, r, "invalid %s action message: %s, %T", msg.Action, msg.Name, msg.Value)
 			return
@@ -587,7 +620,7 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 	var (
 		bucket string
 		msg    = &aisMsg{}
-		query  = r.URL.Query()
+		q      = r.URL.Query()
 	)
 	if cmn.ReadJSON(w, r, msg) != nil {
 		return
@@ -602,7 +635,7 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 	if len(apiItems) == 0 {
 		switch msg.Action {
 		case cmn.ActSummaryBucket:
-			bck, err := newBckFromQuery("", query)
+			bck, err := newBckFromQuery("", q)
 			if err != nil {
 				t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
 				return
@@ -617,7 +650,7 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	bucket = apiItems[0]
-	bck, err := newBckFromQuery(bucket, query)
+	bck, err := newBckFromQuery(bucket, q)
 	if err != nil {
 		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
 		return
@@ -643,12 +676,15 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 			err      error
 			rangeMsg = &cmn.RangeMsg{}
 			listMsg  = &cmn.ListMsg{}
+			queryMsg = &query.DefMsg{}
 			args     = &xreg.DeletePrefetchArgs{Ctx: context.Background()}
 		)
 		if err = cmn.MorphMarshal(msg.Value, &rangeMsg); err == nil {
 			args.RangeMsg = rangeMsg
 		} else if err = cmn.MorphMarshal(msg.Value, &listMsg); err == nil {
 			args.ListMsg = listMsg
+		} else if err = cmn.MorphMarshal(msg.Value, &queryMsg); err == nil {
+			args.QueryMsg = queryMsg
 		} else {
 			t.invalmsghdlrf(w, r, "invalid %s action message: %s, %T", msg.Action, msg.Name, msg.Value)
 			return
@@ -656,6 +692,30 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 		args.UUID = msg.UUID
 		xact := xreg.RenewPrefetch(t, bck, args)
 		go xact.Run()
+	case cmn.ActSyncBucket:
+		if !bck.IsRemote() {
+			t.invalmsghdlrf(w, r, "%s: expecting remote bucket, got %s, action=%s", t.si, bck, msg.Action)
+			return
+		}
+		args := &xreg.SyncBckArgs{Ctx: context.Background(), UUID: msg.UUID, Msg: &cmn.SyncBckMsg{}}
+		if err := cmn.MorphMarshal(msg.Value, args.Msg); err != nil {
+			t.invalmsghdlrf(w, r, "invalid %s action message: %v", msg.Action, err)
+			return
+		}
+		xact, err := xreg.RenewSyncBck(t, bck, args)
+		if err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		xact.AddNotif(&xaction.NotifXact{
+			NotifBase: nl.NotifBase{
+				When: cluster.UponTerm,
+				Dsts: []string{equalIC},
+				F:    t.callerNotifyFin,
+			},
+			Xact: xact,
+		})
+		go xact.Run()
 	case cmn.ActListObjects:
 		// list the bucket and return
 		begin := mono.NanoTime()
@@ -814,6 +874,13 @@ func (t *targetrunner) getObject(w http.ResponseWriter, r *http.Request, query u
 		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
 		return
 	}
+	version := query.Get(cmn.URLParamVersion)
+	if version != "" {
+		if err := cluster.ValidateVersion(version); err != nil {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 	lom := &cluster.LOM{T: t, ObjName: objName}
 	if err = lom.Init(bck.Bck, config); err != nil {
 		if _, ok := err.(*cmn.ErrorRemoteBucketDoesNotExist); ok {
@@ -839,6 +906,7 @@ func (t *targetrunner) getObject(w http.ResponseWriter, r *http.Request, query u
 		ranges:  cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange), Size: 0},
 		isGFN:   isGFNRequest,
 		chunked: config.Net.HTTP.Chunked,
+		version: version,
 	}
 	if bck.IsHTTP() {
 		originalURL := query.Get(cmn.URLParamOrigURL)
@@ -919,7 +987,7 @@ func (t *targetrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 	lom.SetAtimeUnix(started.UnixNano())
 	appendTy := query.Get(cmn.URLParamAppendType)
 	if appendTy == "" {
-		if errCode, err := t.doPut(r, lom, started); err != nil {
+		if errCode, err := t.doPut(r, lom, started, nil /*encryption*/); err != nil {
 			t.fsErr(err, lom.FQN)
 			t.invalmsghdlr(w, r, err.Error(), errCode)
 		}
@@ -963,7 +1031,17 @@ func (t *targetrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		t.invalmsghdlr(w, r, err.Error())
 		return
 	}
-	errCode, err := t.DeleteObject(context.Background(), lom, evict)
+	bypassGovernance := cmn.IsParseBool(query.Get(cmn.URLParamBypassGovernance))
+	if version := query.Get(cmn.URLParamVersion); version != "" {
+		if err := cluster.ValidateVersion(version); err != nil {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+		t.deleteObjVersion(w, r, lom, version, bypassGovernance)
+		return
+	}
+	ctx := context.WithValue(context.Background(), cmn.CtxBypassGovernance, bypassGovernance)
+	errCode, err := t.DeleteObject(ctx, lom, evict)
 	if err != nil {
 		if errCode == http.StatusNotFound {
 			t.invalmsghdlrsilent(w, r,
@@ -979,6 +1057,35 @@ func (t *targetrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 	ec.ECM.CleanupObject(lom)
 }
 
+// DELETE /v1/objects/bucket-name/object-name?version=N
+// Permanently removes a given version of the object (see cluster.DeleteVersion)
+func (t *targetrunner) deleteObjVersion(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, version string,
+	bypassGovernance bool) {
+	if !lom.Bck().IsAIS() {
+		t.invalmsghdlrf(w, r, "%s: deleting object by version is supported only for ais buckets", lom)
+		return
+	}
+	lom.Lock(true)
+	err := lom.DeleteVersion(version, bypassGovernance)
+	lom.Unlock(true)
+	if err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.invalmsghdlrsilent(w, r,
+				fmt.Sprintf("object %s/%s version %q doesn't exist", lom.Bck(), lom.ObjName, version),
+				http.StatusNotFound,
+			)
+		} else if cmn.IsErrObjLocked(err) {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		} else {
+			t.invalmsghdlr(w, r, err.Error())
+		}
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: deleted %s version %q", t.si, lom, version)
+	}
+}
+
 // POST /v1/objects/bucket-name/object-name
 func (t *targetrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -1002,6 +1109,12 @@ func (t *targetrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		t.promoteFQN(w, r, &msg)
+	case cmn.ActReplicate:
+		if !isIntraCall(r.Header) {
+			t.invalmsghdlrf(w, r, "%s: %s-%s(obj) is expected to be intra-called", t.si, r.Method, msg.Action)
+			return
+		}
+		t.replicateObj(w, r)
 	default:
 		t.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
@@ -1098,6 +1211,17 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 			return
 		}
 		lom.ToHTTPHdr(hdr)
+		if lom.Encoded() {
+			// the size and checksum of the decoded content (the latter may be unknown)
+			hdr.Set(cmn.HeaderContentLength, strconv.FormatInt(lom.ContentSize(), 10))
+			if cksum := lom.ContentCksum(); !cksum.IsEmpty() {
+				hdr.Set(cmn.HeaderObjCksumType, cksum.Type())
+				hdr.Set(cmn.HeaderObjCksumVal, cksum.Value())
+			} else {
+				hdr.Del(cmn.HeaderObjCksumVal)
+				hdr.Del(cmn.HeaderObjCksumType)
+			}
+		}
 	} else {
 		objMeta, errCode, err := t.Cloud(lom.Bck()).HeadObj(context.Background(), lom)
 		if err != nil {
@@ -1115,8 +1239,15 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 		Present: exists,
 	}
 	if exists {
-		objProps.Size = lom.Size()
+		objProps.Size = lom.ContentSize()
 		objProps.NumCopies = lom.NumCopies()
+		objProps.Encrypted = lom.Encrypted()
+		objProps.Compressed = lom.Compressed()
+		objProps.Dirty = lom.Dirty()
+		if mode, until := lom.Retention(); mode != "" {
+			objProps.RetentionMode, objProps.RetainUntil = mode, until.UnixNano()
+		}
+		objProps.LegalHold = lom.LegalHold()
 		if lom.Bck().Props.EC.Enabled {
 			if md, err := ec.ObjectMetadata(lom.Bck(), objName); err == nil {
 				hdr.Set(cmn.HeaderObjECMeta, ec.MetaToString(md))
@@ -1329,7 +1460,9 @@ func (t *targetrunner) doAppend(r *http.Request, lom *cluster.LOM, started time.
 // Cloud bucket:
 //  - returned version ID is the version
 // In both cases, new checksum is also generated and stored along with the new version.
-func (t *targetrunner) doPut(r *http.Request, lom *cluster.LOM, started time.Time) (errCode int, err error) {
+// Non-nil `encryption` overrides the bucket's encryption properties (S3 API).
+func (t *targetrunner) doPut(r *http.Request, lom *cluster.LOM, started time.Time,
+	encryption *sse.Params) (errCode int, err error) {
 	var (
 		header     = r.Header
 		cksumType  = header.Get(cmn.HeaderObjCksumType)
@@ -1345,6 +1478,7 @@ func (t *targetrunner) doPut(r *http.Request, lom *cluster.LOM, started time.Tim
 		cksumToUse: cmn.NewCksum(cksumType, cksumValue),
 		ctx:        context.Background(),
 		workFQN:    fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut),
+		encryption: encryption,
 	}
 	if recvType != "" {
 		n, err := strconv.Atoi(recvType)
@@ -1392,6 +1526,73 @@ func (t *targetrunner) putMirror(lom *cluster.LOM) {
 	}
 }
 
+// queue a given dirty object for write-back (see cmn.WriteBackConf)
+func (t *targetrunner) writeBack(lom *cluster.LOM) {
+	const retries = 2
+	var err error
+	for i := 0; i < retries; i++ {
+		var xwb cluster.Xact
+		if xwb, err = xreg.RenewBucketXact(cmn.ActWriteBack, lom.Bck(), xreg.XactArgs{T: t}); err != nil {
+			break
+		}
+		if err = xwb.(*writeback.Xaction).Enqueue(lom); !xaction.IsErrXactExpired(err) {
+			break
+		}
+		// retry upon race vs (just finished/timed_out)
+	}
+	if err != nil {
+		writeback.Release(lom.Size())
+		glog.Errorf("%s: failed to initiate write-back (the object remains dirty), err: %v", lom, err)
+	}
+}
+
+// queue a given PUT or DELETE for replication (see cmn.RemoteReplConf)
+// replicateObj queues replication of the object that has been migrated to
+// this target while still pending replication at its previous location.
+func (t *targetrunner) replicateObj(w http.ResponseWriter, r *http.Request) {
+	apiItems, err := t.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	bck, err := newBckFromQuery(apiItems[0], r.URL.Query())
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	lom := &cluster.LOM{T: t, ObjName: apiItems[1]}
+	if err = lom.Init(bck.Bck); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if !lom.Bprops().Replication.Enabled {
+		t.invalmsghdlrf(w, r, "%s: replication is disabled", lom.Bck())
+		return
+	}
+	if !lom.RestoreObjectFromAny() {
+		t.invalmsghdlrsilent(w, r, fmt.Sprintf("%s %s", lom, cmn.DoesNotExist), http.StatusNotFound)
+		return
+	}
+	t.replicate(lom, replication.OpPut)
+}
+
+func (t *targetrunner) replicate(lom *cluster.LOM, op string) {
+	const retries = 2
+	err := replication.Record(t.dbDriver, lom.Bck().Bck, lom.ObjName, op)
+	for i := 0; i < retries && err == nil; i++ {
+		var xrepl cluster.Xact
+		if xrepl, err = xreg.RenewBucketXact(cmn.ActReplicate, lom.Bck(), xreg.XactArgs{T: t, Custom: t.statsT}); err != nil {
+			break
+		}
+		if err = xrepl.(*replication.Xaction).Notify(); !xaction.IsErrXactExpired(err) {
+			break
+		}
+		// retry upon race vs (just finished/timed_out)
+	}
+	if err != nil {
+		glog.Errorf("%s: failed to initiate replication (%s), err: %v", lom, op, err)
+	}
+}
+
 func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict bool) (int, error) {
 	var (
 		cloudErr     error
@@ -1405,6 +1606,17 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 	delFromCloud := lom.Bck().IsRemote() && !evict
 	if err := lom.Load(false); err == nil {
 		delFromAIS = true
+		// evicting dirty object would lose the data (see cmn.WriteBackConf)
+		if evict && lom.Dirty() {
+			return http.StatusConflict, fmt.Errorf("%s: cannot evict, not yet written back", lom)
+		}
+		// NOTE: when previous versions are kept, the object gets replaced with a delete marker
+		if !lom.VersionConf().KeepPrevious {
+			bypassGovernance, _ := ctx.Value(cmn.CtxBypassGovernance).(bool)
+			if err := lom.CheckRetention(bypassGovernance); err != nil {
+				return http.StatusForbidden, err
+			}
+		}
 	} else if !cmn.IsObjNotExist(err) {
 		return 0, err
 	} else if !delFromCloud && cmn.IsObjNotExist(err) {
@@ -1412,14 +1624,23 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 	}
 
 	if delFromCloud {
-		if errCode, err := t.Cloud(lom.Bck()).DeleteObj(ctx, lom); err != nil {
+		// dirty object may not exist in the cloud yet
+		if errCode, err := t.Cloud(lom.Bck()).DeleteObj(ctx, lom); err != nil &&
+			!(delFromAIS && lom.Dirty() && errCode == http.StatusNotFound) {
 			cloudErr = err
 			cloudErrCode = errCode
 			t.statsT.Add(stats.DeleteCount, 1)
 		}
 	}
 	if delFromAIS {
-		errRet = lom.Remove()
+		if lom.Bck().IsAIS() && lom.VersionConf().KeepPrevious {
+			// keep the object as a previous version
+			if errRet = lom.ArchiveVersion(); errRet == nil {
+				errRet = lom.AddDelMarker()
+			}
+		} else {
+			errRet = lom.Remove()
+		}
 		if errRet != nil {
 			if !os.IsNotExist(errRet) {
 				if cloudErr != nil {
@@ -1439,6 +1660,9 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 	if cloudErr != nil {
 		return cloudErrCode, cloudErr
 	}
+	if errRet == nil && !evict && lom.Bprops().Replication.Enabled {
+		t.replicate(lom, replication.OpDelete)
+	}
 	return 0, errRet
 }
 
@@ -1471,6 +1695,17 @@ func (t *targetrunner) renameObject(w http.ResponseWriter, r *http.Request, msg
 		t.invalmsghdlrf(w, r, "%s: cannot rename erasure-coded object %s", t.si, lom)
 		return
 	}
+	if lom.Bprops().ObjectLock.Enabled {
+		lom.Lock(false)
+		if err = lom.Load(false); err == nil {
+			err = lom.CheckRetention(cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamBypassGovernance)))
+		}
+		lom.Unlock(false)
+		if cmn.IsErrObjLocked(err) {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
 	buf, slab := t.gmm.Alloc()
 	coi := &copyObjInfo{
 		CopyObjectParams: cluster.CopyObjectParams{
@@ -1609,3 +1844,33 @@ func (t *targetrunner) runResilver(id string, skipGlobMisplaced bool, notifs ...
 	}
 	t.rebManager.RunResilver(id, skipGlobMisplaced, notifs...)
 }
+
+// periodically applies bucket lifecycle rules (if any)
+func (t *targetrunner) lifecycleHK() time.Duration {
+	if len(lifecycle.Buckets(&t.owner.bmd.get().BMD)) > 0 {
+		go t.runLifecycle("")
+	}
+	return lifecycle.Interval
+}
+
+func (t *targetrunner) runLifecycle(id string) {
+	regToIC := id == ""
+	if regToIC {
+		id = cmn.GenUUID()
+	}
+	xlc := xreg.RenewLifecycle(id)
+	if xlc == nil {
+		return // still running
+	}
+	if regToIC && xlc.ID().String() == id {
+		regMsg := xactRegMsg{UUID: id, Kind: cmn.ActLifecycle, Srcs: []string{t.si.ID()}}
+		msg := t.newAisMsg(&cmn.ActionMsg{Action: cmn.ActRegGlobalXaction, Value: regMsg}, nil, nil)
+		t.bcastToIC(msg, false /*wait*/)
+	}
+	xlc.AddNotif(&xaction.NotifXact{
+		NotifBase: nl.NotifBase{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
+		Xact:      xlc,
+	})
+	lifecycle.Run(&lifecycle.InitLifecycle{T: t, Xaction: xlc.(*lifecycle.Xaction)}) // blocking
+	xlc.Finish()
+}
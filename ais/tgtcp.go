@@ -1156,6 +1156,25 @@ func (t *targetrunner) LookupRemoteSingle(lom *cluster.LOM, tsi *cluster.Snode)
 	return
 }
 
+// HandoverRepl asks the target that now owns the object (e.g., upon rebalance)
+// to replicate it - see also `replicateObj`.
+func (t *targetrunner) HandoverRepl(lom *cluster.LOM, tsi *cluster.Snode) (errCode int, err error) {
+	query := cmn.AddBckToQuery(nil, lom.Bck().Bck)
+	args := callArgs{
+		si: tsi,
+		req: cmn.ReqArgs{
+			Method: http.MethodPost,
+			Base:   tsi.URL(cmn.NetworkIntraControl),
+			Path:   cmn.JoinWords(cmn.Version, cmn.Objects, lom.BckName(), lom.ObjName),
+			Query:  query,
+			Body:   cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActReplicate}),
+		},
+		timeout: lom.Config().Timeout.CplaneOperation,
+	}
+	res := t.call(args)
+	return res.status, res.err
+}
+
 // lookupRemoteAll sends the broadcast message to all targets to see if they
 // have the specific object.
 func (t *targetrunner) lookupRemoteAll(lom *cluster.LOM, smap *smapX) *cluster.Snode {
@@ -22,12 +22,14 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/compress"
 	"github.com/NVIDIA/aistore/dbdriver"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/lru"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/sse"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/xaction"
@@ -201,6 +203,10 @@ func _sendObjDM(lom *cluster.LOM, params cluster.SendToParams) error {
 	wg.Add(1)
 
 	o.Hdr.FromHdrProvider(params.HdrMeta, params.ObjNameTo, params.BckTo.Bck, nil)
+	if meta, ok := params.HdrMeta.(*cluster.LOM); ok {
+		o.Hdr.ObjAttrs.SSE = meta.SSE()
+		o.Hdr.ObjAttrs.Compress = meta.Compression()
+	}
 	o.Callback = cb
 	o.CmplPtr = unsafe.Pointer(lom)
 	if err := params.DM.Send(o, params.Reader, params.Tsi); err != nil {
@@ -227,6 +233,8 @@ func (t *targetrunner) _recvObjDM(w http.ResponseWriter, hdr transport.ObjHdr, o
 	}
 	lom.SetAtimeUnix(hdr.ObjAttrs.Atime)
 	lom.SetVersion(hdr.ObjAttrs.Version)
+	lom.SetSSE(hdr.ObjAttrs.SSE)
+	lom.SetCompression(hdr.ObjAttrs.Compress)
 
 	params := cluster.PutObjectParams{
 		Tag:          fs.WorkfilePut,
@@ -250,6 +258,14 @@ func (t *targetrunner) _sendPUT(params cluster.SendToParams) error {
 	)
 
 	hdr.Set(cmn.HeaderPutterID, t.si.ID())
+	if meta, ok := params.HdrMeta.(*cluster.LOM); ok {
+		if meta.Encrypted() {
+			hdr.Set(cmn.HeaderObjSSE, meta.SSE())
+		}
+		if meta.Compressed() {
+			hdr.Set(cmn.HeaderObjCompress, meta.Compression())
+		}
+	}
 	query.Add(cmn.URLParamRecvType, strconv.Itoa(int(cluster.Migrated)))
 	reqArgs := cmn.ReqArgs{
 		Method: http.MethodPut,
@@ -381,6 +397,21 @@ func (t *targetrunner) PromoteFile(params cluster.PromoteFileParams) (nlom *clus
 			glog.Infof("promote/PUT %s => %s @ %s", params.SrcFQN, lom, si.ID())
 		}
 		lom.FQN = params.SrcFQN
+		if bprops := lom.Bprops(); bprops.Encryption.Enabled || bprops.Compression.Enabled {
+			// send encoded (see also "local" below)
+			var (
+				cksum   *cmn.CksumHash
+				written int64
+				workFQN = fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut)
+			)
+			if written, cksum, err = t.encodeFile(lom, params.SrcFQN, workFQN, params.Cksum); err != nil {
+				return
+			}
+			defer os.Remove(workFQN)
+			lom.FQN = workFQN
+			lom.SetSize(written)
+			lom.SetCksum(cksum.Clone())
+		}
 		var (
 			coi        = &copyObjInfo{t: t}
 			sendParams = cluster.SendToParams{ObjNameTo: lom.ObjName, Tsi: si}
@@ -416,7 +447,19 @@ func (t *targetrunner) PromoteFile(params cluster.PromoteFileParams) (nlom *clus
 		poi     = &putObjInfo{t: t, lom: lom}
 		conf    = lom.CksumConf()
 	)
-	if params.KeepOrig {
+	lom.SetSSE("")
+	lom.SetCompression("")
+	if bprops := lom.Bprops(); bprops.Encryption.Enabled || bprops.Compression.Enabled {
+		workFQN = fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut)
+		if written, cksum, err = t.encodeFile(lom, params.SrcFQN, workFQN, params.Cksum); err != nil {
+			return
+		}
+		lom.SetCksum(cksum.Clone())
+		if !params.KeepOrig {
+			defer os.Remove(params.SrcFQN)
+		}
+		cksum = nil // validated (above) against the checksum of the original content
+	} else if params.KeepOrig {
 		workFQN = fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut)
 
 		buf, slab := t.gmm.Alloc()
@@ -462,6 +505,89 @@ func (t *targetrunner) PromoteFile(params cluster.PromoteFileParams) (nlom *clus
 	return
 }
 
+// encodeFile compresses and/or encrypts the file to be promoted as per bucket
+// properties, checksums the encoded content, and validates the original one
+// (if requested).
+func (t *targetrunner) encodeFile(lom *cluster.LOM, srcFQN, workFQN string,
+	expct *cmn.Cksum) (written int64, cksum *cmn.CksumHash, err error) {
+	var (
+		src, dst       *os.File
+		encW           *sse.Writer
+		cmprW          *compress.Writer
+		given, content *cmn.CksumHash
+		reader         io.Reader
+		writer         io.Writer
+	)
+	if src, err = os.Open(srcFQN); err != nil {
+		return
+	}
+	defer cmn.Close(src)
+	if dst, err = lom.CreateFile(workFQN); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			cmn.Close(dst)
+			if nestedErr := cmn.RemoveFile(workFQN); nestedErr != nil {
+				glog.Errorf("Nested (%v): failed to remove %s, err: %v", err, workFQN, nestedErr)
+			}
+		}
+	}()
+	cksum = cmn.NewCksumHash(lom.CksumConf().Type)
+	writer = cmn.NewWriterMulti(dst, cksum.H)
+	if encW, err = lom.EncryptWriter(writer, nil); err != nil {
+		return
+	}
+	if encW != nil {
+		writer = encW
+	}
+	if cmprW, err = lom.CompressWriter(writer); err != nil {
+		return
+	}
+	reader = src
+	if cmprW != nil {
+		writer = cmprW
+		content = cmn.NewCksumHash(lom.CksumConf().Type)
+		reader = io.TeeReader(reader, content.H)
+	}
+	if expct != nil {
+		given = cmn.NewCksumHash(expct.Type())
+		reader = io.TeeReader(reader, given.H)
+	}
+	buf, slab := t.gmm.Alloc()
+	written, err = io.CopyBuffer(writer, reader, buf)
+	slab.Free(buf)
+	if err != nil {
+		return
+	}
+	if cmprW != nil {
+		if err = cmprW.Close(); err != nil {
+			return
+		}
+		content.Finalize()
+		lom.SetCompressed(cmprW, &content.Cksum)
+		written = cmprW.Size()
+	}
+	if encW != nil {
+		if err = encW.Close(); err != nil {
+			return
+		}
+		written = encW.Size()
+	}
+	if given != nil {
+		given.Finalize()
+		if !given.Equal(expct) {
+			err = cmn.NewBadDataCksumError(&given.Cksum, expct, srcFQN+" => "+lom.String())
+			return
+		}
+	}
+	if err = dst.Close(); err != nil {
+		return
+	}
+	cksum.Finalize()
+	return
+}
+
 //
 // implements health.fspathDispatcher interface
 //
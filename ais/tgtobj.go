@@ -22,10 +22,14 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/compress"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/replication"
+	"github.com/NVIDIA/aistore/sse"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/writeback"
 	"github.com/NVIDIA/aistore/xaction/xreg"
 )
 
@@ -58,6 +62,8 @@ type (
 		cold bool
 		// if true, poi won't erasure-encode an object when finalizing
 		skipEC bool
+		// Encryption requested by the client (S3 API) - overrides bucket's encryption
+		encryption *sse.Params
 	}
 
 	getObjInfo struct {
@@ -75,6 +81,10 @@ type (
 		isGFN bool
 		// true: chunked transfer (en)coding as per https://tools.ietf.org/html/rfc7230#page-36
 		chunked bool
+		// Specific version of the object to get (ais buckets only)
+		version string
+		// Client-provided key of the object encrypted with it (SSE-C)
+		customerKey []byte
 	}
 
 	// Contains information packed in append handle.
@@ -161,6 +171,12 @@ func (poi *putObjInfo) finalize() (errCode int, err error) {
 		poi.lom.Uncache()
 		return
 	}
+	if poi.lom.Dirty() && !poi.migrated {
+		poi.t.writeBack(poi.lom)
+	}
+	if !poi.migrated && !poi.cold && poi.lom.Bprops().Replication.Enabled {
+		poi.t.replicate(poi.lom, replication.OpPut)
+	}
 	if !poi.skipEC {
 		if ecErr := ec.ECM.EncodeObject(poi.lom); ecErr != nil && ecErr != ec.ErrorECDisabled {
 			err = ecErr
@@ -172,14 +188,37 @@ func (poi *putObjInfo) finalize() (errCode int, err error) {
 	return
 }
 
+// Returns true if the object is to be written back asynchronously, in which
+// case its size gets reserved (see writeback.Reserve). Objects encrypted with
+// client-provided key are always uploaded synchronously as the key is not stored.
+func (poi *putObjInfo) writeBack() bool {
+	var (
+		lom  = poi.lom
+		conf = &lom.Bprops().WriteBack
+	)
+	if !lom.Bck().IsRemote() || poi.migrated || poi.cold || !conf.Enabled || poi.customerKey() != nil {
+		return false
+	}
+	return writeback.Reserve(lom.Size(), conf.MaxDirty)
+}
+
 // poi.workFQN => LOM
 func (poi *putObjInfo) tryFinalize() (errCode int, err error) {
 	var (
 		lom = poi.lom
 		bck = lom.Bck()
 	)
-	if bck.IsRemote() && !poi.migrated {
+	if poi.writeBack() {
+		// respond right away and upload in the background (see package writeback)
+		lom.SetDirty(true)
+		defer func() {
+			if err != nil {
+				writeback.Release(lom.Size())
+			}
+		}()
+	} else if bck.IsRemote() && !poi.migrated {
 		var version string
+		lom.SetDirty(false)
 		if bck.IsCloud() || bck.IsHTTP() {
 			version, errCode, err = poi.putCloud()
 		} else {
@@ -208,7 +247,18 @@ func (poi *putObjInfo) tryFinalize() (errCode int, err error) {
 	lom.Lock(true)
 	defer lom.Unlock(true)
 
+	if bck.IsAIS() && lom.Bprops().ObjectLock.Enabled && !poi.migrated {
+		if err = lom.CheckOverwrite(); err != nil {
+			return http.StatusForbidden, err
+		}
+		lom.SetDefaultRetention()
+	}
 	if bck.IsAIS() && lom.VersionConf().Enabled && !poi.migrated {
+		if lom.VersionConf().KeepPrevious {
+			if err = lom.ArchiveVersion(); err != nil {
+				return
+			}
+		}
 		if err = lom.IncVersion(); err != nil {
 			return
 		}
@@ -233,7 +283,7 @@ func (poi *putObjInfo) putCloud() (version string, errCode int, err error) {
 		lom = poi.lom
 		bck = lom.Bck()
 	)
-	file, errOpen := os.Open(poi.workFQN)
+	file, errOpen := lom.OpenFQN(poi.workFQN, poi.customerKey())
 	if errOpen != nil {
 		err = fmt.Errorf("failed to open %s err: %w", poi.workFQN, errOpen)
 		return
@@ -259,7 +309,7 @@ func (poi *putObjInfo) putRemoteAIS() (version string, errCode int, err error) {
 		bck = lom.Bck()
 	)
 	cmn.Assert(bck.IsRemoteAIS())
-	fh, errOpen := cmn.NewFileHandle(poi.workFQN) // Closed by `PutObj`.
+	fh, errOpen := lom.OpenFQN(poi.workFQN, poi.customerKey()) // Closed by `PutObj`.
 	if errOpen != nil {
 		err = fmt.Errorf("failed to open %s err: %w", poi.workFQN, errOpen)
 		return
@@ -268,6 +318,13 @@ func (poi *putObjInfo) putRemoteAIS() (version string, errCode int, err error) {
 	return
 }
 
+func (poi *putObjInfo) customerKey() []byte {
+	if poi.encryption == nil {
+		return nil
+	}
+	return poi.encryption.CustomerKey
+}
+
 // NOTE: LOM is updated on the end of the call with proper size and checksum.
 // NOTE: `roi.r` is closed on the end of the call.
 func (poi *putObjInfo) writeToFile() (err error) {
@@ -278,11 +335,14 @@ func (poi *putObjInfo) writeToFile() (err error) {
 		slab    *memsys.Slab
 		reader  = poi.r
 		writer  io.Writer
+		encW    *sse.Writer
+		cmprW   *compress.Writer
 		writers = make([]io.Writer, 0, 4)
 		cksums  = struct {
-			store *cmn.CksumHash // store with LOM
-			given *cmn.CksumHash // compute additionally
-			expct *cmn.Cksum     // and validate against `expct` if required/available
+			store   *cmn.CksumHash // store with LOM
+			given   *cmn.CksumHash // compute additionally
+			expct   *cmn.Cksum     // and validate against `expct` if required/available
+			content *cmn.CksumHash // content checksum of compressed object
 		}{}
 		conf = poi.lom.CksumConf()
 	)
@@ -327,7 +387,6 @@ func (poi *putObjInfo) writeToFile() (err error) {
 
 	// compute checksum and save it as part of the object metadata
 	cksums.store = cmn.NewCksumHash(conf.Type)
-	writers = append(writers, cksums.store.H)
 	if conf.ShouldValidate() && !poi.cksumToUse.IsEmpty() {
 		// if validate-cold-get and the cksum is provided we should also check md5 hash (aws, gcp)
 		// or if the object is migrated, and `conf.ValidateObjMove` we should check with existing checksum
@@ -337,6 +396,36 @@ func (poi *putObjInfo) writeToFile() (err error) {
 	}
 
 write:
+	if !poi.migrated {
+		// compress and then encrypt new content as per bucket properties or client's
+		// request (if any); the checksum to store is then computed over the stored content
+		poi.lom.SetSSE("")
+		fileWriter := writer
+		if cksums.store != nil {
+			fileWriter = cmn.NewWriterMulti(writer, cksums.store.H)
+		}
+		if encW, err = poi.lom.EncryptWriter(fileWriter, poi.encryption); err != nil {
+			return
+		}
+		if encW != nil {
+			fileWriter = encW
+		}
+		if cmprW, err = poi.lom.CompressWriter(fileWriter); err != nil {
+			return
+		}
+	}
+	switch {
+	case cmprW != nil:
+		writer = cmprW
+		if cksums.store != nil {
+			cksums.content = cmn.NewCksumHash(conf.Type)
+			writers = append(writers, cksums.content.H)
+		}
+	case encW != nil:
+		writer = encW
+	case cksums.store != nil:
+		writers = append(writers, cksums.store.H)
+	}
 	if len(writers) == 0 {
 		written, err = io.CopyBuffer(writer, reader, buf)
 	} else {
@@ -346,6 +435,24 @@ write:
 	if err != nil {
 		return
 	}
+	if cmprW != nil {
+		if err = cmprW.Close(); err != nil {
+			return
+		}
+		var cksum *cmn.Cksum
+		if cksums.content != nil {
+			cksums.content.Finalize()
+			cksum = &cksums.content.Cksum
+		}
+		poi.lom.SetCompressed(cmprW, cksum)
+		written = cmprW.Size()
+	}
+	if encW != nil {
+		if err = encW.Close(); err != nil {
+			return
+		}
+		written = encW.Size()
+	}
 	// validate
 	if cksums.given != nil {
 		cksums.given.Finalize()
@@ -380,6 +487,9 @@ func (goi *getObjInfo) getObject() (errCode int, err error) {
 		cs                                            fs.CapStatus
 		doubleCheck, retry, retried, coldGet, capRead bool
 	)
+	if goi.version != "" {
+		return goi.getVersion()
+	}
 	// under lock: lom init, restore from cluster
 	goi.lom.Lock(false)
 do:
@@ -424,8 +534,8 @@ do:
 		goi.lom.Lock(false)
 		goto get
 	}
-	// exists && remote|cloud: check ver if requested
-	if !coldGet && goi.lom.Bck().IsRemote() {
+	// exists && remote|cloud: check ver if requested (dirty objects are yet to be written back)
+	if !coldGet && goi.lom.Bck().IsRemote() && !goi.lom.Dirty() {
 		if goi.lom.Version() != "" && goi.lom.VersionConf().ValidateWarmGet {
 			goi.lom.Unlock(false)
 			if coldGet, errCode, err = goi.t.CheckCloudVersion(goi.ctx, goi.lom); err != nil {
@@ -481,6 +591,34 @@ get:
 	return
 }
 
+// GET a given version of the object: current or previous one
+func (goi *getObjInfo) getVersion() (errCode int, err error) {
+	lom := goi.lom
+	if !lom.Bck().IsAIS() {
+		return http.StatusBadRequest, fmt.Errorf("%s: GET by version is supported only for ais buckets", lom)
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err = lom.Load(); err == nil && lom.Version() == goi.version {
+		_, errCode, err = goi.finalize(false /*coldGet*/)
+		return
+	}
+	vlom, err := lom.LoadVersion(goi.version)
+	if err != nil {
+		if cmn.IsObjNotExist(err) {
+			return http.StatusNotFound, fmt.Errorf("%s version %q %s", lom, goi.version, cmn.DoesNotExist)
+		}
+		return http.StatusInternalServerError, err
+	}
+	if vlom.IsDelMarker() {
+		return http.StatusNotFound, fmt.Errorf("%s version %q is a delete marker", lom, goi.version)
+	}
+	// NOTE: previous versions are neither load-balanced nor cached (hence, "cold")
+	goi.lom = vlom
+	_, errCode, err = goi.finalize(true /*coldGet*/)
+	return
+}
+
 // validate checksum; if corrupted try to recover from other replicas or EC slices
 func (goi *getObjInfo) tryRecoverObject() (coldGet bool, code int, err error) {
 	var (
@@ -745,9 +883,25 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 	}
 
 	var (
-		r    *cmn.HTTPRange
-		size = goi.lom.Size()
+		r       *cmn.HTTPRange
+		cr      cluster.ContentReader
+		src     io.ReaderAt = file
+		objSize             = goi.lom.Size()
+		cksum               = goi.lom.Cksum()
+		// decode encrypted and compressed objects unless sending them to another target (GFN)
+		decode = goi.lom.Encoded() && !goi.isGFN
 	)
+	if decode {
+		if cr, err = goi.lom.ContentReader(file, goi.customerKey); err != nil {
+			errCode = http.StatusInternalServerError
+			if errors.Is(err, sse.ErrCustomerKeyRequired) || errors.Is(err, sse.ErrCustomerKeyMismatch) {
+				errCode = http.StatusBadRequest
+			}
+			return
+		}
+		src, objSize, cksum = cr, cr.Size(), goi.lom.ContentCksum()
+	}
+	size := objSize
 	if goi.ranges.Size > 0 {
 		size = goi.ranges.Size
 	}
@@ -778,15 +932,21 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 	cksumRange := cksumConf.Type != cmn.ChecksumNone && r != nil && cksumConf.EnableReadRange
 
 	if hdr != nil {
-		if !goi.lom.Cksum().IsEmpty() && !cksumRange {
-			cksumType, cksumValue := goi.lom.Cksum().Get()
+		if !cksum.IsEmpty() && !cksumRange {
+			cksumType, cksumValue := cksum.Get()
 			hdr.Set(cmn.HeaderObjCksumType, cksumType)
 			hdr.Set(cmn.HeaderObjCksumVal, cksumValue)
 		}
 		if goi.lom.Version() != "" {
 			hdr.Set(cmn.HeaderObjVersion, goi.lom.Version())
 		}
-		hdr.Set(cmn.HeaderObjSize, strconv.FormatInt(goi.lom.Size(), 10))
+		if goi.isGFN && goi.lom.Encrypted() {
+			hdr.Set(cmn.HeaderObjSSE, goi.lom.SSE())
+		}
+		if goi.isGFN && goi.lom.Compressed() {
+			hdr.Set(cmn.HeaderObjCompress, goi.lom.Compression())
+		}
+		hdr.Set(cmn.HeaderObjSize, strconv.FormatInt(objSize, 10))
 		hdr.Set(cmn.HeaderObjAtime, cmn.UnixNano2S(goi.lom.AtimeUnix()))
 		if r != nil {
 			hdr.Set(cmn.HeaderContentLength, strconv.FormatInt(r.Length, 10))
@@ -798,6 +958,9 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 	w := goi.w
 	if r == nil {
 		reader = file
+		if decode {
+			reader = cr
+		}
 		if goi.chunked {
 			// Explicitly hiding `ReadFrom` implemented for `http.ResponseWriter`
 			// so the `sendfile` syscall won't be used.
@@ -806,7 +969,7 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 		}
 	} else {
 		buf, slab = goi.t.gmm.Alloc(r.Length)
-		reader = io.NewSectionReader(file, r.Start, r.Length)
+		reader = io.NewSectionReader(src, r.Start, r.Length)
 		if cksumRange {
 			var cksum *cmn.CksumHash
 			sgl = slab.MMSA().NewSGL(r.Length, slab.Size())
@@ -815,10 +978,13 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 			}
 			hdr.Set(cmn.HeaderObjCksumVal, cksum.Value())
 			hdr.Set(cmn.HeaderObjCksumType, cksumConf.Type)
-			reader = io.NewSectionReader(file, r.Start, r.Length)
+			reader = io.NewSectionReader(src, r.Start, r.Length)
 		}
 	}
 
+	if rw, ok := goi.w.(http.ResponseWriter); ok && r != nil {
+		rw.WriteHeader(http.StatusPartialContent)
+	}
 	written, err = io.CopyBuffer(w, reader, buf)
 	if err != nil {
 		if cmn.IsErrConnectionReset(err) {
@@ -985,6 +1151,12 @@ func combineAppendHandle(nodeID, filePath string, partialCksum *cmn.CksumHash) s
 func (coi *copyObjInfo) copyObject(srcLOM *cluster.LOM, objNameTo string) (copied bool, err error) {
 	cmn.Assert(coi.DP == nil)
 
+	if coi.BckTo.IsRemoteAIS() && !coi.DryRun {
+		// Stream the object directly to the remote AIS cluster, without caching it locally.
+		coi.DP = &cluster.LomReader{}
+		copied, _, err = coi.copyReaderDirectlyToCloud(srcLOM, objNameTo)
+		return copied, err
+	}
 	if srcLOM.Bck().IsRemote() || coi.BckTo.IsRemote() {
 		// There will be no logic to create local copies etc, we can simply use copyReader
 		coi.DP = &cluster.LomReader{}
@@ -1064,6 +1236,9 @@ func (coi *copyObjInfo) copyObject(srcLOM *cluster.LOM, objNameTo string) (copie
 		if srcLOM.Cksum().Equal(dst.Cksum()) {
 			return
 		}
+		if err = dst.CheckRetention(false /*bypass governance*/); err != nil {
+			return
+		}
 	} else if cmn.IsErrBucketNought(err) {
 		return
 	}
@@ -1139,9 +1314,8 @@ func (coi *copyObjInfo) copyReader(lom *cluster.LOM, objNameTo string) (copied b
 	return true, dst.Size(), err
 }
 
-// nolint:unused // This function might become useful if we decide to introduce copying an object directly to a cloud.
-//
-// Provider, without intermediate object caching on a target.
+// copyReaderDirectlyToCloud puts a new object directly to the cloud provider (currently, used only
+// for remote AIS clusters), without intermediate object caching on a target.
 func (coi *copyObjInfo) copyReaderDirectlyToCloud(lom *cluster.LOM, objNameTo string) (copied bool, size int64, err error) {
 	cmn.Assert(coi.BckTo.IsRemote())
 	var (
@@ -1164,6 +1338,8 @@ func (coi *copyObjInfo) copyReaderDirectlyToCloud(lom *cluster.LOM, objNameTo st
 	if err := dstLOM.Init(coi.BckTo.Bck); err != nil {
 		return false, 0, err
 	}
+	dstLOM.SetSize(objMeta.Size())
+	dstLOM.SetCksum(objMeta.Cksum())
 
 	if _, _, err = coi.t.Cloud(coi.BckTo).PutObj(context.Background(), reader, dstLOM); err != nil {
 		return false, 0, err
@@ -27,14 +27,75 @@ func (t *targetrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.Method == http.MethodPut || r.Method == http.MethodPost {
+		if err := s3compat.VerifyPayload(r); err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+	}
+	q := r.URL.Query()
+	_, mptUpload := q[s3compat.URLParamMultipartUploadID]
+	_, tagging := q[s3compat.URLParamTagging]
+	_, retention := q[s3compat.URLParamRetention]
+	_, legalHold := q[s3compat.URLParamLegalHold]
 	switch r.Method {
 	case http.MethodHead:
 		t.headObjS3(w, r, apiItems)
 	case http.MethodGet:
+		if mptUpload {
+			t.listMptPartsS3(w, r, apiItems)
+			return
+		}
+		if tagging {
+			t.getObjTaggingS3(w, r, apiItems)
+			return
+		}
+		if retention || legalHold {
+			t.getObjLockS3(w, r, apiItems, legalHold)
+			return
+		}
 		t.getObjS3(w, r, apiItems)
 	case http.MethodPut:
+		if mptUpload {
+			t.putObjPartS3(w, r, apiItems)
+			return
+		}
+		if tagging {
+			t.putObjTaggingS3(w, r, apiItems)
+			return
+		}
+		if retention {
+			t.putObjRetentionS3(w, r, apiItems)
+			return
+		}
+		if legalHold {
+			t.putObjLegalHoldS3(w, r, apiItems)
+			return
+		}
 		t.putObjS3(w, r, apiItems)
+	case http.MethodPost:
+		if _, ok := q[s3compat.URLParamMultipartUploads]; ok {
+			t.startMptUploadS3(w, r, apiItems)
+			return
+		}
+		if mptUpload {
+			t.completeMptUploadS3(w, r, apiItems)
+			return
+		}
+		t.invalmsghdlrf(w, r, "Invalid POST request: %s", r.URL)
 	case http.MethodDelete:
+		if mptUpload {
+			t.abortMptUploadS3(w, r, apiItems)
+			return
+		}
+		if tagging {
+			t.delObjTaggingS3(w, r, apiItems)
+			return
+		}
+		if retention || legalHold {
+			t.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL)
+			return
+		}
 		t.delObjS3(w, r, apiItems)
 	default:
 		t.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
@@ -90,7 +151,7 @@ func (t *targetrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items [
 	}
 
 	var cksumValue string
-	if cksum := lom.Cksum(); cksum != nil && cksum.Type() == cmn.ChecksumMD5 {
+	if cksum := lom.ContentCksum(); cksum != nil && cksum.Type() == cmn.ChecksumMD5 {
 		cksumValue = cksum.Value()
 	}
 	result := s3compat.CopyObjectResult{
@@ -135,12 +196,32 @@ func (t *targetrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, it
 	lom.SetAtimeUnix(started.UnixNano())
 
 	// TODO: lom.SetCustomMD(cluster.AmazonMD5ObjMD, checksum)
+	// NOTE: PUT replaces user-defined metadata and tags of an existing object
+	md, err := s3compat.CustomMDFromHeader(r.Header)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom.SetCustomMD(md)
+	if err := s3compat.ObjLockFromHeader(r.Header, lom); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	encryption, err := s3compat.SSEFromHeader(r.Header)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
 
-	if errCode, err := t.doPut(r, lom, started); err != nil {
+	if errCode, err := t.doPut(r, lom, started, encryption); err != nil {
+		if s3compat.IsErrContentSHA256Mismatch(err) {
+			errCode = http.StatusBadRequest
+		}
 		t.fsErr(err, lom.FQN)
 		t.invalmsghdlr(w, r, err.Error(), errCode)
 		return
 	}
+	s3compat.SetSSEHeader(w.Header(), lom)
 }
 
 // PUT s3/bckName/objName
@@ -192,15 +273,26 @@ func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []
 		t.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if errCode := s3compat.CheckPreconditions(r.Header, lom); errCode != 0 {
+		t.preconditionFailedS3(w, r, lom, errCode)
+		return
+	}
 
-	objSize = lom.Size()
+	customerKey, err := s3compat.CustomerKeyFromHeader(r.Header)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+
+	objSize = lom.ContentSize()
 	goi := &getObjInfo{
-		started: started,
-		t:       t,
-		lom:     lom,
-		w:       w,
-		ctx:     context.Background(),
-		ranges:  cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange), Size: objSize},
+		started:     started,
+		t:           t,
+		lom:         lom,
+		w:           w,
+		ctx:         context.Background(),
+		ranges:      cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange), Size: objSize},
+		customerKey: customerKey,
 	}
 	s3compat.SetHeaderFromLOM(w.Header(), lom, objSize)
 	if errCode, err := goi.getObject(); err != nil {
@@ -258,7 +350,26 @@ func (t *targetrunner) headObjS3(w http.ResponseWriter, r *http.Request, items [
 		s3compat.SetETLHeader(w.Header(), lom)
 		return
 	}
-	s3compat.SetHeaderFromLOM(w.Header(), lom, lom.Size())
+	if errCode := s3compat.CheckPreconditions(r.Header, lom); errCode != 0 {
+		t.preconditionFailedS3(w, r, lom, errCode)
+		return
+	}
+	if err := s3compat.CheckCustomerKey(r.Header, lom); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	s3compat.SetHeaderFromLOM(w.Header(), lom, lom.ContentSize())
+}
+
+// Responds to a conditional GET or HEAD request which preconditions are not met:
+// either with 304 (Not Modified) or with 412 (Precondition Failed)
+func (t *targetrunner) preconditionFailedS3(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, errCode int) {
+	if errCode == http.StatusNotModified {
+		s3compat.SetNotModifiedHeader(w.Header(), lom)
+		w.WriteHeader(errCode)
+		return
+	}
+	t.invalmsghdlrsilent(w, r, fmt.Sprintf("%s: precondition failed", lom), errCode)
 }
 
 // DEL s3/bckName/objName
@@ -281,7 +392,9 @@ func (t *targetrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []
 		t.invalmsghdlr(w, r, err.Error())
 		return
 	}
-	errCode, err := t.DeleteObject(context.Background(), lom, false)
+	bypassGovernance := cmn.IsParseBool(r.Header.Get(s3compat.HeaderBypassGovernance))
+	ctx := context.WithValue(context.Background(), cmn.CtxBypassGovernance, bypassGovernance)
+	errCode, err := t.DeleteObject(ctx, lom, false)
 	if err != nil {
 		if errCode == http.StatusNotFound {
 			t.invalmsghdlrsilent(w, r,
@@ -296,3 +409,165 @@ func (t *targetrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []
 	// EC cleanup if EC is enabled
 	ec.ECM.CleanupObject(lom)
 }
+
+// GET s3/bckName/objName?tagging
+func (t *targetrunner) getObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom, err := t.initLomS3(r, items)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom.Lock(false)
+	err = lom.Load(true)
+	lom.Unlock(false)
+	if err != nil {
+		errCode := http.StatusBadRequest
+		if cmn.IsObjNotExist(err) {
+			errCode = http.StatusNotFound
+		}
+		t.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	tagging := s3compat.TaggingFromLOM(lom)
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(tagging.MustMarshal())
+}
+
+// PUT s3/bckName/objName?tagging
+func (t *targetrunner) putObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	tagging, err := s3compat.ParseTagging(r.Body)
+	cmn.Close(r.Body)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	t.updObjTaggingS3(w, r, items, tagging)
+}
+
+// DEL s3/bckName/objName?tagging
+func (t *targetrunner) delObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	if t.updObjTaggingS3(w, r, items, nil) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (t *targetrunner) updObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string,
+	tagging *s3compat.Tagging) (ok bool) {
+	lom, err := t.initLomS3(r, items)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err = lom.Load(false); err != nil {
+		errCode := http.StatusBadRequest
+		if cmn.IsObjNotExist(err) {
+			errCode = http.StatusNotFound
+		}
+		t.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	s3compat.SetLOMTagging(lom, tagging)
+	if err = lom.PersistMeta(); err != nil {
+		t.fsErr(err, lom.FQN)
+		t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	lom.ReCache()
+	return true
+}
+
+// GET s3/bckName/objName?retention
+// GET s3/bckName/objName?legal-hold
+func (t *targetrunner) getObjLockS3(w http.ResponseWriter, r *http.Request, items []string, legalHold bool) {
+	lom, err := t.initLomS3(r, items)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom.Lock(false)
+	err = lom.Load(true)
+	lom.Unlock(false)
+	if err != nil {
+		errCode := http.StatusBadRequest
+		if cmn.IsObjNotExist(err) {
+			errCode = http.StatusNotFound
+		}
+		t.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	var body []byte
+	if legalHold {
+		body = s3compat.NewLegalHold(lom).MustMarshal()
+	} else {
+		body = s3compat.NewRetention(lom).MustMarshal()
+	}
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(body)
+}
+
+// PUT s3/bckName/objName?retention
+func (t *targetrunner) putObjRetentionS3(w http.ResponseWriter, r *http.Request, items []string) {
+	mode, until, err := s3compat.ParseRetention(r.Body)
+	cmn.Close(r.Body)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	bypassGovernance := cmn.IsParseBool(r.Header.Get(s3compat.HeaderBypassGovernance))
+	t.updObjLockS3(w, r, items, func(lom *cluster.LOM) error {
+		return lom.SetRetention(mode, until, bypassGovernance)
+	})
+}
+
+// PUT s3/bckName/objName?legal-hold
+func (t *targetrunner) putObjLegalHoldS3(w http.ResponseWriter, r *http.Request, items []string) {
+	on, err := s3compat.ParseLegalHold(r.Body)
+	cmn.Close(r.Body)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	t.updObjLockS3(w, r, items, func(lom *cluster.LOM) error {
+		lom.SetLegalHold(on)
+		return nil
+	})
+}
+
+func (t *targetrunner) updObjLockS3(w http.ResponseWriter, r *http.Request, items []string,
+	upd func(lom *cluster.LOM) error) {
+	lom, err := t.initLomS3(r, items)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if !lom.Bprops().ObjectLock.Enabled {
+		t.invalmsghdlrf(w, r, "bucket %s does not have object lock enabled", lom.Bck())
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err = lom.Load(false); err != nil {
+		errCode := http.StatusBadRequest
+		if cmn.IsObjNotExist(err) {
+			errCode = http.StatusNotFound
+		}
+		t.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	if err = upd(lom); err != nil {
+		errCode := http.StatusBadRequest
+		if cmn.IsErrObjLocked(err) {
+			errCode = http.StatusForbidden
+		}
+		t.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	if err = lom.PersistMeta(); err != nil {
+		t.fsErr(err, lom.FQN)
+		t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	lom.ReCache()
+}
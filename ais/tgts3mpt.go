@@ -0,0 +1,483 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+//
+// S3 multipart upload: parts are stored on the target the upload has started on
+// (see s3compat.InitUpload) and get concatenated into the object on completion.
+//
+
+// how often to check for expired (abandoned) multipart uploads
+const mptHKInterval = time.Hour
+
+// concatenates the parts of a multipart upload, closes the files when done
+type mptReader struct {
+	files []*os.File
+	r     io.Reader
+}
+
+func newMptReader(parts []*s3compat.MptPart) (*mptReader, error) {
+	var (
+		mr      = &mptReader{files: make([]*os.File, 0, len(parts))}
+		readers = make([]io.Reader, 0, len(parts))
+	)
+	for _, part := range parts {
+		fh, err := os.Open(part.FQN)
+		if err != nil {
+			mr.Close()
+			return nil, err
+		}
+		mr.files = append(mr.files, fh)
+		readers = append(readers, fh)
+	}
+	mr.r = io.MultiReader(readers...)
+	return mr, nil
+}
+
+func (mr *mptReader) Read(b []byte) (int, error) { return mr.r.Read(b) }
+
+// NOTE: may be called more than once
+func (mr *mptReader) Close() error {
+	for _, fh := range mr.files {
+		cmn.Close(fh)
+	}
+	mr.files = nil
+	return nil
+}
+
+func (t *targetrunner) initLomS3(r *http.Request, items []string) (lom *cluster.LOM, err error) {
+	if len(items) < 2 {
+		return nil, fmt.Errorf("object name is undefined")
+	}
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err = bck.Init(t.owner.bmd, nil); err != nil {
+		return
+	}
+	lom = &cluster.LOM{T: t, ObjName: path.Join(items[1:]...)}
+	if err = lom.Init(bck.Bck); err != nil {
+		if _, ok := err.(*cmn.ErrorRemoteBucketDoesNotExist); ok {
+			t.BMDVersionFixup(r, cmn.Bck{}, true /* sleep */)
+			err = lom.Init(bck.Bck)
+		}
+	}
+	return
+}
+
+// POST s3/bckName/objName?uploads
+// Start a new multipart upload
+func (t *targetrunner) startMptUploadS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom, err := t.initLomS3(r, items)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if uploadID := r.URL.Query().Get(s3compat.URLParamMultipartUploadID); uploadID != "" {
+		t.acceptMptUploadS3(w, r, lom, uploadID)
+		return
+	}
+	md, err := s3compat.CustomMDFromHeader(r.Header)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	encryption, err := s3compat.SSEFromHeader(r.Header)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	uploadID := s3compat.NewUploadID(t.si.ID())
+	dir := mptUploadDir(lom, uploadID)
+	if err := s3compat.InitUpload(uploadID, dir, lom.BckName(), lom.ObjName, md, encryption); err != nil {
+		t.fsErr(err, dir)
+		t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	result := s3compat.NewInitiateMptUploadResult(lom.BckName(), lom.ObjName, uploadID)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: started multipart upload %q of %s", t.si, uploadID, lom)
+	}
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(result.MustMarshal())
+}
+
+// POST s3/bckName/objName?uploads&uploadId=ID
+// Accept the upload handed over by another target (see handoverMptUpload)
+func (t *targetrunner) acceptMptUploadS3(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, uploadID string) {
+	hdv := &s3compat.MptHandover{}
+	if err := cmn.ReadJSON(w, r, hdv); err != nil {
+		return
+	}
+	if hdv.ID != uploadID || hdv.Bck != lom.BckName() || hdv.Obj != lom.ObjName {
+		t.invalmsghdlrf(w, r, "invalid handover of upload %q (%s/%s)", uploadID, hdv.Bck, hdv.Obj)
+		return
+	}
+	dir := mptUploadDir(lom, uploadID)
+	if err := s3compat.AcceptUpload(dir, hdv); err != nil {
+		t.fsErr(err, dir)
+		t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: accepted multipart upload %q of %s", t.si, uploadID, lom)
+	}
+}
+
+// PUT s3/bckName/objName?partNumber=N&uploadId=ID
+// Upload a part of the object
+func (t *targetrunner) putObjPartS3(w http.ResponseWriter, r *http.Request, items []string) {
+	if cs := fs.GetCapStatus(); cs.OOS {
+		t.invalmsghdlr(w, r, cs.Err.Error())
+		return
+	}
+	lom, err := t.initLomS3(r, items)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	var (
+		query    = r.URL.Query()
+		uploadID = query.Get(s3compat.URLParamMultipartUploadID)
+	)
+	if !s3compat.UploadExists(uploadID, lom.BckName(), lom.ObjName) {
+		t.mptNotFoundS3(w, r, lom, uploadID)
+		return
+	}
+	partNum, err := s3compat.ParsePartNum(query.Get(s3compat.URLParamMultipartPartNo))
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	workFQN, err := s3compat.PartWorkFQN(uploadID, partNum)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	size := int64(-1)
+	if r.ContentLength >= 0 {
+		size = r.ContentLength
+	}
+	buf, slab := t.gmm.Alloc()
+	cksum, err := cmn.SaveReader(workFQN, r.Body, buf, cmn.ChecksumMD5, size, filepath.Dir(workFQN))
+	slab.Free(buf)
+	if err != nil {
+		if s3compat.IsErrContentSHA256Mismatch(err) {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		t.fsErr(err, workFQN)
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if size < 0 {
+		if finfo, err := os.Stat(workFQN); err == nil {
+			size = finfo.Size()
+		}
+	}
+	part, err := s3compat.AddPart(uploadID, workFQN, partNum, cksum.Value(), size)
+	if err != nil {
+		if errRm := cmn.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf("Nested error: %v => (remove %s => err: %v)", err, workFQN, errRm)
+		}
+		if s3compat.IsErrUploadNotFound(err) {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		} else {
+			t.fsErr(err, workFQN)
+			t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set(cmn.HeaderETag, part.MD5)
+}
+
+// POST s3/bckName/objName?uploadId=ID
+// Complete multipart upload: concatenate the parts and create the object
+func (t *targetrunner) completeMptUploadS3(w http.ResponseWriter, r *http.Request, items []string) {
+	started := time.Now()
+	lom, err := t.initLomS3(r, items)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	uploadID := r.URL.Query().Get(s3compat.URLParamMultipartUploadID)
+	if !s3compat.UploadExists(uploadID, lom.BckName(), lom.ObjName) {
+		t.mptNotFoundS3(w, r, lom, uploadID)
+		return
+	}
+	// the object is owned by another target (e.g., the cluster has changed
+	// since the upload started) - hand the upload over to complete it there
+	smap := t.owner.smap.get()
+	tsi, err := cluster.HrwTarget(lom.Uname(), &smap.Smap)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if tsi.ID() != t.si.ID() {
+		if err := t.handoverMptUpload(lom, uploadID, tsi); err != nil {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		s3Redirect(w, tsi.URL(cmn.NetworkPublic)+r.URL.RequestURI(), lom.BckName())
+		return
+	}
+	var (
+		decoder  = xml.NewDecoder(r.Body)
+		partList = &s3compat.CompleteMptUpload{}
+	)
+	defer cmn.Close(r.Body)
+	if err := decoder.Decode(partList); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	customerKey, err := s3compat.CustomerKeyFromHeader(r.Header)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	encryption, err := s3compat.UploadEncryption(uploadID, customerKey)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	var (
+		etag    string
+		errCode int
+		errPut  error
+	)
+	err = s3compat.CompleteUpload(uploadID, partList.Parts, func(parts []*s3compat.MptPart) (err error) {
+		if etag, err = s3compat.MptETag(parts); err != nil {
+			return
+		}
+		reader, err := newMptReader(parts)
+		if err != nil {
+			return
+		}
+		var size int64
+		for _, part := range parts {
+			size += part.Size
+		}
+		if lom.Bck().IsAIS() && lom.VersionConf().Enabled {
+			lom.Load() // need to know the current version if versioning enabled
+		}
+		lom.SetAtimeUnix(started.UnixNano())
+		md := s3compat.UploadCustomMD(uploadID)
+		md[s3compat.MptETagMD] = etag
+		lom.SetCustomMD(md)
+		poi := &putObjInfo{
+			started:    started,
+			t:          t,
+			lom:        lom,
+			r:          reader,
+			size:       size,
+			ctx:        context.Background(),
+			workFQN:    fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut),
+			encryption: encryption,
+		}
+		errCode, errPut = poi.putObject()
+		reader.Close()
+		return errPut
+	})
+	if err != nil {
+		switch {
+		case errPut != nil:
+			t.fsErr(err, lom.FQN)
+			t.invalmsghdlr(w, r, err.Error(), errCode)
+		case s3compat.IsErrUploadNotFound(err):
+			t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		default:
+			t.invalmsghdlr(w, r, err.Error())
+		}
+		return
+	}
+
+	result := s3compat.NewCompleteMptUploadResult(lom.BckName(), lom.ObjName, etag)
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(result.MustMarshal())
+}
+
+// DELETE s3/bckName/objName?uploadId=ID
+// Abort multipart upload and remove all uploaded parts
+func (t *targetrunner) abortMptUploadS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom, err := t.initLomS3(r, items)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	uploadID := r.URL.Query().Get(s3compat.URLParamMultipartUploadID)
+	if !s3compat.UploadExists(uploadID, lom.BckName(), lom.ObjName) {
+		t.mptNotFoundS3(w, r, lom, uploadID)
+		return
+	}
+	if err := s3compat.AbortUpload(uploadID); err != nil {
+		if s3compat.IsErrUploadNotFound(err) {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+			return
+		}
+		glog.Errorf("%s: failed to remove parts of upload %q, err: %v", t.si, uploadID, err)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET s3/bckName/objName?uploadId=ID
+// List uploaded parts
+func (t *targetrunner) listMptPartsS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom, err := t.initLomS3(r, items)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	var (
+		query    = r.URL.Query()
+		uploadID = query.Get(s3compat.URLParamMultipartUploadID)
+		marker   int64
+		maxParts int
+	)
+	if !s3compat.UploadExists(uploadID, lom.BckName(), lom.ObjName) {
+		t.mptNotFoundS3(w, r, lom, uploadID)
+		return
+	}
+	if s := query.Get(s3compat.URLParamMultipartMarker); s != "" {
+		if marker, err = strconv.ParseInt(s, 10, 64); err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+	}
+	if s := query.Get(s3compat.URLParamMultipartMaxParts); s != "" {
+		if maxParts, err = strconv.Atoi(s); err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+	}
+	result, err := s3compat.ListParts(uploadID, marker, maxParts)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(result.MustMarshal())
+}
+
+// The upload is not here: it may have been handed over to the target that owns
+// the object (see completeMptUploadS3) - redirect there, unless it's this one.
+func (t *targetrunner) mptNotFoundS3(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, uploadID string) {
+	smap := t.owner.smap.get()
+	if tsi, err := cluster.HrwTarget(lom.Uname(), &smap.Smap); err == nil && tsi.ID() != t.si.ID() {
+		s3Redirect(w, tsi.URL(cmn.NetworkPublic)+r.URL.RequestURI(), lom.BckName())
+		return
+	}
+	t.invalmsghdlrstatusf(w, r, http.StatusNotFound, "upload %q not found", uploadID)
+}
+
+// transfers the upload - the manifest and all the parts - to a given target
+func (t *targetrunner) handoverMptUpload(lom *cluster.LOM, uploadID string, tsi *cluster.Snode) error {
+	return s3compat.HandoverUpload(uploadID, func(hdv *s3compat.MptHandover, parts []*s3compat.MptPart) error {
+		var (
+			path  = cmn.JoinWords(cmn.S3, lom.BckName(), lom.ObjName)
+			query = url.Values{}
+		)
+		query.Set(s3compat.URLParamMultipartUploads, "")
+		query.Set(s3compat.URLParamMultipartUploadID, uploadID)
+		res := t.call(callArgs{
+			si: tsi,
+			req: cmn.ReqArgs{
+				Method: http.MethodPost,
+				Base:   tsi.URL(cmn.NetworkIntraData),
+				Path:   path,
+				Query:  query,
+				Body:   cmn.MustMarshal(hdv),
+			},
+			timeout: cmn.DefaultTimeout,
+		})
+		if res.err != nil {
+			return res.err
+		}
+		for _, part := range parts {
+			fh, err := os.Open(part.FQN)
+			if err != nil {
+				return err
+			}
+			query = url.Values{}
+			query.Set(s3compat.URLParamMultipartUploadID, uploadID)
+			query.Set(s3compat.URLParamMultipartPartNo, strconv.FormatInt(part.Num, 10))
+			res = t.call(callArgs{
+				si: tsi,
+				req: cmn.ReqArgs{
+					Method: http.MethodPut,
+					Base:   tsi.URL(cmn.NetworkIntraData),
+					Path:   path,
+					Query:  query,
+					BodyR:  fh,
+				},
+				timeout: cmn.GCO.Get().Timeout.SendFile,
+			})
+			cmn.Close(fh)
+			if res.err != nil {
+				return res.err
+			}
+		}
+		glog.Infof("%s: handed over multipart upload %q of %s (%d part(s)) to %s", t.si, uploadID, lom, len(parts), tsi)
+		return nil
+	})
+}
+
+// loads the multipart uploads persisted before the restart
+func (t *targetrunner) loadMptUploads() {
+	var (
+		availablePaths, _ = fs.Get()
+		provider          = cmn.ProviderAIS
+		bmd               = t.owner.bmd.get()
+		cnt               int
+	)
+	for _, mpathInfo := range availablePaths {
+		bmd.Range(&provider, nil, func(bck *cluster.Bck) bool {
+			n, err := s3compat.LoadUploads(mpathInfo.MakePathCT(bck.Bck, fs.MptType))
+			if err != nil {
+				glog.Errorf("%s: failed to load multipart uploads of %s from %s, err: %v", t.si, bck, mpathInfo, err)
+			}
+			cnt += n
+			return false
+		})
+	}
+	if cnt > 0 {
+		glog.Infof("%s: loaded %d multipart upload(s)", t.si, cnt)
+	}
+}
+
+// periodically aborts multipart uploads with no activity for more than
+// `timeout.mpt_upload_time` and removes their parts
+func (t *targetrunner) mptHK() time.Duration {
+	expired, err := s3compat.ExpireUploads(cmn.GCO.Get().Timeout.MptUpload)
+	for _, uploadID := range expired {
+		glog.Infof("%s: multipart upload %q expired", t.si, uploadID)
+	}
+	if err != nil {
+		glog.Errorf("%s: failed to remove parts of expired multipart upload(s), err: %v", t.si, err)
+	}
+	return mptHKInterval
+}
+
+// the directory of the upload on the object's mountpath (see s3compat.InitUpload)
+func mptUploadDir(lom *cluster.LOM, uploadID string) string {
+	return filepath.Join(lom.ParsedFQN.MpathInfo.MakePathCT(lom.Bck().Bck, fs.MptType), uploadID)
+}
@@ -416,6 +416,9 @@ func (t *targetrunner) validateBckRenTxn(bckFrom *cluster.Bck, msg *aisMsg) (bck
 	if err = t.coExists(bckFrom, msg.Action); err != nil {
 		return
 	}
+	if err = cluster.CheckBckRetention(t, bckFrom); err != nil {
+		return
+	}
 	bckTo = cluster.NewBck(bTo.Name, bTo.Provider, bTo.Ns)
 	bmd := t.owner.bmd.get()
 	if _, present := bmd.Get(bckFrom); !present {
@@ -667,6 +670,13 @@ func (t *targetrunner) destroyBucket(c *txnServerCtx) error {
 		if !nlp.TryLock() {
 			return cmn.NewErrorBucketIsBusy(c.bck.Bck, t.si.Name())
 		}
+		// buckets with locked objects cannot be destroyed (see cmn.ObjectLockConf)
+		if err := c.bck.Init(t.owner.bmd, t.si); err == nil {
+			if err := cluster.CheckBckRetention(t, c.bck); err != nil {
+				nlp.Unlock()
+				return err
+			}
+		}
 		txn := newTxnBckBase("dlb", *c.bck)
 		txn.fillFromCtx(c)
 		if err := t.transactions.begin(txn); err != nil {
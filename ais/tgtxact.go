@@ -133,6 +133,11 @@ func (t *targetrunner) cmdXactStart(xactMsg *xaction.XactReqMsg, bck *cluster.Bc
 			glog.Errorf(erfmb, xactMsg.Kind, bck)
 		}
 		go t.RunLRU(xactMsg.ID, xactMsg.Force != nil && *xactMsg.Force, xactMsg.Buckets...)
+	case cmn.ActLifecycle:
+		if bck != nil {
+			glog.Errorf(erfmb, xactMsg.Kind, bck)
+		}
+		go t.runLifecycle(xactMsg.ID)
 	case cmn.ActResilver:
 		if bck != nil {
 			glog.Errorf(erfmb, xactMsg.Kind, bck)
@@ -164,9 +169,53 @@ func (t *targetrunner) cmdXactStart(xactMsg *xaction.XactReqMsg, bck *cluster.Bc
 			Xact: xact,
 		})
 		go xact.Run()
+	case cmn.ActFlush:
+		if bck == nil {
+			return fmt.Errorf(erfmn, xactMsg.Kind)
+		}
+		if !bck.IsRemote() {
+			return fmt.Errorf("%q: expecting remote bucket, got %s", xactMsg, bck)
+		}
+		xact, err := xreg.RenewBucketXact(cmn.ActFlush, bck, xreg.XactArgs{T: t, UUID: xactMsg.ID})
+		if err != nil {
+			return err
+		}
+		xact.AddNotif(&xaction.NotifXact{
+			NotifBase: nl.NotifBase{
+				When: cluster.UponTerm,
+				Dsts: []string{equalIC},
+				F:    t.callerNotifyFin,
+			},
+			Xact: xact,
+		})
+		go xact.Run()
+	case cmn.ActReplCatchup:
+		if bck == nil {
+			return fmt.Errorf(erfmn, xactMsg.Kind)
+		}
+		if !bck.Props.Replication.Enabled {
+			return fmt.Errorf("%q: replication is not enabled for %s", xactMsg, bck)
+		}
+		xact, err := xreg.RenewBucketXact(cmn.ActReplCatchup, bck, xreg.XactArgs{T: t, UUID: xactMsg.ID, Custom: t.statsT})
+		if err != nil {
+			return err
+		}
+		xact.AddNotif(&xaction.NotifXact{
+			NotifBase: nl.NotifBase{
+				When: cluster.UponTerm,
+				Dsts: []string{equalIC},
+				F:    t.callerNotifyFin,
+			},
+			Xact: xact,
+		})
+		go xact.Run()
 	// 3. cannot start
 	case cmn.ActPutCopies:
 		return fmt.Errorf("cannot start %q (is driven by PUTs into a mirrored bucket)", xactMsg)
+	case cmn.ActWriteBack:
+		return fmt.Errorf("cannot start %q (is driven by PUTs into a write-back bucket)", xactMsg)
+	case cmn.ActReplicate:
+		return fmt.Errorf("cannot start %q (is driven by PUTs and DELETEs in a replicated bucket)", xactMsg)
 	case cmn.ActDownload, cmn.ActEvictObjects, cmn.ActDelete, cmn.ActMakeNCopies, cmn.ActECEncode:
 		return fmt.Errorf("initiating %q must be done via a separate documented API", xactMsg)
 	// 4. unknown
@@ -78,6 +78,23 @@ func LoginUser(baseParams BaseParams, userID, pass string) (token *AuthCreds, er
 	return token, nil
 }
 
+// IssueS3Key requests AuthN to generate a new pair of S3 credentials for the user.
+func IssueS3Key(baseParams BaseParams, userID, pass string) (s3Key *cmn.AuthS3Key, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Users, userID, cmn.S3Keys),
+		Body:       cmn.MustMarshal(loginRec{Password: pass}),
+	}, &s3Key)
+	if err != nil {
+		return nil, err
+	}
+	if s3Key.AccessKey == "" {
+		return nil, errors.New("failed to issue S3 key: empty response from AuthN server")
+	}
+	return s3Key, nil
+}
+
 func RegisterClusterAuthN(baseParams BaseParams, cluSpec cmn.AuthCluster) error {
 	msg := cmn.MustMarshal(cluSpec)
 	baseParams.Method = http.MethodPost
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/query"
 )
 
 const (
@@ -228,6 +229,52 @@ func PrefetchRange(baseParams BaseParams, bck cmn.Bck, rng string) (string, erro
 	return doListRangeRequest(baseParams, bck, cmn.ActPrefetch, prefetchMsg)
 }
 
+// PrefetchQuery sends a HTTP request to prefetch the objects of a cloud bucket
+// selected by prefix and filters (the bucket in the query is ignored).
+func PrefetchQuery(baseParams BaseParams, bck cmn.Bck, queryMsg *query.DefMsg) (string, error) {
+	return doListRangeRequest(baseParams, bck, cmn.ActPrefetch, queryMsg)
+}
+
+// SyncBucket reconciles locally cached objects of a given remote bucket with
+// the remote bucket itself (see cmn.SyncBckMsg). Returns xaction ID.
+func SyncBucket(baseParams BaseParams, bck cmn.Bck, msg *cmn.SyncBckMsg) (xactID string, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.JoinWords(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActSyncBucket, Value: msg}),
+		Header: http.Header{
+			cmn.HeaderContentType: []string{cmn.ContentJSON},
+		},
+		Query: cmn.AddBckToQuery(nil, bck),
+	}, &xactID)
+	return
+}
+
+// FlushBucket writes back all dirty objects of a given write-back bucket (see
+// cmn.WriteBackConf) to its remote bucket and waits for completion.
+func FlushBucket(baseParams BaseParams, bck cmn.Bck, timeout ...time.Duration) error {
+	xactID, err := StartXaction(baseParams, XactReqArgs{Kind: cmn.ActFlush, Bck: bck})
+	if err != nil {
+		return err
+	}
+	args := XactReqArgs{ID: xactID, Kind: cmn.ActFlush}
+	if len(timeout) > 0 {
+		args.Timeout = timeout[0]
+	}
+	status, err := WaitForXaction(baseParams, args)
+	if err != nil {
+		return err
+	}
+	if status.ErrMsg != "" {
+		return errors.New(status.ErrMsg)
+	}
+	if status.Aborted() {
+		return fmt.Errorf("%s %q was aborted", cmn.ActFlush, xactID)
+	}
+	return nil
+}
+
 // EvictList sends a HTTP request to evict a list of objects from a cloud bucket.
 func EvictList(baseParams BaseParams, bck cmn.Bck, fileslist []string) (string, error) {
 	evictMsg := cmn.ListMsg{ObjNames: fileslist}
@@ -240,6 +287,12 @@ func EvictRange(baseParams BaseParams, bck cmn.Bck, rng string) (string, error)
 	return doListRangeRequest(baseParams, bck, cmn.ActEvictObjects, evictMsg)
 }
 
+// EvictQuery sends a HTTP request to evict the objects of a cloud bucket
+// selected by prefix and filters (the bucket in the query is ignored).
+func EvictQuery(baseParams BaseParams, bck cmn.Bck, queryMsg *query.DefMsg) (string, error) {
+	return doListRangeRequest(baseParams, bck, cmn.ActEvictObjects, queryMsg)
+}
+
 // EvictCloudBucket sends a HTTP request to a proxy to evict an entire cloud bucket from the AIStore
 // - the operation results in eliminating all traces of the specified cloud bucket in the AIStore
 func EvictCloudBucket(baseParams BaseParams, bck cmn.Bck, query ...url.Values) error {
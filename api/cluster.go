@@ -123,10 +123,16 @@ func SetClusterConfig(baseParams BaseParams, nvs cmn.SimpleKVs) error {
 	})
 }
 
-func AttachRemoteAIS(baseParams BaseParams, alias, u string) error {
+// AttachRemoteAIS attaches remote AIS cluster under a given alias. Optionally, the
+// caller may specify AuthN token to access the remote cluster (in which case the
+// token gets forwarded with each request to the remote cluster).
+func AttachRemoteAIS(baseParams BaseParams, alias, u string, token ...string) error {
 	q := make(url.Values)
 	q.Set(cmn.URLParamWhat, cmn.GetWhatRemoteAIS)
 	q.Set(alias, u)
+	if len(token) > 0 && token[0] != "" {
+		q.Set(cmn.URLParamRemoteToken, token[0])
+	}
 	baseParams.Method = http.MethodPut
 	return DoHTTPRequest(ReqParams{
 		BaseParams: baseParams,
@@ -67,6 +67,42 @@ func DownloadMulti(baseParams BaseParams, description string, bck cmn.Bck, msg i
 	return DownloadWithParam(baseParams, downloader.DlTypeMulti, dlBody)
 }
 
+// DownloadManifest downloads objects listed in a given manifest (see `downloader.DlManifestEntry`)
+// validating their sizes and checksums.
+func DownloadManifest(baseParams BaseParams, description string, bck cmn.Bck, manifest, format string,
+	intervals ...time.Duration) (string, error) {
+	dlBody := downloader.DlManifestBody{
+		Manifest: manifest,
+		Format:   format,
+	}
+
+	if len(intervals) > 0 {
+		dlBody.ProgressInterval = intervals[0].String()
+	}
+
+	dlBody.Bck = bck
+	dlBody.Description = description
+	return DownloadWithParam(baseParams, downloader.DlTypeManifest, dlBody)
+}
+
+// DownloadCrawl recursively crawls HTTP directory listing (or S3-style bucket listing)
+// at a given URL and downloads all discovered objects (see `downloader.DlCrawlBody`).
+func DownloadCrawl(baseParams BaseParams, description string, bck cmn.Bck, url string, depth int,
+	intervals ...time.Duration) (string, error) {
+	dlBody := downloader.DlCrawlBody{
+		URL:   url,
+		Depth: depth,
+	}
+
+	if len(intervals) > 0 {
+		dlBody.ProgressInterval = intervals[0].String()
+	}
+
+	dlBody.Bck = bck
+	dlBody.Description = description
+	return DownloadWithParam(baseParams, downloader.DlTypeCrawl, dlBody)
+}
+
 func DownloadCloud(baseParams BaseParams, description string, bck cmn.Bck, prefix, suffix string, intervals ...time.Duration) (string, error) {
 	dlBody := downloader.DlCloudBody{
 		Prefix: prefix,
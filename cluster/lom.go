@@ -49,6 +49,9 @@ type (
 		cksum    *cmn.Cksum // ReCache(ref)
 		copies   fs.MPI     // ditto
 		customMD cmn.SimpleKVs
+		sse      string // encryption metadata (see lom_sse.go)
+		cmpr     string // compression metadata (see lom_compress.go)
+		dirty    bool   // not yet written back (see cmn.WriteBackConf)
 	}
 	LOM struct {
 		md      lmeta  // local meta
@@ -100,6 +103,11 @@ func (lom *LOM) GetCustomMD(key string) (string, bool) {
 	value, exists := lom.md.customMD[key]
 	return value, exists
 }
+
+// dirty objects are yet to be written back to the remote bucket (see package writeback)
+func (lom *LOM) Dirty() bool         { return lom.md.dirty }
+func (lom *LOM) SetDirty(dirty bool) { lom.md.dirty = dirty }
+
 func (lom *LOM) ECEnabled() bool            { return lom.Bprops().EC.Enabled }
 func (lom *LOM) IsHRW() bool                { return lom.HrwFQN == lom.FQN } // subj to resilvering
 func (lom *LOM) Bck() *Bck                  { return lom.bck }
@@ -132,6 +140,10 @@ func (lom *LOM) CopyMetadata(from *LOM) {
 	lom.md.size = from.md.size
 	lom.md.version = from.md.version
 	lom.md.atime = from.md.atime
+	lom.md.customMD = from.md.customMD
+	lom.md.sse = from.md.sse
+	lom.md.cmpr = from.md.cmpr
+	lom.md.dirty = from.md.dirty
 }
 
 func (lom *LOM) CloneCopiesMd() int {
@@ -171,6 +183,12 @@ func (lom *LOM) FromHTTPHdr(hdr http.Header) {
 		}
 		lom.SetCustomMD(md)
 	}
+	if sseEntry := hdr.Get(cmn.HeaderObjSSE); sseEntry != "" {
+		lom.SetSSE(sseEntry)
+	}
+	if cmprEntry := hdr.Get(cmn.HeaderObjCompress); cmprEntry != "" {
+		lom.SetCompression(cmprEntry)
+	}
 }
 
 ////////////////////////////
@@ -0,0 +1,53 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/compress"
+)
+
+//
+// On-disk compression - see cmn.ObjCompressionConf and package compress.
+//
+// Same as encrypted objects (see lom_sse.go), compressed objects are stored,
+// replicated, erasure coded, and rebalanced in their compressed form, along
+// with the compression metadata that keeps the size and checksum of the
+// uncompressed content. Objects get compressed before being encrypted.
+//
+
+func (lom *LOM) Compressed() bool        { return lom.md.cmpr != "" }
+func (lom *LOM) Compression() string     { return lom.md.cmpr }
+func (lom *LOM) SetCompression(s string) { lom.md.cmpr = s }
+
+// CompressWriter returns writer that compresses the new content of the object
+// as per bucket properties, or nil if the content is not to be compressed.
+// Once the writer is closed, the caller must call SetCompressed.
+func (lom *LOM) CompressWriter(w io.Writer) (*compress.Writer, error) {
+	lom.md.cmpr = ""
+	conf := &lom.Bprops().Compression
+	if !conf.Enabled {
+		return nil, nil
+	}
+	return compress.NewWriter(w, conf.Algorithm)
+}
+
+// SetCompressed updates the object's compression metadata given the closed
+// writer and the checksum of the uncompressed content.
+func (lom *LOM) SetCompressed(cw *compress.Writer, cksum *cmn.Cksum) {
+	if !cw.Compressed() {
+		lom.md.cmpr = ""
+		return
+	}
+	info := &compress.Info{Alg: cw.Alg(), Size: cw.ContentSize()}
+	if cksum != nil {
+		info.CksumType, info.CksumValue = cksum.Get()
+	}
+	lom.md.cmpr = info.String()
+}
+
+func (lom *LOM) cmprInfo() (*compress.Info, error) { return compress.ParseInfo(lom.md.cmpr) }
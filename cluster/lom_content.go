@@ -0,0 +1,135 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"io"
+	"os"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/compress"
+	"github.com/NVIDIA/aistore/sse"
+)
+
+//
+// Content of the object vs. its stored (encoded) content - see lom_sse.go and
+// lom_compress.go. The stored content is what lom.Size() and lom.Cksum() refer
+// to; the (decoded) content is what users get.
+//
+
+type (
+	// ContentReader reads the (decoded) content of the object.
+	ContentReader interface {
+		io.Reader
+		io.ReaderAt
+		Size() int64
+	}
+
+	// decoding cmn.ReadOpenCloser
+	contentHandle struct {
+		ContentReader
+		file        *os.File
+		lom         *LOM
+		fqn         string
+		customerKey []byte
+	}
+	// object metadata of the decoded content
+	contentMeta struct {
+		*LOM
+	}
+)
+
+// interface guard
+var (
+	_ cmn.ReadOpenCloser        = (*contentHandle)(nil)
+	_ cmn.ObjHeaderMetaProvider = (*contentMeta)(nil)
+)
+
+// Encoded returns true if the stored content of the object differs from its
+// content, that is, if the object is encrypted or compressed.
+func (lom *LOM) Encoded() bool { return lom.Encrypted() || lom.Compressed() }
+
+// ContentSize returns the size of the object as seen by users, that is,
+// the size of the decoded content.
+func (lom *LOM) ContentSize() int64 {
+	if lom.Compressed() {
+		if info, err := lom.cmprInfo(); err == nil {
+			return info.Size
+		}
+	}
+	if lom.Encrypted() {
+		return sse.PlainSize(lom.md.size)
+	}
+	return lom.md.size
+}
+
+// ContentCksum returns the checksum of the decoded content, if known.
+func (lom *LOM) ContentCksum() *cmn.Cksum {
+	if lom.Compressed() {
+		if info, err := lom.cmprInfo(); err == nil {
+			return info.Cksum()
+		}
+		return nil
+	}
+	if lom.Encrypted() {
+		return nil // (the checksum of encrypted object is not the checksum of its content)
+	}
+	return lom.md.cksum
+}
+
+// ContentReader returns reader of the decoded content of the object (or its
+// replica, or workfile); customer key is required only for objects encrypted
+// with the client-provided key (SSE-C).
+func (lom *LOM) ContentReader(r io.ReaderAt, customerKey []byte) (ContentReader, error) {
+	var (
+		cr   ContentReader = io.NewSectionReader(r, 0, lom.md.size)
+		size               = lom.md.size
+	)
+	if lom.Encrypted() {
+		dr, err := lom.decryptReader(r, customerKey)
+		if err != nil {
+			return nil, err
+		}
+		cr, r, size = dr, dr, dr.Size()
+	}
+	if lom.Compressed() {
+		info, err := lom.cmprInfo()
+		if err != nil {
+			return nil, err
+		}
+		if cr, err = compress.NewReader(r, size, info.Alg); err != nil {
+			return nil, err
+		}
+	}
+	return cr, nil
+}
+
+// Open returns reader of the (decoded) content of the object.
+func (lom *LOM) Open() (cmn.ReadOpenCloser, error) { return lom.OpenFQN(lom.FQN, nil) }
+
+// OpenFQN opens a given file that holds the object's content and returns
+// reader of the decoded content if the object is encoded.
+func (lom *LOM) OpenFQN(fqn string, customerKey []byte) (cmn.ReadOpenCloser, error) {
+	if !lom.Encoded() {
+		return cmn.NewFileHandle(fqn)
+	}
+	file, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := lom.ContentReader(file, customerKey)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &contentHandle{ContentReader: reader, file: file, lom: lom, fqn: fqn, customerKey: customerKey}, nil
+}
+
+func (h *contentHandle) Close() error { return h.file.Close() }
+
+func (h *contentHandle) Open() (io.ReadCloser, error) { return h.lom.OpenFQN(h.fqn, h.customerKey) }
+
+func (m contentMeta) Size() int64       { return m.ContentSize() }
+func (m contentMeta) Cksum() *cmn.Cksum { return m.ContentCksum() }
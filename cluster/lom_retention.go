@@ -0,0 +1,282 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+//
+// Object lock (WORM) - see cmn.ObjectLockConf.
+//
+// Retention and legal hold of an object are stored in its custom metadata.
+// An object is locked while its retention period lasts or while it is under
+// legal hold, whichever is longer. Retention in governance mode can be
+// bypassed, while compliance mode retention cannot be bypassed, shortened,
+// or removed. Previous versions of objects (see lom_version.go) retain the
+// locks they had when they were current.
+//
+
+// per-target records of the objects that have been locked (see CheckBckRetention)
+const lockedCollection = "objlock"
+
+type lockedRec struct {
+	Until int64 `json:"until,string"` // the latest retain-until (Unix nanoseconds) of all versions
+	Hold  bool  `json:"hold"`         // legal hold has been placed on any of the versions
+}
+
+// serializes updating vs removing the records
+var lockedMu sync.Mutex
+
+const (
+	RetentionModeMD = "retention-mode" // cmn.LockModeGovernance or cmn.LockModeCompliance
+	RetainUntilMD   = "retain-until"   // unix nanoseconds
+	LegalHoldMD     = "legal-hold"
+)
+
+// Retention returns retention mode and period of the object; empty mode
+// means that the object has no retention.
+func (lom *LOM) Retention() (mode string, until time.Time) {
+	mode, ok := lom.GetCustomMD(RetentionModeMD)
+	if !ok {
+		return
+	}
+	value, _ := lom.GetCustomMD(RetainUntilMD)
+	ns, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return "", time.Time{}
+	}
+	return mode, time.Unix(0, ns)
+}
+
+func (lom *LOM) LegalHold() bool {
+	_, ok := lom.GetCustomMD(LegalHoldMD)
+	return ok
+}
+
+// CheckRetention returns cmn.ObjectLockedError if the object cannot be
+// modified or removed.
+func (lom *LOM) CheckRetention(bypassGovernance bool) error {
+	if lom.LegalHold() {
+		return cmn.NewObjectLockedError(lom.String(), "under legal hold")
+	}
+	mode, until := lom.Retention()
+	if mode == "" || !until.After(time.Now()) {
+		return nil
+	}
+	if mode == cmn.LockModeGovernance && bypassGovernance {
+		return nil
+	}
+	return cmn.NewObjectLockedError(lom.String(),
+		fmt.Sprintf("retained in %s mode until %s", mode, until.Format(time.RFC3339)))
+}
+
+// CheckOverwrite checks whether the object can be overwritten - that is,
+// whether the object is locked unless it is going to be kept as a previous
+// version.
+func (lom *LOM) CheckOverwrite() error {
+	if lom.VersionConf().KeepPrevious || !lom.Bprops().ObjectLock.Enabled {
+		return nil
+	}
+	cur := lom.Clone(lom.FQN)
+	if err := cur.FromFS(); err != nil {
+		return nil
+	}
+	return cur.CheckRetention(false)
+}
+
+// CheckBckRetention returns cmn.ObjectLockedError if any object of the bucket,
+// current or previous version, stored on this target is locked. Buckets with
+// locked objects cannot be destroyed or renamed. Rather than walking the
+// bucket, the check visits only the objects that have ever been locked on
+// this target (see markLocked) and removes the records that have expired.
+func CheckBckRetention(t Target, bck *Bck) error {
+	if !bck.Props.ObjectLock.Enabled {
+		return nil
+	}
+	var (
+		db     = t.DB()
+		prefix = bck.MakeUname("")
+		now    = time.Now().UnixNano()
+	)
+	keys, err := db.List(lockedCollection, prefix)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		rec := &lockedRec{}
+		if err := db.Get(lockedCollection, key, rec); err != nil {
+			if dbdriver.IsErrNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if rec.Hold || rec.Until > now {
+			lom := &LOM{T: t, ObjName: key[len(prefix):]}
+			if err := lom.Init(bck.Bck); err != nil {
+				return err
+			}
+			if err := lom.checkRetentionAny(); err != nil {
+				return err
+			}
+		}
+		if err := unmarkLocked(db, key, rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRetentionAny checks current and previous versions of the object
+// wherever they are stored (e.g., not yet resilvered)
+func (lom *LOM) checkRetentionAny() error {
+	availablePaths, _ := fs.Get()
+	for _, mpathInfo := range availablePaths {
+		mlom := lom.Clone(fs.CSM.FQN(mpathInfo, lom.bck.Bck, fs.ObjectType, lom.ObjName))
+		mlom.ParsedFQN.MpathInfo = mpathInfo
+		if err := mlom.FromFS(); err == nil {
+			if err := mlom.CheckRetention(false /*bypass governance*/); err != nil {
+				return err
+			}
+		}
+		vers, err := mlom.versions()
+		if err != nil {
+			return err
+		}
+		for _, ver := range vers {
+			vlom, err := mlom.LoadVersion(strconv.FormatUint(ver, 10))
+			if err != nil {
+				continue
+			}
+			if err := vlom.CheckRetention(false /*bypass governance*/); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// markLocked records that the object (uname) has a locked current or previous
+// version, unless already recorded. Retention can only be extended, so the
+// record keeps the latest retain-until.
+func (lom *LOM) markLocked() {
+	var (
+		hold        = lom.LegalHold()
+		mode, until = lom.Retention()
+	)
+	if !hold && (mode == "" || !until.After(time.Now())) {
+		return
+	}
+	var (
+		db  = lom.T.DB()
+		key = lom.Uname()
+		rec = &lockedRec{}
+	)
+	lockedMu.Lock()
+	err := db.Get(lockedCollection, key, rec)
+	if err == nil || dbdriver.IsErrNotFound(err) {
+		curr := *rec
+		rec.Hold = rec.Hold || hold
+		if mode != "" && until.UnixNano() > rec.Until {
+			rec.Until = until.UnixNano()
+		}
+		err = nil
+		if *rec != curr {
+			err = db.Set(lockedCollection, key, rec)
+		}
+	}
+	lockedMu.Unlock()
+	if err != nil {
+		glog.Errorf("%s: failed to record object lock, err: %v", lom, err)
+	}
+}
+
+// remove the record unless it has been updated in the meantime
+func unmarkLocked(db dbdriver.Driver, key string, rec *lockedRec) error {
+	curr := &lockedRec{}
+	lockedMu.Lock()
+	defer lockedMu.Unlock()
+	if err := db.Get(lockedCollection, key, curr); err != nil {
+		if dbdriver.IsErrNotFound(err) {
+			err = nil
+		}
+		return err
+	}
+	if *curr != *rec {
+		return nil
+	}
+	return db.Delete(lockedCollection, key)
+}
+
+// SetRetention sets (or, if the mode is empty, removes) retention of the
+// object. An active retention can only be extended, or upgraded from
+// governance to compliance mode, unless it is bypassed in governance mode.
+// The caller is responsible for persisting LOM metadata.
+func (lom *LOM) SetRetention(mode string, until time.Time, bypassGovernance bool) error {
+	curMode, curUntil := lom.Retention()
+	if curMode != "" && curUntil.After(time.Now()) {
+		extend := mode != "" && !until.Before(curUntil) && (mode == curMode || mode == cmn.LockModeCompliance)
+		if !extend && (curMode == cmn.LockModeCompliance || !bypassGovernance) {
+			return cmn.NewObjectLockedError(lom.String(),
+				fmt.Sprintf("cannot shorten or remove %s mode retention until %s",
+					curMode, curUntil.Format(time.RFC3339)))
+		}
+	}
+	if mode == "" {
+		lom.setCustomMD(RetentionModeMD, "")
+		lom.setCustomMD(RetainUntilMD, "")
+		return nil
+	}
+	lom.setCustomMD(RetentionModeMD, mode)
+	lom.setCustomMD(RetainUntilMD, strconv.FormatInt(until.UnixNano(), 10))
+	return nil
+}
+
+// SetLegalHold places or removes legal hold. The caller is responsible for
+// persisting LOM metadata.
+func (lom *LOM) SetLegalHold(on bool) {
+	if on {
+		lom.setCustomMD(LegalHoldMD, "on")
+	} else {
+		lom.setCustomMD(LegalHoldMD, "")
+	}
+}
+
+// SetDefaultRetention applies the bucket's default retention to a new object,
+// unless the latter has its own.
+func (lom *LOM) SetDefaultRetention() {
+	conf := &lom.Bprops().ObjectLock
+	if !conf.Enabled || conf.Days == 0 {
+		return
+	}
+	if mode, _ := lom.Retention(); mode != "" {
+		return
+	}
+	until := time.Now().Add(time.Duration(conf.Days) * 24 * time.Hour)
+	lom.setCustomMD(RetentionModeMD, conf.Mode)
+	lom.setCustomMD(RetainUntilMD, strconv.FormatInt(until.UnixNano(), 10))
+}
+
+// copy-on-write, so that the LOMs that share the same metadata are not affected
+func (lom *LOM) setCustomMD(key, value string) {
+	md := make(cmn.SimpleKVs, len(lom.md.customMD)+1)
+	for k, v := range lom.md.customMD {
+		md[k] = v
+	}
+	if value == "" {
+		delete(md, key)
+	} else {
+		md[key] = value
+	}
+	lom.md.customMD = md
+}
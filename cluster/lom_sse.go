@@ -0,0 +1,60 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"io"
+
+	"github.com/NVIDIA/aistore/sse"
+)
+
+//
+// Server-side encryption - see cmn.EncryptionConf and package sse.
+//
+// Encrypted objects are stored (and checksummed, replicated, erasure coded,
+// and rebalanced) in their encrypted form, so that lom.Size() and lom.Cksum()
+// refer to the encrypted content; the object's encryption metadata travels
+// along with the object. The content is decrypted only when read by users
+// or by the components that transform it (see lom_content.go).
+//
+
+func (lom *LOM) Encrypted() bool { return lom.md.sse != "" }
+func (lom *LOM) SSE() string     { return lom.md.sse }
+func (lom *LOM) SetSSE(s string) { lom.md.sse = s }
+
+// EncryptWriter returns writer that encrypts the new content of the object
+// and updates the object's encryption metadata, or nil if the content is not
+// to be encrypted. The client's encryption params (if any) take precedence
+// over the bucket's properties.
+func (lom *LOM) EncryptWriter(w io.Writer, params *sse.Params) (*sse.Writer, error) {
+	if params == nil {
+		conf := &lom.Bprops().Encryption
+		if !conf.Enabled {
+			return nil, nil
+		}
+		params = &sse.Params{KeyID: conf.KeyID}
+	}
+	info, key, err := sse.NewInfo(params)
+	if err != nil {
+		return nil, err
+	}
+	lom.md.sse = info.String()
+	return sse.NewWriter(w, key)
+}
+
+// returns reader of the decrypted content of the object (or its replica, or
+// workfile); customer key is required only for objects encrypted with the
+// client-provided key (SSE-C)
+func (lom *LOM) decryptReader(r io.ReaderAt, customerKey []byte) (*sse.Reader, error) {
+	info, err := sse.ParseInfo(lom.md.sse)
+	if err != nil {
+		return nil, err
+	}
+	key, err := info.Key(customerKey)
+	if err != nil {
+		return nil, err
+	}
+	return sse.NewReader(r, lom.md.size, key)
+}
@@ -29,6 +29,8 @@ var _ = Describe("LOM", func() {
 		bucketLocalA = "LOM_TEST_Local_A"
 		bucketLocalB = "LOM_TEST_Local_B"
 		bucketLocalC = "LOM_TEST_Local_C"
+		bucketLocalV = "LOM_TEST_Local_V"
+		bucketLocalL = "LOM_TEST_Local_L"
 
 		bucketCloudA = "LOM_TEST_Cloud_A"
 		bucketCloudB = "LOM_TEST_Cloud_B"
@@ -39,6 +41,8 @@ var _ = Describe("LOM", func() {
 	var (
 		localBckA = cmn.Bck{Name: bucketLocalA, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
 		localBckB = cmn.Bck{Name: bucketLocalB, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		localBckV = cmn.Bck{Name: bucketLocalV, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		localBckL = cmn.Bck{Name: bucketLocalL, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
 		cloudBckA = cmn.Bck{Name: bucketCloudA, Provider: cmn.ProviderAmazon, Ns: cmn.NsGlobal}
 	)
 
@@ -68,6 +72,7 @@ var _ = Describe("LOM", func() {
 
 	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.VersionType, &fs.VersionContentResolver{})
 
 	var (
 		bmd = cluster.NewBaseBownerMock(
@@ -87,6 +92,20 @@ var _ = Describe("LOM", func() {
 					Mirror: cmn.MirrorConf{Enabled: true, Copies: 2},
 				},
 			),
+			cluster.NewBck(
+				bucketLocalV, cmn.ProviderAIS, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum:      cmn.CksumConf{Type: cmn.ChecksumNone},
+					Versioning: cmn.VersionConf{Enabled: true, KeepPrevious: true, MaxVersions: 2},
+				},
+			),
+			cluster.NewBck(
+				bucketLocalL, cmn.ProviderAIS, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum:      cmn.CksumConf{Type: cmn.ChecksumNone},
+					ObjectLock: cmn.ObjectLockConf{Enabled: true, Mode: cmn.LockModeGovernance, Days: 1},
+				},
+			),
 			cluster.NewBck(sameBucketName, cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{}),
 			cluster.NewBck(bucketCloudA, cmn.ProviderAmazon, cmn.NsGlobal, &cmn.BucketProps{}),
 			cluster.NewBck(bucketCloudB, cmn.ProviderAmazon, cmn.NsGlobal, &cmn.BucketProps{}),
@@ -961,6 +980,217 @@ var _ = Describe("LOM", func() {
 		})
 	})
 
+	Describe("previous versions", func() {
+		const testFileSize = 123
+		testObject := "foldr/test-obj.ext"
+		localFQN := mis[0].MakePathFQN(localBckV, fs.ObjectType, testObject)
+
+		BeforeEach(func() {
+			fs.Disable(mpaths[1]) // Ensure that it matches localFQN
+			fs.Disable(mpaths[2]) // ditto
+		})
+
+		AfterEach(func() {
+			fs.Enable(mpaths[1])
+			fs.Enable(mpaths[2])
+		})
+
+		It("should keep previous versions and delete markers", func() {
+			lom := filePut(localFQN, testFileSize, tMock)
+			Expect(lom.ArchiveVersion()).NotTo(HaveOccurred())
+			Expect(lom.Version()).To(Equal("1"))
+			fqn, err := lom.VersionFQN("1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fqn).To(Equal(mis[0].MakePathFQN(localBckV, fs.VersionType, testObject+";1")))
+			_, err = os.Stat(localFQN)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+			vlom, err := lom.LoadVersion("1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vlom.Size()).To(BeEquivalentTo(testFileSize))
+			Expect(vlom.IsDelMarker()).To(BeFalse())
+
+			// overwrite with version 2 and then delete
+			createTestFile(localFQN, 2*testFileSize)
+			Expect(lom.IncVersion()).NotTo(HaveOccurred())
+			lom.SetSize(2 * testFileSize)
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			Expect(lom.ArchiveVersion()).NotTo(HaveOccurred())
+			Expect(lom.AddDelMarker()).NotTo(HaveOccurred())
+			Expect(lom.Version()).To(Equal("3"))
+			vlom, err = lom.LoadVersion("3")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vlom.IsDelMarker()).To(BeTrue())
+
+			// max_versions = 2
+			_, err = lom.LoadVersion("1")
+			Expect(cmn.IsObjNotExist(err)).To(BeTrue())
+
+			// removing delete marker makes the previous version current
+			Expect(lom.DeleteVersion("3", false)).NotTo(HaveOccurred())
+			Expect(lom.Load(false)).NotTo(HaveOccurred())
+			Expect(lom.Version()).To(Equal("2"))
+			Expect(lom.Size()).To(BeEquivalentTo(2 * testFileSize))
+
+			Expect(cmn.IsObjNotExist(lom.DeleteVersion("1", false))).To(BeTrue())
+		})
+
+		It("should reject invalid versions", func() {
+			var (
+				lom      = filePut(localFQN, testFileSize, tMock)
+				otherFQN = mis[0].MakePathFQN(localBckA, fs.ObjectType, testObject)
+			)
+			createTestFile(otherFQN, testFileSize)
+			// "<object name>;../<path to the object in another bucket>"
+			rel, err := filepath.Rel(filepath.Dir(mis[0].MakePathFQN(localBckV, fs.VersionType, testObject)), otherFQN)
+			Expect(err).NotTo(HaveOccurred())
+			traversal := "../" + rel
+			for _, version := range []string{traversal, "", "1/../1", "-1", "v1"} {
+				_, err := lom.VersionFQN(version)
+				Expect(err).To(HaveOccurred())
+				_, err = lom.LoadVersion(version)
+				Expect(err).To(HaveOccurred())
+				Expect(lom.DeleteVersion(version, true)).To(HaveOccurred())
+			}
+			_, err = os.Stat(otherFQN)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should move previous versions when mountpaths change", func() {
+			// find an object that moves away from mpaths[0] once all mountpaths are enabled
+			fs.Enable(mpaths[1])
+			fs.Enable(mpaths[2])
+			var objName string
+			for i := 0; objName == ""; i++ {
+				name := fmt.Sprintf("foldr/test-obj-%d.ext", i)
+				lom := &cluster.LOM{T: tMock, ObjName: name}
+				Expect(lom.Init(localBckV)).NotTo(HaveOccurred())
+				if lom.ParsedFQN.MpathInfo.Path != mpaths[0] {
+					objName = name
+				}
+			}
+			fs.Disable(mpaths[1])
+			fs.Disable(mpaths[2])
+
+			lom := filePut(mis[0].MakePathFQN(localBckV, fs.ObjectType, objName), testFileSize, tMock)
+			Expect(lom.ArchiveVersion()).NotTo(HaveOccurred())
+			oldFQN, err := lom.VersionFQN("1")
+			Expect(err).NotTo(HaveOccurred())
+			hash := getTestFileHash(oldFQN)
+
+			fs.Enable(mpaths[1])
+			fs.Enable(mpaths[2])
+			Expect(mis[1].CreateMissingBckDirs(localBckV)).NotTo(HaveOccurred())
+			Expect(mis[2].CreateMissingBckDirs(localBckV)).NotTo(HaveOccurred())
+			lom = &cluster.LOM{T: tMock, ObjName: objName}
+			Expect(lom.Init(localBckV)).NotTo(HaveOccurred())
+			_, err = lom.LoadVersion("1")
+			Expect(cmn.IsObjNotExist(err)).To(BeTrue())
+
+			// resilver
+			vlom, version, err := cluster.VersionOf(tMock, oldFQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal("1"))
+			Expect(vlom.Uname()).To(Equal(lom.Uname()))
+			vlom.Lock(true)
+			err = vlom.MoveVersion(oldFQN, version)
+			vlom.Unlock(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			moved, err := lom.LoadVersion("1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(moved.Size()).To(BeEquivalentTo(testFileSize))
+			Expect(getTestFileHash(moved.FQN)).To(Equal(hash))
+			_, err = os.Stat(oldFQN)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+
+	Describe("object lock", func() {
+		const testFileSize = 123
+		testObject := "foldr/test-obj.ext"
+		localFQN := mis[0].MakePathFQN(localBckL, fs.ObjectType, testObject)
+
+		BeforeEach(func() {
+			fs.Disable(mpaths[1]) // Ensure that it matches localFQN
+			fs.Disable(mpaths[2]) // ditto
+		})
+
+		AfterEach(func() {
+			fs.Enable(mpaths[1])
+			fs.Enable(mpaths[2])
+		})
+
+		It("should apply default retention and allow to bypass it in governance mode", func() {
+			lom := filePut(localFQN, testFileSize, tMock)
+			lom.SetDefaultRetention()
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+
+			mode, until := lom.Retention()
+			Expect(mode).To(Equal(cmn.LockModeGovernance))
+			Expect(until).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
+			Expect(cmn.IsErrObjLocked(lom.CheckRetention(false))).To(BeTrue())
+			Expect(lom.CheckRetention(true)).NotTo(HaveOccurred())
+			Expect(cmn.IsErrObjLocked(NewBasicLom(localFQN, tMock).CheckOverwrite())).To(BeTrue())
+
+			Expect(lom.SetRetention("", time.Time{}, false)).To(HaveOccurred())
+			Expect(lom.SetRetention("", time.Time{}, true)).NotTo(HaveOccurred())
+			Expect(lom.CheckRetention(false)).NotTo(HaveOccurred())
+		})
+
+		It("should not allow to shorten compliance mode retention", func() {
+			lom := filePut(localFQN, testFileSize, tMock)
+			until := time.Now().Add(time.Hour)
+			Expect(lom.SetRetention(cmn.LockModeCompliance, until, false)).NotTo(HaveOccurred())
+			Expect(lom.SetRetention(cmn.LockModeCompliance, until.Add(-time.Minute), true)).To(HaveOccurred())
+			Expect(lom.SetRetention(cmn.LockModeGovernance, until.Add(time.Hour), true)).To(HaveOccurred())
+			Expect(lom.SetRetention(cmn.LockModeCompliance, until.Add(time.Hour), false)).NotTo(HaveOccurred())
+			Expect(lom.CheckRetention(true)).To(HaveOccurred())
+		})
+
+		It("should not allow to remove object under legal hold", func() {
+			lom := filePut(localFQN, testFileSize, tMock)
+			lom.SetLegalHold(true)
+			Expect(lom.LegalHold()).To(BeTrue())
+			Expect(cmn.IsErrObjLocked(lom.CheckRetention(true))).To(BeTrue())
+			lom.SetLegalHold(false)
+			Expect(lom.CheckRetention(false)).NotTo(HaveOccurred())
+		})
+
+		It("should not allow to destroy bucket with locked objects", func() {
+			lom := filePut(localFQN, testFileSize, tMock)
+			Expect(cluster.CheckBckRetention(tMock, lom.Bck())).NotTo(HaveOccurred())
+
+			lom.SetLegalHold(true)
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			Expect(cmn.IsErrObjLocked(cluster.CheckBckRetention(tMock, lom.Bck()))).To(BeTrue())
+
+			lom.SetLegalHold(false)
+			Expect(lom.SetRetention(cmn.LockModeGovernance, time.Now().Add(time.Hour), false)).NotTo(HaveOccurred())
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			Expect(cmn.IsErrObjLocked(cluster.CheckBckRetention(tMock, lom.Bck()))).To(BeTrue())
+
+			Expect(lom.SetRetention("", time.Time{}, true)).NotTo(HaveOccurred())
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			Expect(cluster.CheckBckRetention(tMock, lom.Bck())).NotTo(HaveOccurred())
+		})
+
+		It("should keep track of locked objects that have been migrated or removed", func() {
+			lom := filePut(localFQN, testFileSize, tMock)
+			lom.SetLegalHold(true)
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			keys, err := tMock.DB().List("objlock", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(1))
+
+			// e.g., migrated to another target
+			Expect(os.Remove(localFQN)).NotTo(HaveOccurred())
+			Expect(cluster.CheckBckRetention(tMock, lom.Bck())).NotTo(HaveOccurred())
+			keys, err = tMock.DB().List("objlock", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(BeEmpty())
+		})
+	})
+
 	Describe("local and cloud bucket with the same name", func() {
 		It("should have different fqn", func() {
 			testObject := "foldr/test-obj.ext"
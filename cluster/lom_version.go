@@ -0,0 +1,403 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+//
+// Previous (noncurrent) versions of objects in ais buckets that have
+// `versioning.keep_previous` enabled.
+//
+// When an object gets overwritten or deleted, its current content is renamed,
+// along with its metadata, to a file of the fs.VersionType content type
+// named "<object name>;<version>" and located on the same mountpath. Deleting
+// an object also creates a delete marker: an empty version file with
+// DelMarkerMD custom metadata that becomes the latest version of the object.
+//
+// Versions always follow their object: resilver moves them to the object's
+// (HRW) mountpath (see MoveVersion) and rebalance - to the object's target
+// (see PutVersion).
+//
+// All methods below, except LoadVersion, must be called under exclusive lock.
+//
+
+const DelMarkerMD = "delete-marker"
+
+// ValidateVersion returns error if the version is not a valid (numeric) version;
+// client-provided versions must be validated as they become part of the FQN.
+func ValidateVersion(version string) error {
+	if _, err := strconv.ParseUint(version, 10, 64); err != nil {
+		return fmt.Errorf("invalid version %q", version)
+	}
+	return nil
+}
+
+// VersionFQN returns FQN of a given previous version of the object.
+func (lom *LOM) VersionFQN(version string) (string, error) {
+	if err := ValidateVersion(version); err != nil {
+		return "", err
+	}
+	return lom.versionFQN(version), nil
+}
+
+func (lom *LOM) versionFQN(version string) string {
+	return fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.VersionType, version)
+}
+
+func (lom *LOM) IsDelMarker() bool {
+	_, ok := lom.GetCustomMD(DelMarkerMD)
+	return ok
+}
+
+// LoadVersion loads metadata of a given previous version (or delete marker)
+// of the object. The returned LOM is never cached.
+func (lom *LOM) LoadVersion(version string) (vlom *LOM, err error) {
+	fqn, err := lom.VersionFQN(version)
+	if err != nil {
+		return nil, err
+	}
+	return lom.LoadVersionFile(fqn)
+}
+
+// LoadVersionFile loads metadata of the object's previous version stored in
+// a given file - not necessarily on the object's mountpath (see VersionOf).
+func (lom *LOM) LoadVersionFile(fqn string) (vlom *LOM, err error) {
+	vlom = lom.Clone(fqn)
+	vlom.md = lmeta{uname: lom.md.uname}
+	if err = vlom.FromFS(); err != nil {
+		return nil, err
+	}
+	vlom.loaded = true
+	return
+}
+
+// VersionOf returns the object (initialized but not loaded) and the version
+// that a given version file (fs.VersionType) belongs to.
+func VersionOf(t Target, fqn string) (lom *LOM, version string, err error) {
+	parsedFQN, err := fs.ParseFQN(fqn)
+	if err != nil {
+		return nil, "", err
+	}
+	objName, version, ok := fs.SplitVersion(parsedFQN.ObjName)
+	if !ok || parsedFQN.ContentType != fs.VersionType {
+		return nil, "", fmt.Errorf("%q is not a version of any object", fqn)
+	}
+	lom = &LOM{T: t, ObjName: objName}
+	if err = lom.Init(parsedFQN.Bck); err != nil {
+		return nil, "", err
+	}
+	return lom, version, nil
+}
+
+// PackVersionMeta returns serialized metadata of a given (loaded) version -
+// to store the version elsewhere (see PutVersion).
+func (vlom *LOM) PackVersionMeta() []byte {
+	buf, mm := vlom._persist()
+	meta := append([]byte(nil), buf...)
+	mm.Free(buf)
+	return meta
+}
+
+// PutVersion stores a given previous version of the object - its content and
+// serialized metadata (see PackVersionMeta) - on the object's mountpath.
+// The modification time is preserved as it is when the version has become
+// noncurrent (see lifecycle `noncurrent_days`).
+func (lom *LOM) PutVersion(version string, r io.Reader, meta []byte, mtime time.Time) error {
+	fqn, err := lom.VersionFQN(version)
+	if err != nil {
+		return err
+	}
+	workFQN := fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut)
+	file, err := lom.CreateFile(workFQN)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(file, r); err != nil {
+		cmn.Close(file)
+	} else {
+		err = file.Close()
+	}
+	if err == nil {
+		err = fs.SetXattr(workFQN, XattrLOM, meta)
+	}
+	if err == nil {
+		err = os.Chtimes(workFQN, mtime, mtime)
+	}
+	if err == nil {
+		err = cmn.Rename(workFQN, fqn)
+	}
+	if err != nil {
+		if errRm := cmn.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf(fmtNestedErr, errRm)
+		}
+		return err
+	}
+	if lom.Bprops().ObjectLock.Enabled {
+		if vlom, err := lom.LoadVersionFile(fqn); err == nil {
+			vlom.markLocked()
+		}
+	}
+	return nil
+}
+
+// MoveVersion moves a given version file to the object's mountpath, if
+// misplaced (see resilver). Must be called under exclusive lock.
+func (lom *LOM) MoveVersion(fqn, version string) error {
+	dst, err := lom.VersionFQN(version)
+	if err != nil || dst == fqn {
+		return err
+	}
+	vlom, err := lom.LoadVersionFile(fqn)
+	if err != nil {
+		return err
+	}
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(fqn)
+	if err != nil {
+		return err
+	}
+	err = lom.PutVersion(version, file, vlom.PackVersionMeta(), finfo.ModTime())
+	cmn.Close(file)
+	if err != nil {
+		return err
+	}
+	return os.Remove(fqn)
+}
+
+// RemoveVersionFiles removes a given version from all mountpaths, e.g.,
+// once the version has been migrated to another target.
+func (lom *LOM) RemoveVersionFiles(version string) error {
+	if err := ValidateVersion(version); err != nil {
+		return err
+	}
+	availablePaths, _ := fs.Get()
+	for _, mpathInfo := range availablePaths {
+		fqn := fs.CSM.FQN(mpathInfo, lom.Bck().Bck, fs.VersionType, lom.ObjName+fs.VersionSepa+version)
+		if err := cmn.RemoveFile(fqn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ArchiveVersion renames the current object, if exists, to its previous
+// version. In addition, it sets LOM's version to the latest version of the
+// object, so that subsequent IncVersion generates the version of the next one.
+func (lom *LOM) ArchiveVersion() error {
+	vers, err := lom.versions()
+	if err != nil {
+		return err
+	}
+	var (
+		latest uint64
+		cur    = lom.Clone(lom.FQN)
+	)
+	if len(vers) > 0 {
+		latest = vers[len(vers)-1]
+	}
+	if err := cur.FromFS(); err == nil {
+		ver, _ := strconv.ParseUint(cur.Version(), 10, 64) // zero if created with versioning disabled
+		if err := lom.archive(cur, ver); err != nil {
+			return err
+		}
+		if ver > latest {
+			latest = ver
+		}
+		vers = append(vers, ver)
+		sort.Slice(vers, func(i, j int) bool { return vers[i] < vers[j] })
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	lom.SetVersion(strconv.FormatUint(latest, 10))
+	lom.pruneVersions(vers)
+	return nil
+}
+
+// AddDelMarker creates a delete marker with the next version of the object.
+// Must be called after ArchiveVersion.
+func (lom *LOM) AddDelMarker() error {
+	if err := lom.IncVersion(); err != nil {
+		return err
+	}
+	marker := lom.Clone(lom.versionFQN(lom.Version()))
+	marker.md = lmeta{
+		uname:    lom.md.uname,
+		version:  lom.Version(),
+		atime:    time.Now().UnixNano(),
+		cksum:    cmn.NoneCksum,
+		customMD: cmn.SimpleKVs{DelMarkerMD: "true"},
+	}
+	file, err := cmn.CreateFile(marker.FQN)
+	if err != nil {
+		return err
+	}
+	cmn.Close(file)
+	if err := marker.Persist(); err != nil {
+		if errRm := cmn.RemoveFile(marker.FQN); errRm != nil {
+			glog.Errorf(fmtNestedErr, errRm)
+		}
+		return err
+	}
+	vers, err := lom.versions()
+	if err != nil {
+		return err
+	}
+	lom.pruneVersions(vers)
+	return nil
+}
+
+// DeleteVersion permanently removes a given version of the object, current or
+// previous, unless the version is locked (see CheckRetention). When the object
+// is left without the current version, the latest previous version (unless it
+// is a delete marker) becomes current.
+func (lom *LOM) DeleteVersion(version string, bypassGovernance bool) error {
+	if err := ValidateVersion(version); err != nil {
+		return err
+	}
+	cur := lom.Clone(lom.FQN)
+	if err := cur.FromFS(); err == nil {
+		if cur.Version() != version {
+			return lom.removeVersion(version, bypassGovernance)
+		}
+		if err := cur.CheckRetention(bypassGovernance); err != nil {
+			return err
+		}
+		if err := cur.Remove(); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	} else if err := lom.removeVersion(version, bypassGovernance); err != nil {
+		return err
+	}
+	return lom.promoteLatest()
+}
+
+func (lom *LOM) removeVersion(version string, bypassGovernance bool) error {
+	vlom, err := lom.LoadVersion(version)
+	if err != nil {
+		return err
+	}
+	if err := vlom.CheckRetention(bypassGovernance); err != nil {
+		return err
+	}
+	return os.Remove(vlom.FQN)
+}
+
+// renames the current object to a given previous version
+func (lom *LOM) archive(cur *LOM, ver uint64) error {
+	for copyFQN := range cur.md.copies {
+		if copyFQN == cur.FQN {
+			continue
+		}
+		if err := cmn.RemoveFile(copyFQN); err != nil {
+			glog.Errorf("%s: failed to remove copy %s, err: %v", lom, copyFQN, err)
+		}
+	}
+	fqn := lom.versionFQN(strconv.FormatUint(ver, 10))
+	if err := cmn.Rename(cur.FQN, fqn); err != nil {
+		return err
+	}
+	lom.Uncache()
+
+	vlom := cur.Clone(fqn)
+	vlom.md.copies = nil
+	if err := vlom.Persist(); err != nil {
+		return err
+	}
+	// the version becomes noncurrent now (see lifecycle `noncurrent_days`)
+	now := time.Now()
+	if err := os.Chtimes(fqn, now, now); err != nil {
+		glog.Errorf("%s: failed to update mtime of %s, err: %v", lom, fqn, err)
+	}
+	return nil
+}
+
+func (lom *LOM) promoteLatest() error {
+	vers, err := lom.versions()
+	if err != nil || len(vers) == 0 {
+		return err
+	}
+	vlom, err := lom.LoadVersion(strconv.FormatUint(vers[len(vers)-1], 10))
+	if err != nil {
+		return err
+	}
+	if vlom.IsDelMarker() {
+		return nil
+	}
+	if err := cmn.Rename(vlom.FQN, lom.FQN); err != nil {
+		return err
+	}
+	lom.Uncache()
+	return nil
+}
+
+// keeps at most `versioning.max_versions` latest versions (in addition to
+// the locked ones, if any)
+func (lom *LOM) pruneVersions(vers []uint64) {
+	max := lom.VersionConf().MaxVersions
+	if max == 0 || len(vers) <= max {
+		return
+	}
+	for _, ver := range vers[:len(vers)-max] {
+		version := strconv.FormatUint(ver, 10)
+		if lom.Bprops().ObjectLock.Enabled {
+			if vlom, err := lom.LoadVersion(version); err == nil && vlom.CheckRetention(false) != nil {
+				continue
+			}
+		}
+		fqn := lom.versionFQN(version)
+		if err := cmn.RemoveFile(fqn); err != nil {
+			glog.Errorf("%s: failed to remove %s, err: %v", lom, fqn, err)
+		}
+	}
+}
+
+// returns (sorted) previous versions of the object
+func (lom *LOM) versions() (vers []uint64, err error) {
+	var (
+		fqn    = lom.versionFQN("0")
+		prefix = filepath.Base(lom.ObjName) + fs.VersionSepa
+		names  []string
+	)
+	dir, err := os.Open(filepath.Dir(fqn))
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	names, err = dir.Readdirnames(-1)
+	cmn.Close(dir)
+	if err != nil {
+		return
+	}
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if ver, err := strconv.ParseUint(name[len(prefix):], 10, 64); err == nil {
+			vers = append(vers, ver)
+		}
+	}
+	sort.Slice(vers, func(i, j int) bool { return vers[i] < vers[j] })
+	return
+}
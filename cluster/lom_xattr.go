@@ -52,6 +52,9 @@ const (
 	lomObjSize
 	lomObjCopies
 	lomCustomMD
+	lomSSE
+	lomCompression
+	lomDirty
 )
 
 // packing format separators
@@ -70,6 +73,8 @@ const (
 	SourceAmazonObjMD = cmn.ProviderAmazon
 	SourceGoogleObjMD = cmn.ProviderGoogle
 	SourceAzureObjMD  = cmn.ProviderAzure
+	SourceHDFSObjMD   = cmn.ProviderHDFS
+	SourcePOSIXObjMD  = cmn.ProviderPOSIX
 	SourceHTTPObjMD   = cmn.ProviderHTTP
 	SourceWebObjMD    = "web"
 
@@ -130,11 +135,22 @@ func (lom *LOM) Persist() (err error) {
 	buf, mm := lom._persist()
 	if err = fs.SetXattr(lom.FQN, XattrLOM, buf); err != nil {
 		lom.T.FSHC(err, lom.FQN)
+	} else if lom.bck != nil && lom.Bprops().ObjectLock.Enabled {
+		lom.markLocked()
 	}
 	mm.Free(buf)
 	return
 }
 
+// PersistMeta stores updated metadata of an existing object and all its copies.
+// NOTE: uname for LOM must be already locked.
+func (lom *LOM) PersistMeta() (err error) {
+	if err = lom.Persist(); err != nil {
+		return
+	}
+	return lom.syncMetaWithCopies()
+}
+
 // TODO -- FIXME: xattrMaxSize == MaxSmallSlabSize is the hard limit
 //                support runtime switch small => page allocator
 func (lom *LOM) _persist() (buf []byte, mm *memsys.MMSA) {
@@ -202,6 +218,7 @@ func (md *lmeta) unmarshal(buf []byte) (err error) {
 		return fmt.Errorf("%s: unknown checksum %d", invalid, buf[1])
 	}
 	payload = string(buf[prefLen:])
+	md.sse, md.cmpr, md.dirty = "", "", false
 	actualCksum = xxhash.Checksum64S(buf[prefLen:], cmn.MLCG32)
 	expectedCksum = binary.BigEndian.Uint64(buf[2:])
 	if expectedCksum != actualCksum {
@@ -276,6 +293,12 @@ func (md *lmeta) unmarshal(buf []byte) (err error) {
 			for i := 0; i < len(entries); i += 2 {
 				md.customMD[entries[i]] = entries[i+1]
 			}
+		case lomSSE:
+			md.sse = val
+		case lomCompression:
+			md.cmpr = val
+		case lomDirty:
+			md.dirty = true
 		default:
 			return errors.New(invalid + " #6")
 		}
@@ -320,6 +343,18 @@ func (md *lmeta) marshal(mm *memsys.MMSA, mdSize int64) (buf []byte) {
 		buf = _marshRecord(mm, buf, lomCustomMD, "", false)
 		buf = _marshCustomMD(mm, buf, md.customMD)
 	}
+	if md.sse != "" {
+		buf = mm.Append(buf, recordSepa)
+		buf = _marshRecord(mm, buf, lomSSE, md.sse, false)
+	}
+	if md.cmpr != "" {
+		buf = mm.Append(buf, recordSepa)
+		buf = _marshRecord(mm, buf, lomCompression, md.cmpr, false)
+	}
+	if md.dirty {
+		buf = mm.Append(buf, recordSepa)
+		buf = _marshRecord(mm, buf, lomDirty, "1", false)
+	}
 
 	// checksum, prepend, and return
 	buf[0] = mdVersion
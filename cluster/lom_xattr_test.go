@@ -90,6 +90,9 @@ var _ = Describe("LOM Xattributes", func() {
 					cluster.VersionObjMD: "version",
 					cluster.CRC32CObjMD:  "crc32",
 				})
+				lom.SetSSE(`{"kid":"k1","dk":"AAEC"}`)
+				lom.SetCompression(`{"alg":"zstd","size":1024}`)
+				lom.SetDirty(true)
 				Expect(lom.AddCopy(fqns[0], copyMpathInfo)).NotTo(HaveOccurred())
 				Expect(lom.AddCopy(fqns[1], copyMpathInfo)).NotTo(HaveOccurred())
 				Expect(lom.Persist()).NotTo(HaveOccurred())
@@ -108,6 +111,11 @@ var _ = Describe("LOM Xattributes", func() {
 				Expect(lom.GetCopies()).To(BeEquivalentTo(newLom.GetCopies()))
 				Expect(lom.CustomMD()).To(HaveLen(3))
 				Expect(lom.CustomMD()).To(BeEquivalentTo(newLom.CustomMD()))
+				Expect(newLom.Encrypted()).To(BeTrue())
+				Expect(lom.SSE()).To(Equal(newLom.SSE()))
+				Expect(newLom.Compressed()).To(BeTrue())
+				Expect(newLom.ContentSize()).To(BeEquivalentTo(1024))
+				Expect(newLom.Dirty()).To(BeTrue())
 			})
 
 			It("should override old values", func() {
@@ -130,6 +130,7 @@ type Target interface {
 	GetCold(ctx context.Context, lom *LOM, getType GetColdType) (errCode int, err error)
 	PromoteFile(params PromoteFileParams) (lom *LOM, err error)
 	LookupRemoteSingle(lom *LOM, si *Snode) bool
+	HandoverRepl(lom *LOM, si *Snode) (errCode int, err error)
 
 	// File-system related functions.
 	FSHC(err error, path string)
@@ -21,13 +21,15 @@ var _ Target = (*TargetMock)(nil)
 
 // TargetMock implements Target interface with mocked return values.
 type TargetMock struct {
-	BO Bowner
+	BO       Bowner
+	DBDriver dbdriver.Driver
 }
 
 func NewTargetMock(bo Bowner) *TargetMock {
 	InitLomLocker()
 	return &TargetMock{
-		BO: bo,
+		BO:       bo,
+		DBDriver: dbdriver.NewDBMock(),
 	}
 }
 
@@ -57,11 +59,12 @@ func (*TargetMock) GetCold(ctx context.Context, lom *LOM, _ GetColdType) (int, e
 	return http.StatusOK, nil
 }
 func (*TargetMock) PromoteFile(_ PromoteFileParams) (*LOM, error)      { return nil, nil }
-func (*TargetMock) DB() dbdriver.Driver                                { return nil }
+func (t *TargetMock) DB() dbdriver.Driver                              { return t.DBDriver }
 func (*TargetMock) Cloud(_ *Bck) CloudProvider                         { return nil }
 func (*TargetMock) StartTime() time.Time                               { return time.Now() }
 func (*TargetMock) GFN(_ GFNType) GFN                                  { return nil }
 func (*TargetMock) LookupRemoteSingle(_ *LOM, _ *Snode) bool           { return false }
+func (*TargetMock) HandoverRepl(_ *LOM, _ *Snode) (int, error)         { return 0, nil }
 func (*TargetMock) RebalanceNamespace(_ *Snode) ([]byte, int, error)   { return nil, 0, nil }
 func (*TargetMock) BMDVersionFixup(_ *http.Request, _ cmn.Bck, _ bool) {}
 func (*TargetMock) Health(si *Snode, timeout time.Duration, query url.Values) ([]byte, int, error) {
@@ -194,3 +194,36 @@ func TestToken(t *testing.T) {
 
 	deleteUsers(mgr, false, t)
 }
+
+func TestS3Key(t *testing.T) {
+	driver := dbdriver.NewDBMock()
+	mgr, err := newUserManager(driver)
+	tassert.CheckError(t, err)
+	createUsers(mgr, t)
+	defer deleteUsers(mgr, true, t)
+
+	_, err = mgr.issueS3Key(users[0], passs[1])
+	if err != errInvalidCredentials {
+		t.Errorf("S3 key issued for incorrect user creds: %v", err)
+	}
+
+	s3Key, err := mgr.issueS3Key(users[0], passs[0])
+	tassert.CheckFatal(t, err)
+	if s3Key.SecretKey != cmn.S3SecretKey(s3Key.AccessKey, conf.Auth.Secret) {
+		t.Errorf("Invalid secret key %q", s3Key.SecretKey)
+	}
+	token, err := cmn.DecryptToken(s3Key.AccessKey, conf.Auth.Secret)
+	tassert.CheckFatal(t, err)
+	if !token.S3Key || token.UserID != users[0] {
+		t.Errorf("Invalid S3 key token: %+v", token)
+	}
+
+	// deleting a user must revoke all its S3 keys
+	tassert.CheckFatal(t, mgr.delUser(users[0]))
+	if _, err := driver.GetString(s3KeysCollection, s3Key.AccessKey); err == nil {
+		t.Errorf("S3 key of deleted user %s was not revoked", users[0])
+	}
+	if _, err := driver.GetString(revokedCollection, s3Key.AccessKey); err != nil {
+		t.Errorf("S3 key of deleted user %s is not in the revoked list: %v", users[0], err)
+	}
+}
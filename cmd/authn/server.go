@@ -20,12 +20,17 @@ const (
 	pathUsers    = "users"
 	pathTokens   = "tokens"
 	pathClusters = "clusters"
+	pathS3Keys   = "s3keys"
 )
 
 // a message to generate token
 // POST: <version>/<pathUsers>/<username>
 //		Body: <loginMsg>
 //	Returns: <tokenMsg>
+// or to generate S3 credentials
+// POST: <version>/<pathUsers>/<username>/<pathS3Keys>
+//		Body: <loginMsg>
+//	Returns: <cmn.AuthS3Key>
 type loginMsg struct {
 	Password string `json:"password"`
 }
@@ -186,6 +191,8 @@ func (a *authServ) httpUserPost(w http.ResponseWriter, r *http.Request) {
 		return
 	} else if len(apiItems) == 0 {
 		a.userAdd(w, r)
+	} else if len(apiItems) == 2 && apiItems[1] == pathS3Keys {
+		a.userS3Key(w, r, apiItems[0])
 	} else {
 		a.userLogin(w, r)
 	}
@@ -320,6 +327,27 @@ func (a *authServ) userLogin(w http.ResponseWriter, r *http.Request) {
 	a.writeBytes(w, []byte(repl), "auth")
 }
 
+// Generate a new pair of S3 credentials for a user if provided credentials are valid
+func (a *authServ) userS3Key(w http.ResponseWriter, r *http.Request, userID string) {
+	msg := &loginMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		glog.Errorf("Failed to read request body: %v\n", err)
+		return
+	}
+	if msg.Password == "" {
+		cmn.InvalidHandlerWithMsg(w, r, "Not authorized", http.StatusUnauthorized)
+		return
+	}
+
+	s3Key, err := a.users.issueS3Key(userID, msg.Password)
+	if err != nil {
+		glog.Errorf("Failed to generate S3 key: %v\n", err)
+		cmn.InvalidHandlerWithMsg(w, r, "Not authorized", http.StatusUnauthorized)
+		return
+	}
+	a.writeJSON(w, s3Key, "s3key")
+}
+
 // Borrowed from ais (modified cmn.InvalidHandler calls)
 func (a *authServ) writeJSON(w http.ResponseWriter, val interface{}, tag string) {
 	w.Header().Set(cmn.HeaderContentType, cmn.ContentJSON)
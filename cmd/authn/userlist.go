@@ -27,6 +27,7 @@ const (
 	tokensCollection   = "token"
 	revokedCollection  = "revoked"
 	clustersCollection = "cluster"
+	s3KeysCollection   = "s3key" // S3 access key => user ID
 
 	adminID   = "admin"
 	adminPass = "admin"
@@ -220,6 +221,9 @@ func (m *userManager) delUser(userID string) error {
 		_ = m.db.Delete(tokensCollection, userID)
 		err = m.db.SetString(revokedCollection, token.Token, userID)
 	}
+	if errKeys := m.revokeS3Keys(userID); errKeys != nil && err == nil {
+		err = errKeys
+	}
 
 	return err
 }
@@ -366,38 +370,94 @@ func (m *userManager) issueToken(userID, pwd string, ttl ...time.Duration) (stri
 	}
 	expires = issued.Add(expDelta)
 
-	// put all useful info into token: who owns the token, when it was issued,
-	// when it expires and credentials to log in AWS, GCP etc.
-	// If a user is a super user, it is enough to pass only isAdmin marker
-	var t *jwt.Token
+	tokenString, err := m.signToken(uInfo, expires, false /*s3key*/)
+	if err != nil {
+		return "", err
+	}
+
+	// TODO: multiple tokens per user
+	tInfo = &cmn.AuthToken{
+		UserID:  userID,
+		Expires: expires,
+		Token:   tokenString,
+	}
+	err = m.db.Set(tokensCollection, userID, tInfo)
+	return tokenString, err
+}
+
+// Generates a new pair of S3 credentials for a user if user credentials are
+// valid. The access key is a never-expiring token that can be used only to
+// sign S3 requests (AWS Signature V4); the secret key is derived from it.
+// Every call generates a new pair - the previously issued ones remain valid
+// until revoked.
+func (m *userManager) issueS3Key(userID, pwd string) (*cmn.AuthS3Key, error) {
+	uInfo := &cmn.AuthUser{}
+	if err := m.db.Get(usersCollection, userID, uInfo); err != nil {
+		glog.Error(err)
+		return nil, errInvalidCredentials
+	}
+	if !isSamePassword(pwd, uInfo.Password) {
+		return nil, errInvalidCredentials
+	}
+	accessKey, err := m.signToken(uInfo, time.Now().Add(foreverTokenTime), true /*s3key*/)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.db.SetString(s3KeysCollection, accessKey, userID); err != nil {
+		return nil, err
+	}
+	return &cmn.AuthS3Key{
+		AccessKey: accessKey,
+		SecretKey: cmn.S3SecretKey(accessKey, conf.Auth.Secret),
+	}, nil
+}
+
+// Put all useful info into token: who owns the token, when it was issued,
+// when it expires and credentials to log in AWS, GCP etc.
+// If a user is a super user, it is enough to pass only isAdmin marker
+func (m *userManager) signToken(uInfo *cmn.AuthUser, expires time.Time, s3Key bool) (string, error) {
+	var claims jwt.MapClaims
 	if uInfo.IsAdmin() {
-		t = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		claims = jwt.MapClaims{
 			"expires":  expires,
-			"username": userID,
+			"username": uInfo.ID,
 			"admin":    true,
-		})
+		}
 	} else {
 		m.fixClusterIDs(uInfo.Clusters)
-		t = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		claims = jwt.MapClaims{
 			"expires":  expires,
-			"username": userID,
+			"username": uInfo.ID,
 			"buckets":  uInfo.Buckets,
 			"clusters": uInfo.Clusters,
-		})
+		}
+	}
+	if s3Key {
+		claims["s3key"] = true
 	}
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := t.SignedString([]byte(conf.Auth.Secret))
 	if err != nil {
 		return "", fmt.Errorf("failed to generate token: %v", err)
 	}
+	return tokenString, nil
+}
 
-	// TODO: multiple tokens per user
-	tInfo = &cmn.AuthToken{
-		UserID:  userID,
-		Expires: expires,
-		Token:   tokenString,
+// Revokes all S3 access keys of a user
+func (m *userManager) revokeS3Keys(userID string) error {
+	keys, err := m.db.GetAll(s3KeysCollection, "")
+	if err != nil {
+		return err
 	}
-	err = m.db.Set(tokensCollection, userID, tInfo)
-	return tokenString, err
+	for accessKey, uid := range keys {
+		if uid != userID {
+			continue
+		}
+		if err := m.revokeToken(accessKey); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Delete existing token, a.k.a log out
@@ -418,6 +478,11 @@ func (m *userManager) revokeToken(token string) error {
 			glog.Errorf("Failed to clean up token for %s", uInfo.ID)
 		}
 	}
+	if _, err := m.db.GetString(s3KeysCollection, token); err == nil {
+		if err := m.db.Delete(s3KeysCollection, token); err != nil {
+			glog.Errorf("Failed to clean up S3 key: %v", err)
+		}
+	}
 
 	return nil
 }
@@ -18,7 +18,9 @@ import (
 
 var (
 	attachCmdsFlags = map[string][]cli.Flag{
-		subcmdAttachRemoteAIS: {},
+		subcmdAttachRemoteAIS: {
+			remoteTokenFlag,
+		},
 		subcmdAttachMountpath: {},
 	}
 
@@ -78,7 +80,7 @@ func attachRemoteAISHandler(c *cli.Context) (err error) {
 	if err != nil {
 		return
 	}
-	if err = api.AttachRemoteAIS(defaultAPIParams, alias, url); err != nil {
+	if err = api.AttachRemoteAIS(defaultAPIParams, alias, url, parseStrFlag(c, remoteTokenFlag)); err != nil {
 		return
 	}
 	fmt.Fprintf(c.App.Writer, "Remote cluster (%s=%s) successfully attached\n", alias, url)
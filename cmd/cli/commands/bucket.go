@@ -8,6 +8,7 @@ package commands
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
 	"sort"
 	"strconv"
@@ -362,6 +363,16 @@ func setBucketProps(c *cli.Context, bck cmn.Bck, props cmn.BucketPropsToUpdate)
 	return
 }
 
+// Sets properties of the bucket in the remote AIS cluster
+func setRemoteBucketProps(c *cli.Context, bck cmn.Bck, props cmn.BucketPropsToUpdate) (err error) {
+	query := url.Values{cmn.URLParamRemoteProps: []string{"true"}}
+	if _, err = api.SetBucketProps(defaultAPIParams, bck, props, query); err != nil {
+		return
+	}
+	fmt.Fprintln(c.App.Writer, "Remote bucket props successfully updated")
+	return
+}
+
 // Resets bucket props
 func resetBucketProps(c *cli.Context, bck cmn.Bck) (err error) {
 	if _, err = api.ResetBucketProps(defaultAPIParams, bck); err != nil {
@@ -25,6 +25,7 @@ const (
 	commandDetach    = "detach"
 	commandECEncode  = "ec-encode"
 	commandEvict     = "evict"
+	commandFlush     = cmn.ActFlush
 	commandGenShards = "gen-shards"
 	commandGet       = "get"
 	commandJoin      = "join"
@@ -39,6 +40,7 @@ const (
 	commandShow      = "show"
 	commandStart     = cmn.ActXactStart
 	commandStop      = cmn.ActXactStop
+	commandSyncBck   = cmn.ActSyncBucket
 	commandWait      = "wait"
 	commandSearch    = "search"
 	commandETL       = cmn.ETL
@@ -258,6 +260,7 @@ var (
 	dataSlicesFlag    = cli.IntFlag{Name: "data-slices,data,d", Usage: "number of data slices", Required: true}
 	paritySlicesFlag  = cli.IntFlag{Name: "parity-slices,parity,p", Usage: "number of parity slices", Required: true}
 	listBucketsFlag   = cli.StringFlag{Name: "buckets", Usage: "comma-separated list of bucket names, eg. 'b1,b2,b3'"}
+	pushFlag          = cli.BoolFlag{Name: "push", Usage: "upload local-only objects to the remote bucket instead of evicting them"}
 
 	// Daeclu
 	countFlag = cli.IntFlag{Name: "count", Usage: "total number of generated reports", Value: countDefault}
@@ -276,7 +279,30 @@ var (
 		Name:  "limit-bytes-per-hour,limit-bph,bph",
 		Usage: "number of bytes (can end with suffix (k, MB, GiB, ...)) that all targets can maximally download in hour",
 	}
-	objectsListFlag = cli.StringFlag{
+	chunksFlag = cli.IntFlag{
+		Name:  "chunks",
+		Usage: "split large objects into up to N ranges downloaded concurrently (limited by --limit-connections)",
+	}
+	chunkMinSizeFlag = cli.StringFlag{
+		Name:  "chunk-min-size",
+		Usage: "do not split objects smaller than this size (can end with suffix (k, MB, GiB, ...))",
+	}
+	scheduleFlag = cli.StringFlag{
+		Name:  "schedule",
+		Usage: "run the download periodically, on a cron-like schedule in UTC (e.g. \"0 3 * * *\", \"@daily\", \"@every 6h\")",
+	}
+	manifestFlag = cli.StringFlag{
+		Name:  "manifest",
+		Usage: "path to manifest (JSON lines, CSV or TSV) with links, object names, expected sizes and checksums (md5, sha256, xxhash)",
+	}
+	crawlFlag = cli.BoolFlag{
+		Name:  "crawl",
+		Usage: "treat SOURCE as HTTP directory listing (or S3 bucket listing) and recursively download all discovered links",
+	}
+	crawlDepthFlag   = cli.IntFlag{Name: "crawl-depth", Usage: "max number of nested directories to crawl into (0 - unlimited)"}
+	crawlIncludeFlag = cli.StringFlag{Name: "crawl-include", Usage: "crawl: regex pattern - download only matching objects"}
+	crawlExcludeFlag = cli.StringFlag{Name: "crawl-exclude", Usage: "crawl: regex pattern - skip matching objects"}
+	objectsListFlag  = cli.StringFlag{
 		Name:  "object-list,from",
 		Usage: "path to file containing JSON array of strings with object names to download",
 	}
@@ -327,6 +353,13 @@ var (
 	tokenFileFlag = cli.StringFlag{Name: "file,f", Value: "", Usage: "save token to file"}
 	passwordFlag  = cli.StringFlag{Name: "password,p", Value: "", Usage: "user password"}
 
+	// Remote AIS cluster
+	remoteTokenFlag = cli.StringFlag{Name: "token", Usage: "AuthN token to access the remote cluster"}
+	remotePropsFlag = cli.BoolFlag{
+		Name:  "remote",
+		Usage: "update properties of the remote AIS bucket itself (rather than its local counterpart)",
+	}
+
 	// Copy Bucket
 	cpBckDryRunFlag = cli.BoolFlag{
 		Name:  "dry-run",
@@ -365,6 +398,19 @@ var (
 		listFlag,
 		templateFlag,
 	}
+
+	// Query (select objects by prefix and filters)
+	queryExtFlag     = cli.StringFlag{Name: "ext", Usage: "select objects with a given extension, eg. '.tar'"}
+	queryMinSizeFlag = cli.StringFlag{Name: "min-size", Usage: "select objects of at least a given size, eg. '100MB'"}
+	queryMaxSizeFlag = cli.StringFlag{Name: "max-size", Usage: "select objects of at most a given size, eg. '1GiB'"}
+	queryIdleFlag    = cli.DurationFlag{Name: "not-accessed", Usage: "select objects not accessed for a given duration, eg. '720h'"}
+	baseQueryFlags   = []cli.Flag{
+		prefixFlag,
+		queryExtFlag,
+		queryMinSizeFlag,
+		queryMaxSizeFlag,
+		queryIdleFlag,
+	}
 )
 
 func getCksumFlags() []cli.Flag {
@@ -10,7 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -31,14 +33,22 @@ var (
 			timeoutFlag,
 			descriptionFlag,
 			limitConnectionsFlag,
+			chunksFlag,
+			chunkMinSizeFlag,
+			scheduleFlag,
 			objectsListFlag,
+			manifestFlag,
+			crawlFlag,
+			crawlDepthFlag,
+			crawlIncludeFlag,
+			crawlExcludeFlag,
 			progressIntervalFlag,
 		},
 		subcmdStartDsort: {
 			specFileFlag,
 		},
 		commandPrefetch: append(
-			baseLstRngFlags,
+			append(baseLstRngFlags, baseQueryFlags...),
 			dryRunFlag,
 		),
 		subcmdLRU: {
@@ -131,7 +141,7 @@ func xactionCmds() cli.Commands {
 
 	splCmdKinds := make(cmn.StringSet)
 	// Add any xaction which requires a separate handler here.
-	splCmdKinds.Add(cmn.ActPrefetch, cmn.ActECEncode, cmn.ActMakeNCopies, cmn.ActLRU)
+	splCmdKinds.Add(cmn.ActPrefetch, cmn.ActECEncode, cmn.ActMakeNCopies, cmn.ActLRU, cmn.ActFlush)
 
 	startable := listXactions(true)
 	for _, xact := range startable {
@@ -211,13 +221,13 @@ func stopXactionHandler(c *cli.Context) (err error) {
 
 func startDownloadHandler(c *cli.Context) error {
 	var (
-		description      = parseStrFlag(c, descriptionFlag)
-		timeout          = parseStrFlag(c, timeoutFlag)
-		objectsListPath  = parseStrFlag(c, objectsListFlag)
-		progressInterval = parseStrFlag(c, progressIntervalFlag)
-		id               string
+		objectsListPath = parseStrFlag(c, objectsListFlag)
+		id              string
 	)
 
+	if manifestPath := parseStrFlag(c, manifestFlag); manifestPath != "" {
+		return startManifestDownloadHandler(c, manifestPath)
+	}
 	if c.NArg() == 0 {
 		return missingArgumentsError(c, "source", "destination")
 	}
@@ -245,33 +255,19 @@ func startDownloadHandler(c *cli.Context) error {
 		return err
 	}
 
-	limitBPH, err := parseByteFlagToInt(c, limitBytesPerHourFlag)
+	basePayload, err := parseDownloadBase(c, bucket)
 	if err != nil {
 		return err
 	}
 
-	if _, err := time.ParseDuration(progressInterval); err != nil {
-		return err
-	}
-
-	basePayload := downloader.DlBase{
-		Bck: cmn.Bck{
-			Name:     bucket,
-			Provider: cmn.ProviderAIS,
-			Ns:       cmn.NsGlobal,
-		},
-		Timeout:          timeout,
-		Description:      description,
-		ProgressInterval: progressInterval,
-		Limits: downloader.DlLimits{
-			Connections:  parseIntFlag(c, limitConnectionsFlag),
-			BytesPerHour: int(limitBPH),
-		},
-	}
-
 	// Heuristics to determine the download type.
 	var dlType downloader.DlType
-	if objectsListPath != "" {
+	if flagIsSet(c, crawlFlag) {
+		if source.link == "" {
+			return fmt.Errorf("cannot crawl %q: expecting HTTP(S) link", src)
+		}
+		dlType = downloader.DlTypeCrawl
+	} else if objectsListPath != "" {
 		dlType = downloader.DlTypeMulti
 	} else if strings.Contains(source.link, "{") && strings.Contains(source.link, "}") {
 		dlType = downloader.DlTypeRange
@@ -351,6 +347,16 @@ func startDownloadHandler(c *cli.Context) error {
 			Template: source.link,
 		}
 		id, err = api.DownloadWithParam(defaultAPIParams, dlType, payload)
+	case downloader.DlTypeCrawl:
+		payload := downloader.DlCrawlBody{
+			DlBase:  basePayload,
+			URL:     source.link,
+			Subdir:  pathSuffix, // in this case pathSuffix is a subdirectory in which the objects are to be saved
+			Depth:   parseIntFlag(c, crawlDepthFlag),
+			Include: parseStrFlag(c, crawlIncludeFlag),
+			Exclude: parseStrFlag(c, crawlExcludeFlag),
+		}
+		id, err = api.DownloadWithParam(defaultAPIParams, dlType, payload)
 	case downloader.DlTypeCloud:
 		payload := downloader.DlCloudBody{
 			DlBase: basePayload,
@@ -366,11 +372,101 @@ func startDownloadHandler(c *cli.Context) error {
 		return err
 	}
 
+	fmt.Fprintln(c.App.Writer, id)
+	if basePayload.Schedule != "" {
+		fmt.Fprintf(c.App.Writer, "Run `ais show download` to list scheduled downloads and outcomes of their runs.\n")
+		return nil
+	}
+	fmt.Fprintf(c.App.Writer, "Run `ais show download %s --progress` to monitor the progress of downloading.\n", id)
+	return nil
+}
+
+// startManifestDownloadHandler downloads objects listed in a given manifest file
+// (JSON lines, CSV, or TSV - by the file extension).
+func startManifestDownloadHandler(c *cli.Context, manifestPath string) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "destination")
+	}
+	if c.NArg() > 1 {
+		return incorrectUsageMsg(c, "too many arguments - expected destination bucket only (source links are in the manifest)")
+	}
+	bucket, pathSuffix, err := parseDest(c.Args().First())
+	if err != nil {
+		return err
+	}
+	if pathSuffix != "" {
+		return incorrectUsageMsg(c, "destination must be a bucket (object names are in the manifest)")
+	}
+	basePayload, err := parseDownloadBase(c, bucket)
+	if err != nil {
+		return err
+	}
+	manifest, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return err
+	}
+	format := downloader.ManifestJSONL
+	switch strings.ToLower(filepath.Ext(manifestPath)) {
+	case ".csv":
+		format = downloader.ManifestCSV
+	case ".tsv":
+		format = downloader.ManifestTSV
+	}
+	payload := downloader.DlManifestBody{
+		DlBase:   basePayload,
+		Manifest: string(manifest),
+		Format:   format,
+	}
+	id, err := api.DownloadWithParam(defaultAPIParams, downloader.DlTypeManifest, payload)
+	if err != nil {
+		return err
+	}
 	fmt.Fprintln(c.App.Writer, id)
 	fmt.Fprintf(c.App.Writer, "Run `ais show download %s --progress` to monitor the progress of downloading.\n", id)
 	return nil
 }
 
+// parseDownloadBase parses flags common to all types of download requests.
+func parseDownloadBase(c *cli.Context, bucket string) (downloader.DlBase, error) {
+	var (
+		description      = parseStrFlag(c, descriptionFlag)
+		timeout          = parseStrFlag(c, timeoutFlag)
+		progressInterval = parseStrFlag(c, progressIntervalFlag)
+	)
+	limitBPH, err := parseByteFlagToInt(c, limitBytesPerHourFlag)
+	if err != nil {
+		return downloader.DlBase{}, err
+	}
+	chunkMinSize, err := parseByteFlagToInt(c, chunkMinSizeFlag)
+	if err != nil {
+		return downloader.DlBase{}, err
+	}
+
+	if _, err := time.ParseDuration(progressInterval); err != nil {
+		return downloader.DlBase{}, err
+	}
+
+	return downloader.DlBase{
+		Bck: cmn.Bck{
+			Name:     bucket,
+			Provider: cmn.ProviderAIS,
+			Ns:       cmn.NsGlobal,
+		},
+		Timeout:          timeout,
+		Description:      description,
+		ProgressInterval: progressInterval,
+		Limits: downloader.DlLimits{
+			Connections:  parseIntFlag(c, limitConnectionsFlag),
+			BytesPerHour: int(limitBPH),
+		},
+		Chunks: downloader.DlChunks{
+			Count:   parseIntFlag(c, chunksFlag),
+			MinSize: chunkMinSize,
+		},
+		Schedule: parseStrFlag(c, scheduleFlag),
+	}, nil
+}
+
 func stopDownloadHandler(c *cli.Context) (err error) {
 	id := c.Args().First()
 
@@ -312,7 +312,26 @@ func downloadJobsList(c *cli.Context, regex string) error {
 	if err != nil {
 		return err
 	}
-	return templates.DisplayOutput(list, c.App.Writer, templates.DownloadListTmpl)
+	jobs, schedules := make(downloader.DlJobInfos, 0, len(list)), make(downloader.DlJobInfos, 0)
+	for _, dl := range list {
+		if dl.Schedule != nil {
+			schedules = append(schedules, dl)
+		} else {
+			jobs = append(jobs, dl)
+		}
+	}
+	if len(jobs) > 0 || len(schedules) == 0 {
+		if err := templates.DisplayOutput(jobs, c.App.Writer, templates.DownloadListTmpl); err != nil {
+			return err
+		}
+	}
+	if len(schedules) == 0 {
+		return nil
+	}
+	if len(jobs) > 0 {
+		fmt.Fprintln(c.App.Writer)
+	}
+	return templates.DisplayOutput(schedules, c.App.Writer, templates.DownloadScheduleListTmpl)
 }
 
 func downloadJobStatus(c *cli.Context, id string) error {
@@ -23,6 +23,7 @@ import (
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmd/cli/templates"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/query"
 	"github.com/urfave/cli"
 	"github.com/vbauerster/mpb/v4"
 	"github.com/vbauerster/mpb/v4/decor"
@@ -821,6 +822,78 @@ func rangeOp(c *cli.Context, command string, bck cmn.Bck) (err error) {
 	return
 }
 
+func queryFlagsSet(c *cli.Context) bool {
+	for _, flag := range baseQueryFlags {
+		if flagIsSet(c, flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Query handler
+func queryOp(c *cli.Context, command string, bck cmn.Bck) (err error) {
+	var (
+		filters  []*query.FilterMsg
+		xactID   string
+		queryMsg = &query.DefMsg{}
+	)
+	if flagIsSet(c, listFlag) || flagIsSet(c, templateFlag) {
+		return incorrectUsageMsg(c, "flags %q and %q cannot be used with query flags", listFlag.Name, templateFlag.Name)
+	}
+	queryMsg.OuterSelect.Prefix = parseStrFlag(c, prefixFlag)
+	if flagIsSet(c, queryExtFlag) {
+		filters = append(filters, query.NewFilter(query.ExtF, []string{parseStrFlag(c, queryExtFlag)}))
+	}
+	if flagIsSet(c, queryMinSizeFlag) {
+		size, err := parseByteFlagToInt(c, queryMinSizeFlag)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, query.SizeGEFilterMsg(size))
+	}
+	if flagIsSet(c, queryMaxSizeFlag) {
+		size, err := parseByteFlagToInt(c, queryMaxSizeFlag)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, query.SizeLEFilterMsg(size))
+	}
+	if flagIsSet(c, queryIdleFlag) {
+		filters = append(filters, query.ATimeBeforeFilterMsg(time.Now().Add(-c.Duration(queryIdleFlag.Name))))
+	}
+	switch len(filters) {
+	case 0:
+	case 1:
+		queryMsg.Where.Filter = filters[0]
+	default:
+		queryMsg.Where.Filter = query.NewAndFilter(filters...)
+	}
+
+	if flagIsSet(c, dryRunFlag) {
+		fmt.Fprintf(c.App.Writer, "%s %s/%s* matching %s\n",
+			strings.ToUpper(command), bck, queryMsg.OuterSelect.Prefix, cmn.MustMarshal(queryMsg.Where.Filter))
+		return
+	}
+
+	if err = ensureHasProvider(bck, command); err != nil {
+		return
+	}
+	switch command {
+	case commandPrefetch:
+		xactID, err = api.PrefetchQuery(defaultAPIParams, bck, queryMsg)
+	case commandEvict:
+		xactID, err = api.EvictQuery(defaultAPIParams, bck, queryMsg)
+	default:
+		return fmt.Errorf(invalidCmdMsg, command)
+	}
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "%sed objects matching the query from %q bucket, %s\n", command, bck, xactProgressMsg(xactID))
+	return
+}
+
 // Multiple object arguments handler
 func multiObjOp(c *cli.Context, command string) error {
 	// stops iterating if it encounters an error
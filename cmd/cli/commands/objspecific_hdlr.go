@@ -17,7 +17,7 @@ import (
 var (
 	objectSpecificCmdsFlags = map[string][]cli.Flag{
 		commandEvict: append(
-			baseLstRngFlags,
+			append(baseLstRngFlags, baseQueryFlags...),
 			dryRunFlag,
 		),
 		commandGet: {
@@ -144,8 +144,11 @@ func prefetchHandler(c *cli.Context) (err error) {
 	if flagIsSet(c, listFlag) || flagIsSet(c, templateFlag) {
 		return listOrRangeOp(c, commandPrefetch, bck)
 	}
+	if queryFlagsSet(c) {
+		return queryOp(c, commandPrefetch, bck)
+	}
 
-	return missingArgumentsError(c, "object list or range")
+	return missingArgumentsError(c, "object list, range, or query")
 }
 
 func evictHandler(c *cli.Context) (err error) {
@@ -183,6 +186,12 @@ func evictHandler(c *cli.Context) (err error) {
 			// list or range operation on a given bucket
 			return listOrRangeOp(c, commandEvict, bck)
 		}
+		if queryFlagsSet(c) {
+			if objName != "" {
+				return incorrectUsageMsg(c, "object name (%q) not supported when query flags provided", objName)
+			}
+			return queryOp(c, commandEvict, bck)
+		}
 
 		if objName == "" {
 			// operation on a given bucket
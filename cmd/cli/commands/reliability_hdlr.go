@@ -8,6 +8,7 @@ package commands
 import (
 	"fmt"
 
+	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/urfave/cli"
 )
@@ -21,6 +22,11 @@ var (
 			dataSlicesFlag,
 			paritySlicesFlag,
 		},
+		commandSyncBck: {
+			prefixFlag,
+			pushFlag,
+			dryRunFlag,
+		},
 	}
 
 	bucketSpecificCmds = []cli.Command{
@@ -40,6 +46,21 @@ var (
 			Action:       ecEncodeHandler,
 			BashComplete: bucketCompletions(),
 		},
+		{
+			Name:         commandFlush,
+			Usage:        "write back all dirty objects of a write-back bucket and wait for completion",
+			ArgsUsage:    bucketArgument,
+			Action:       flushHandler,
+			BashComplete: bucketCompletions(),
+		},
+		{
+			Name:         commandSyncBck,
+			Usage:        "sync cached objects of a remote bucket with the remote bucket",
+			ArgsUsage:    bucketArgument,
+			Flags:        bucketSpecificCmdsFlags[commandSyncBck],
+			Action:       syncBckHandler,
+			BashComplete: bucketCompletions(),
+		},
 	}
 )
 
@@ -91,3 +112,52 @@ func ecEncodeHandler(c *cli.Context) (err error) {
 
 	return ecEncode(c, bck, dataSlices, paritySlices)
 }
+
+func flushHandler(c *cli.Context) (err error) {
+	var (
+		bck cmn.Bck
+		p   *cmn.BucketProps
+	)
+	if bck, err = parseBckURI(c, c.Args().First()); err != nil {
+		return
+	}
+
+	if bck, p, err = validateBucket(c, bck, "", false); err != nil {
+		return
+	}
+	if !p.WriteBack.Enabled {
+		fmt.Fprintf(c.App.Writer, "Warning: write-back is disabled for bucket %q\n", bck)
+	}
+	if err = api.FlushBucket(defaultAPIParams, bck); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "Bucket %q flushed\n", bck)
+	return
+}
+
+func syncBckHandler(c *cli.Context) (err error) {
+	var (
+		bck cmn.Bck
+		p   *cmn.BucketProps
+	)
+	if bck, err = parseBckURI(c, c.Args().First()); err != nil {
+		return
+	}
+	if bck, p, err = validateBucket(c, bck, "", false); err != nil {
+		return
+	}
+	if bck.IsAIS() && p.BackendBck.IsEmpty() {
+		return fmt.Errorf("cannot sync ais bucket %q (the operation applies to remote buckets only)", bck)
+	}
+	msg := &cmn.SyncBckMsg{
+		Prefix: parseStrFlag(c, prefixFlag),
+		Push:   flagIsSet(c, pushFlag),
+		DryRun: flagIsSet(c, dryRunFlag),
+	}
+	xactID, err := api.SyncBucket(defaultAPIParams, bck, msg)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(c.App.Writer, xactProgressMsg(xactID))
+	return
+}
@@ -18,6 +18,7 @@ var (
 		subcmdSetConfig: {},
 		subcmdSetProps: {
 			resetFlag,
+			remotePropsFlag,
 		},
 		subcmdSetPrimary: {},
 	}
@@ -83,6 +84,13 @@ func setPropsHandler(c *cli.Context) (err error) {
 		return
 	}
 
+	if flagIsSet(c, remotePropsFlag) {
+		if !bck.IsRemoteAIS() {
+			return fmt.Errorf("option %q applies only to remote AIS buckets (%q is not)", remotePropsFlag.Name, bck)
+		}
+		return setRemoteBucketProps(c, bck, updateProps)
+	}
+
 	newProps := origProps.Clone()
 	newProps.Apply(updateProps)
 	if newProps.Equal(origProps) { // Apply props and check for change
@@ -929,12 +929,21 @@ func bckPropList(props *cmn.BucketProps, verbose bool) (propList []prop, err err
 			{"checksum", props.Cksum.String()},
 			{"mirror", props.Mirror.String()},
 			{"ec", props.EC.String()},
+			{"encryption", props.Encryption.String()},
+			{"compression", props.Compression.String()},
+			{"write_back", props.WriteBack.String()},
+			{"replication", props.Replication.String()},
 			{"lru", props.LRU.String()},
+			{"lifecycle", props.Lifecycle.String()},
+			{"object_lock", props.ObjectLock.String()},
 			{"versioning", props.Versioning.String()},
 		}
 		if props.Extra.OrigURLBck != "" {
 			propList = append(propList, prop{Name: "original-url", Value: props.Extra.OrigURLBck})
 		}
+		if props.Extra.Endpoint != "" {
+			propList = append(propList, prop{Name: "endpoint", Value: props.Extra.Endpoint})
+		}
 	} else {
 		err = cmn.IterFields(props, func(uniqueTag string, field cmn.IterField) (err error, b bool) {
 			value := fmt.Sprintf("%v", field.Value())
@@ -117,7 +117,8 @@ const (
 		" Control Plane Operation:\t{{$obj.CplaneOperationStr}}\n" +
 		" Max Host Busy:\t{{$obj.MaxHostBusyStr}}\n" +
 		" Send File Time:\t{{$obj.SendFileStr}}\n" +
-		" Startup Time:\t{{$obj.StartupStr}}\n"
+		" Startup Time:\t{{$obj.StartupStr}}\n" +
+		" Multipart Upload Time:\t{{$obj.MptUploadStr}}\n"
 	ClientConfTmpl = "\n{{$obj := .Client}}Client Config\n" +
 		" Timeout:\t{{$obj.TimeoutStr}}\n" +
 		" Long Timeout:\t{{$obj.TimeoutLongStr}}\n" +
@@ -154,7 +155,9 @@ const (
 		" Enable For Read Range:\t{{$obj.EnableReadRange}}\n"
 	VerConfTmpl = "\n{{$obj := .Versioning}}Version Config\n" +
 		" Enabled:\t{{$obj.Enabled}}\n" +
-		" Validate Warm Get:\t{{$obj.ValidateWarmGet}}\n"
+		" Validate Warm Get:\t{{$obj.ValidateWarmGet}}\n" +
+		" Keep Previous:\t{{$obj.KeepPrevious}}\n" +
+		" Max Versions:\t{{$obj.MaxVersions}}\n"
 	FSpathsConfTmpl = "\nFile System Paths Config\n" +
 		"{{$obj := .FSpaths.Paths}}" +
 		"{{range $key, $val := $obj}}" +
@@ -240,6 +243,12 @@ const (
 		"{{end}}\t {{$value.ErrorCnt}}\t {{$value.Description}}\n"
 	DownloadListTmpl = DownloadListHeader + "{{ range $key, $value := . }}" + DownloadListBody + "{{end}}"
 
+	DownloadScheduleListHeader = "SCHEDULE ID\t SCHEDULE\t NEXT RUN\t RUNS\t LAST RUN\t LAST OUTCOME\t DESCRIPTION\n"
+	DownloadScheduleListBody   = "{{$value.ID}}\t {{$value.Schedule.Schedule}}\t {{FormatTime $value.Schedule.NextRun}}\t " +
+		"{{$value.Schedule.Runs}}\t {{if (IsUnsetTime $value.Schedule.LastRun)}}-{{else}}{{FormatTime $value.Schedule.LastRun}}{{end}}\t " +
+		"{{$value.Schedule.LastOutcome}}\t {{$value.Description}}\n"
+	DownloadScheduleListTmpl = DownloadScheduleListHeader + "{{ range $key, $value := . }}" + DownloadScheduleListBody + "{{end}}"
+
 	DSortListHeader = "JOB ID\t STATUS\t START\t FINISH\t DESCRIPTION\n"
 	DSortListBody   = "{{$value.ID}}\t " +
 		"{{if $value.Aborted}}Aborted" +
@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/NVIDIA/aistore/cmn/debug"
+	jsoniter "github.com/json-iterator/go"
 )
 
 // SelectMsg extended flags
@@ -18,6 +20,7 @@ const (
 	SelectCached    = 1 << iota // list only cached (Cloud buckets only)
 	SelectMisplaced             // Include misplaced
 	SelectDeleted               // Include marked for deletion
+	SelectVersions              // Include previous versions and delete markers (ais buckets only)
 )
 
 // ActionMsg is a JSON-formatted control structures for the REST API
@@ -51,6 +54,7 @@ type (
 		PageSize          uint   `json:"pagesize"`           // max entries returned by list objects call
 		StartAfter        string `json:"start_after"`        // start listing after (AIS buckets only)
 		ContinuationToken string `json:"continuation_token"` // `BucketList.ContinuationToken`
+		Delimiter         string `json:"delimiter"`          // roll up names containing delimiter (after prefix) into "directories"
 		Flags             uint64 `json:"flags,string"`       // advanced filtering (SelectMsg extended flags)
 		UseCache          bool   `json:"use_cache"`          // use proxy cache to speed up listing objects
 	}
@@ -94,6 +98,14 @@ type (
 		DryRun bool   `json:"dry_run"` // Don't perform any PUT
 	}
 
+	// SyncBckMsg contains options of the `sync-bucket` xaction that reconciles
+	// locally cached objects of a remote bucket with the remote bucket itself
+	SyncBckMsg struct {
+		Prefix string `json:"prefix"`  // sync only the objects with names starting with prefix
+		Push   bool   `json:"push"`    // upload local-only objects instead of evicting them
+		DryRun bool   `json:"dry_run"` // only count the objects to fetch, evict, and upload
+	}
+
 	Bck2BckMsg struct {
 		BckTo Bck `json:"bck_to"`
 
@@ -144,17 +156,29 @@ type (
 		// EC defines erasure coding setting for the bucket
 		EC ECConf `json:"ec"`
 
+		// Lifecycle defines object expiration and eviction rules for the bucket
+		Lifecycle LifecycleConf `json:"lifecycle"`
+
+		// ObjectLock defines WORM protection of the objects (ais buckets only)
+		ObjectLock ObjectLockConf `json:"object_lock"`
+
+		// Encryption defines server-side encryption of the objects at rest
+		Encryption EncryptionConf `json:"encryption"`
+
+		// Compression defines on-disk compression of the objects
+		Compression ObjCompressionConf `json:"compression"`
+
+		// WriteBack defines asynchronous (write-back) PUT into remote buckets
+		WriteBack WriteBackConf `json:"write_back"`
+
+		// Replication defines asynchronous replication into a remote AIS cluster
+		Replication RemoteReplConf `json:"replication"`
+
 		// Bucket access attributes - see Allow* above
 		Access AccessAttrs `json:"access,string"`
 
 		// Extra contains additional information which can depend on the provider.
-		Extra struct {
-			// [HTTP provider] Original URL prior to hashing.
-			OrigURLBck string `json:"original_url,omitempty" list:"readonly"`
-
-			// [AWS provider] Region where the cloud bucket is located.
-			CloudRegion string `json:"cloud_region,omitempty" list:"readonly"`
-		} `json:"extra,omitempty" list:"readonly"`
+		Extra ExtraProps `json:"extra,omitempty" list:"readonly"`
 
 		// unique bucket ID
 		BID uint64 `json:"bid,string" list:"omit"`
@@ -165,14 +189,41 @@ type (
 		// non-empty when the bucket has been renamed (TODO: delayed deletion likewise)
 		Renamed string `list:"omit"`
 	}
+	ExtraProps struct {
+		// [HTTP provider] Original URL prior to hashing.
+		OrigURLBck string `json:"original_url,omitempty" list:"readonly"`
+
+		// [AWS provider] Region where the cloud bucket is located.
+		CloudRegion string `json:"cloud_region,omitempty" list:"readonly"`
+
+		// [AWS provider] Endpoint of S3-compatible storage (e.g., MinIO or Ceph RGW),
+		// path-style addressing, and named credentials profile - override the
+		// defaults from `cloud.conf.aws` (see `CloudConfAWS`).
+		Endpoint       string `json:"endpoint,omitempty"`
+		ForcePathStyle bool   `json:"force_path_style,omitempty"`
+		Profile        string `json:"profile,omitempty"`
+	}
+
 	BucketPropsToUpdate struct {
-		BackendBck *BckToUpdate         `json:"backend_bck"`
-		Versioning *VersionConfToUpdate `json:"versioning"`
-		Cksum      *CksumConfToUpdate   `json:"checksum"`
-		LRU        *LRUConfToUpdate     `json:"lru"`
-		Mirror     *MirrorConfToUpdate  `json:"mirror"`
-		EC         *ECConfToUpdate      `json:"ec"`
-		Access     *AccessAttrs         `json:"access,string"`
+		BackendBck  *BckToUpdate                `json:"backend_bck"`
+		Versioning  *VersionConfToUpdate        `json:"versioning"`
+		Cksum       *CksumConfToUpdate          `json:"checksum"`
+		LRU         *LRUConfToUpdate            `json:"lru"`
+		Mirror      *MirrorConfToUpdate         `json:"mirror"`
+		EC          *ECConfToUpdate             `json:"ec"`
+		Lifecycle   *LifecycleConfToUpdate      `json:"lifecycle"`
+		ObjectLock  *ObjectLockConfToUpdate     `json:"object_lock"`
+		Encryption  *EncryptionConfToUpdate     `json:"encryption"`
+		Compression *ObjCompressionConfToUpdate `json:"compression"`
+		WriteBack   *WriteBackConfToUpdate      `json:"write_back"`
+		Replication *RemoteReplConfToUpdate     `json:"replication"`
+		Access      *AccessAttrs                `json:"access,string"`
+		Extra       *ExtraPropsToUpdate         `json:"extra"`
+	}
+	ExtraPropsToUpdate struct {
+		Endpoint       *string `json:"endpoint"`
+		ForcePathStyle *bool   `json:"force_path_style"`
+		Profile        *string `json:"profile"`
 	}
 	BckToUpdate struct {
 		Name     *string `json:"name"`
@@ -180,6 +231,130 @@ type (
 	}
 )
 
+// bucket lifecycle
+type (
+	// LifecycleConf is a set of rules that a target periodically applies
+	// to the objects of the bucket (see `lifecycle` package). An object is
+	// subject to a rule if its name starts with the rule's prefix; when
+	// several rules match the same object the shortest period wins.
+	LifecycleConf struct {
+		Rules   LifecycleRules `json:"rules"`
+		Enabled bool           `json:"enabled"`
+	}
+	LifecycleConfToUpdate struct {
+		Rules   *LifecycleRules `json:"rules"`
+		Enabled *bool           `json:"enabled"`
+	}
+	LifecycleRules []LifecycleRule
+	LifecycleRule  struct {
+		ID     string `json:"id,omitempty"`
+		Prefix string `json:"prefix,omitempty"`
+		// ExpireDays: remove objects that were last modified more than
+		// ExpireDays ago (from the Cloud as well, if the bucket is remote)
+		ExpireDays int `json:"expire_days,omitempty"`
+		// NoncurrentDays: remove non-current versions of objects
+		// NoncurrentDays after they have become non-current
+		NoncurrentDays int `json:"noncurrent_days,omitempty"`
+		// EvictDays: evict cached copies of remote objects that were not
+		// accessed for EvictDays (the objects remain in the Cloud);
+		// ignored for ais buckets that have no backend
+		EvictDays int  `json:"evict_days,omitempty"`
+		Disabled  bool `json:"disabled,omitempty"`
+	}
+)
+
+// object lock
+type (
+	// ObjectLockConf enables WORM (write once, read many) protection of the
+	// objects: an object cannot be overwritten, deleted, renamed, or evicted
+	// while it is retained or under legal hold (see cluster.LOM.CheckRetention).
+	// Retention is set on a per-object basis; in addition, new objects are
+	// retained for `Days` in a given `Mode` by default. Once enabled,
+	// object lock cannot be disabled.
+	ObjectLockConf struct {
+		Mode    string `json:"mode"` // default retention mode: LockModeGovernance or LockModeCompliance
+		Days    int    `json:"days"` // default retention period (0 - no default retention)
+		Enabled bool   `json:"enabled"`
+	}
+	ObjectLockConfToUpdate struct {
+		Mode    *string `json:"mode"`
+		Days    *int    `json:"days"`
+		Enabled *bool   `json:"enabled"`
+	}
+)
+
+// server-side encryption
+type (
+	// EncryptionConf enables server-side encryption of the objects at rest
+	// (see package sse): new objects get encrypted with a data key that, in
+	// turn, is encrypted with the master key `KeyID` of the configured KMS
+	// (see KMSConf). Empty KeyID means the KMS default key. Disabling
+	// encryption does not affect the objects that are already encrypted.
+	EncryptionConf struct {
+		KeyID   string `json:"key_id"`
+		Enabled bool   `json:"enabled"`
+	}
+	EncryptionConfToUpdate struct {
+		KeyID   *string `json:"key_id"`
+		Enabled *bool   `json:"enabled"`
+	}
+)
+
+// on-disk compression
+type (
+	// ObjCompressionConf enables on-disk compression of the objects (see
+	// package compress): new objects get compressed with a given algorithm
+	// unless their content does not shrink. The size and checksum of the
+	// uncompressed content are kept in the object's metadata. Disabling
+	// compression does not affect the objects that are already compressed.
+	ObjCompressionConf struct {
+		Algorithm string `json:"algorithm"` // LZ4Compression or ZstdCompression
+		Enabled   bool   `json:"enabled"`
+	}
+	ObjCompressionConfToUpdate struct {
+		Algorithm *string `json:"algorithm"`
+		Enabled   *bool   `json:"enabled"`
+	}
+)
+
+// write-back
+type (
+	// WriteBackConf enables write-back mode for remote buckets: PUT stores
+	// the object locally and acknowledges it right away, while the per-target
+	// `write-back` xaction uploads the (dirty) object to the remote bucket
+	// in the background (see package writeback). Once the total size of the
+	// dirty objects reaches MaxDirty, PUTs fall back to synchronous upload.
+	WriteBackConf struct {
+		MaxDirty int64 `json:"max_dirty"` // per target, in bytes (0 - no limit)
+		Retries  int   `json:"retries"`   // number of times to retry failed upload
+		Enabled  bool  `json:"enabled"`
+	}
+	WriteBackConfToUpdate struct {
+		MaxDirty *int64 `json:"max_dirty"`
+		Retries  *int   `json:"retries"`
+		Enabled  *bool  `json:"enabled"`
+	}
+)
+
+// cross-cluster replication
+type (
+	// RemoteReplConf enables asynchronous replication of the bucket into a
+	// bucket in the attached remote AIS cluster (see CloudConfAIS): each PUT
+	// and DELETE gets recorded in the target's persistent replication queue
+	// and then replayed against the destination by the per-target `replicate`
+	// xaction (see package replication).
+	RemoteReplConf struct {
+		Alias   string `json:"alias"`  // alias (or UUID) of the remote cluster
+		Bucket  string `json:"bucket"` // destination bucket in the remote cluster
+		Enabled bool   `json:"enabled"`
+	}
+	RemoteReplConfToUpdate struct {
+		Alias   *string `json:"alias"`
+		Bucket  *string `json:"bucket"`
+		Enabled *bool   `json:"enabled"`
+	}
+)
+
 // object properties
 type (
 	ObjectProps struct {
@@ -194,6 +369,16 @@ type (
 		ParitySlices int              `list:"omit"`
 		IsECCopy     bool             `list:"omit"`
 		Present      bool             `json:"present"`
+		// object lock (see ObjectLockConf)
+		RetentionMode string `json:"retention_mode"`
+		RetainUntil   int64  `json:"retain_until"` // unix nanoseconds
+		LegalHold     bool   `json:"legal_hold"`
+		// server-side encryption (see EncryptionConf)
+		Encrypted bool `json:"encrypted"`
+		// on-disk compression (see ObjCompressionConf)
+		Compressed bool `json:"compressed"`
+		// not yet written back to the remote bucket (see WriteBackConf)
+		Dirty bool `json:"dirty"`
 	}
 	ObjectCksumProps struct {
 		Type  string `json:"type"`
@@ -322,6 +507,14 @@ func (c *VersionConf) String() string {
 	} else {
 		text += "no"
 	}
+	if c.KeepPrevious {
+		text += " | Keep previous: "
+		if c.MaxVersions == 0 {
+			text += "all"
+		} else {
+			text += strconv.Itoa(c.MaxVersions)
+		}
+	}
 
 	return text
 }
@@ -358,6 +551,73 @@ func (c *LRUConf) String() string {
 		c.LowWM, c.HighWM, c.DontEvictTimeStr, c.OOS)
 }
 
+func (c *LifecycleConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return fmt.Sprintf("%d rule(s)", len(c.Rules))
+}
+
+func (c *ObjectLockConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	if c.Days == 0 {
+		return "Enabled | Default retention: none"
+	}
+	return fmt.Sprintf("Enabled | Default retention: %s, %d day(s)", c.Mode, c.Days)
+}
+
+func (c *EncryptionConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	if c.KeyID == "" {
+		return "Enabled | Key: default"
+	}
+	return "Enabled | Key: " + c.KeyID
+}
+
+func (c *ObjCompressionConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return "Enabled | Algorithm: " + c.Algorithm
+}
+
+func (c *WriteBackConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	maxDirty := "unlimited"
+	if c.MaxDirty > 0 {
+		maxDirty = B2S(c.MaxDirty, 0)
+	}
+	return fmt.Sprintf("Enabled | Max dirty: %s | Retries: %d", maxDirty, c.Retries)
+}
+
+func (c *RemoteReplConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return "Enabled | Destination: " + c.DstBck().String()
+}
+
+// DstBck returns the destination (remote AIS) bucket.
+func (c *RemoteReplConf) DstBck() Bck {
+	return Bck{Name: c.Bucket, Provider: ProviderAIS, Ns: Ns{UUID: c.Alias}}
+}
+
+// NOTE: used to pass the rules via HTTP headers and `IterFields`
+func (rules LifecycleRules) String() string {
+	if len(rules) == 0 {
+		return ""
+	}
+	b, err := jsoniter.Marshal(rules)
+	AssertNoErr(err)
+	return string(b)
+}
+
 func (c *MirrorConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
@@ -390,9 +650,7 @@ func (c *ECConf) RequiredRestoreTargets() int {
 	return c.DataSlices
 }
 
-//
 // by default, bucket props inherit global config
-//
 func DefaultAISBckProps() *BucketProps {
 	c := GCO.Clone()
 	if c.Cksum.Type == "" {
@@ -477,8 +735,18 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 		}
 	}
 
+	if bp.Extra.Endpoint != "" || bp.Extra.ForcePathStyle || bp.Extra.Profile != "" {
+		if bp.Provider != ProviderAmazon && bp.BackendBck.Provider != ProviderAmazon {
+			return fmt.Errorf("S3 endpoint, addressing, and profile can only be set for %q buckets", ProviderAmazon)
+		}
+		if err := ValidateEndpoint(bp.Extra.Endpoint); err != nil {
+			return err
+		}
+	}
+
 	validationArgs := &ValidationArgs{TargetCnt: targetCnt}
-	validators := []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC}
+	validators := []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC, &bp.Lifecycle, &bp.ObjectLock,
+		&bp.Compression, &bp.WriteBack, &bp.Replication}
 	for _, validator := range validators {
 		if err := validator.ValidateAsProps(validationArgs); err != nil {
 			return err
@@ -36,12 +36,14 @@ const (
 	ActRebalance      = "rebalance"
 	ActResilver       = "resilver"
 	ActLRU            = "lru"
+	ActLifecycle      = "lifecycle"
 	ActSyncLB         = "synclb"
 	ActCreateLB       = "createlb"
 	ActDestroyLB      = "destroylb"
 	ActRenameLB       = "renamelb"
 	ActCopyBucket     = "copybck"
 	ActETLBucket      = "etlbck"
+	ActSyncBucket     = "sync-bucket" // reconcile cached remote bucket with the remote (see SyncBckMsg)
 	ActRegisterCB     = "registercb"
 	ActEvictCB        = "evictcb"
 	ActSetConfig      = "setconfig"
@@ -68,10 +70,14 @@ const (
 	ActPutCopies      = "putcopies"
 	ActMakeNCopies    = "makencopies"
 	ActLoadLomCache   = "loadlomcache"
-	ActECGet          = "ecget"    // erasure decode objects
-	ActECPut          = "ecput"    // erasure encode objects
-	ActECRespond      = "ecresp"   // respond to other targets' EC requests
-	ActECEncode       = "ecencode" // erasure code a bucket
+	ActWriteBack      = "writeback"    // upload dirty objects to remote bucket (see WriteBackConf)
+	ActFlush          = "flush"        // write back all dirty objects and wait for completion
+	ActReplicate      = "replicate"    // replay PUTs and DELETEs in remote cluster (see RemoteReplConf)
+	ActReplCatchup    = "repl-catchup" // queue objects written while replication was disabled (or down)
+	ActECGet          = "ecget"        // erasure decode objects
+	ActECPut          = "ecput"        // erasure encode objects
+	ActECRespond      = "ecresp"       // respond to other targets' EC requests
+	ActECEncode       = "ecencode"     // erasure code a bucket
 	ActStartGFN       = "metasync-start-gfn"
 	ActRecoverBck     = "recoverbck"
 	ActAttach         = "attach"
@@ -139,6 +145,8 @@ const (
 	HeaderObjSize      = "size"           // Object size (bytes)
 	HeaderObjVersion   = "version"        // Object version/generation - ais or Cloud
 	HeaderObjECMeta    = "ec_meta"        // Info about EC object/slice/replica
+	HeaderObjSSE       = "sse"            // Encryption metadata of encrypted object (intra-cluster)
+	HeaderObjCompress  = "compression"    // Compression metadata of compressed object (intra-cluster)
 
 	// intra-cluster: control
 	HeaderCallerID          = "caller.id" // it is a marker of intra-cluster request (see cmn.IsInternalReq)
@@ -161,7 +169,19 @@ const (
 
 // supported compressions (alg-s)
 const (
-	LZ4Compression = "lz4"
+	LZ4Compression  = "lz4"
+	ZstdCompression = "zstd" // (on-disk compression only - see ObjCompressionConf)
+)
+
+// object retention modes (see ObjectLockConf)
+const (
+	LockModeGovernance = "governance" // can be bypassed (see URLParamBypassGovernance)
+	LockModeCompliance = "compliance" // cannot be bypassed, shortened, or removed
+)
+
+// KMS providers (see KMSConf)
+const (
+	KMSProviderLocal = "local" // master keys are loaded from a local key file
 )
 
 // URL Query "?name1=val1&name2=..."
@@ -172,8 +192,16 @@ const (
 	URLParamCheckExists = "check_cached" // true: check if object exists
 	URLParamProvider    = "provider"     // cloud provider
 	URLParamNamespace   = "namespace"
-	URLParamPrefix      = "prefix" // prefix for list objects in a bucket
-	URLParamRegex       = "regex"  // dsort/downloader regex
+	URLParamPrefix      = "prefix"  // prefix for list objects in a bucket
+	URLParamRegex       = "regex"   // dsort/downloader regex
+	URLParamVersion     = "version" // object version to GET or DELETE (ais buckets that keep previous versions)
+
+	URLParamBypassGovernance = "bypass_governance" // true: DELETE or rename object retained in governance mode
+
+	// remote AIS clusters
+	URLParamRemoteToken = "remote_token" // AuthN token to access remote AIS cluster that is being attached
+	URLParamRemoteProps = "remote_props" // true: set props of the remote AIS bucket (rather than its local counterpart)
+
 	// internal use
 	URLParamCheckExistsAny   = "cea" // true: lookup object in all mountpaths (NOTE: compare with URLParamCheckExists)
 	URLParamProxyID          = "pid" // ID of the redirecting proxy
@@ -273,6 +301,9 @@ const (
 	EntryStatusBits = 5                          // N bits
 	EntryStatusMask = (1 << EntryStatusBits) - 1 // mask for N low bits
 	EntryIsCached   = 1 << (EntryStatusBits + 1) // StatusMaskBits + 1
+	EntryIsDir      = 1 << (EntryStatusBits + 2) // common prefix of the names rolled up by SelectMsg.Delimiter
+	EntryIsVersion  = 1 << (EntryStatusBits + 3) // previous (noncurrent) version of an object, see SelectVersions
+	EntryDelMarker  = 1 << (EntryStatusBits + 4) // delete marker, always comes with EntryIsVersion
 )
 
 // List objects default page size
@@ -306,6 +337,7 @@ const (
 	Users     = "users"    // AuthN
 	Clusters  = "clusters" // AuthN
 	Roles     = "roles"    // AuthN
+	S3Keys    = "s3keys"   // AuthN
 	Query     = "query"
 	IC        = "ic" // information center
 
@@ -5,6 +5,9 @@
 package cmn
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
@@ -59,10 +62,16 @@ type (
 		Clusters []*AuthCluster `json:"clusters"`
 		Buckets  []*AuthBucket  `json:"buckets,omitempty"`
 		IsAdmin  bool           `json:"admin"`
+		S3Key    bool           `json:"s3key,omitempty"` // the token is S3 access key (see S3SecretKey)
 	}
 	AuthClusterList struct {
 		Clusters map[string]*AuthCluster `json:"clusters,omitempty"`
 	}
+	// S3 credentials issued by AuthN
+	AuthS3Key struct {
+		AccessKey string `json:"access_key"`
+		SecretKey string `json:"secret_key"`
+	}
 )
 
 var (
@@ -181,6 +190,17 @@ func DecryptToken(tokenStr, secret string) (*AuthToken, error) {
 	return tInfo, nil
 }
 
+// S3SecretKey returns S3 secret access key for the access key issued by AuthN.
+// The access key is a token that is valid only for S3 requests signed with
+// AWS Signature V4, and the secret key is derived from the access key and
+// the secret shared by AuthN and the cluster: neither of them needs to keep
+// S3 credentials.
+func S3SecretKey(accessKey, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(accessKey))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 // Returns true if given `token` includes given object name.
 // `token` includes an object name iff the object name would
 // be included in response having given continuation token.
@@ -19,8 +19,10 @@ const (
 	ProviderGoogle = "gcp"
 	ProviderAIS    = "ais"
 	ProviderAzure  = "azure"
+	ProviderHDFS   = "hdfs"
+	ProviderPOSIX  = "posix"
 	ProviderHTTP   = "ht"
-	allProviders   = "aws, gcp, ais, azure, ht"
+	allProviders   = "aws, gcp, ais, azure, hdfs, posix, ht"
 
 	NsUUIDPrefix = '@' // BEWARE: used by on-disk layout
 	NsNamePrefix = '#' // BEWARE: used by on-disk layout
@@ -90,6 +92,8 @@ var (
 		ProviderGoogle,
 		ProviderAmazon,
 		ProviderAzure,
+		ProviderHDFS,
+		ProviderPOSIX,
 		ProviderHTTP,
 	)
 )
@@ -14,6 +14,7 @@ package cmn
 // 0-2: objects status, all statuses are mutually exclusive, so it can hold up
 //      to 8 different statuses. Now only OK=0, Moved=1, Deleted=2 are supported
 // 3:   CheckExists (for cloud bucket it shows if the object in local cache)
+// 4:   IsDir (the entry is a common prefix of the objects - see SelectMsg.Delimiter)
 type BucketEntry struct {
 	Name      string `json:"name" msg:"n"`                            // name of the object - NOTE: Does not include the bucket name.
 	Size      int64  `json:"size,string,omitempty" msg:"s,omitempty"` // size in bytes
@@ -33,6 +34,18 @@ func (be *BucketEntry) SetExists() {
 	be.Flags |= EntryIsCached
 }
 
+func (be *BucketEntry) IsDir() bool {
+	return be.Flags&EntryIsDir != 0
+}
+
+func (be *BucketEntry) IsVersion() bool {
+	return be.Flags&EntryIsVersion != 0
+}
+
+func (be *BucketEntry) IsDelMarker() bool {
+	return be.Flags&EntryDelMarker != 0
+}
+
 func (be *BucketEntry) IsStatusOK() bool {
 	return be.Flags&EntryStatusMask == 0
 }
@@ -40,7 +53,7 @@ func (be *BucketEntry) IsStatusOK() bool {
 func (be *BucketEntry) String() string { return "{" + be.Name + "}" }
 
 func (be *BucketEntry) CopyWithProps(propsSet StringSet) (ne *BucketEntry) {
-	ne = &BucketEntry{Name: be.Name}
+	ne = &BucketEntry{Name: be.Name, Flags: be.Flags & (EntryIsDir | EntryIsVersion | EntryDelMarker)}
 	if propsSet.Contains(GetPropsSize) {
 		ne.Size = be.Size
 	}
@@ -43,6 +43,12 @@ const (
 	ExtTarTgz = ".tar.gz"
 	// ExtZip is zip files extension
 	ExtZip = ".zip"
+	// ExtTarZst is tar zstd files extension
+	ExtTarZst = ".tar.zst"
+	// ExtTarLz4 is tar lz4 files extension
+	ExtTarLz4 = ".tar.lz4"
+	// ExtMsgpack is msgpack (WebDataset-style) files extension
+	ExtMsgpack = ".msgpack"
 
 	// misc
 	SizeofI64 = int(unsafe.Sizeof(uint64(0)))
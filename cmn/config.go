@@ -9,6 +9,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -34,6 +35,9 @@ const (
 	// EC
 	MinSliceCount = 1  // minimum number of data or parity slices
 	MaxSliceCount = 32 // maximum number of data or parity slices
+
+	// bucket lifecycle
+	MaxLifecycleRules = 1000 // same as S3
 )
 
 const (
@@ -145,6 +149,7 @@ type (
 		Downloader       DownloaderConf  `json:"downloader"`
 		DSort            DSortConf       `json:"distributed_sort"`
 		Compression      CompressionConf `json:"compression"`
+		KMS              KMSConf         `json:"kms"`
 	}
 	CloudConf struct {
 		Conf map[string]interface{} `json:"conf,omitempty"` // implementation depends on cloud provider
@@ -164,6 +169,29 @@ type (
 	CloudConfAIS map[string][]string // cluster alias -> [urls...]
 	CloudInfoAIS map[string]*RemoteAISInfo
 
+	// CloudConfAWS is the default configuration of all "aws" buckets; it allows
+	// to use any S3-compatible storage (e.g., MinIO or Ceph RGW) instead of AWS.
+	// Can be overridden per bucket - see `BucketProps.Extra`.
+	CloudConfAWS struct {
+		Endpoint       string `json:"endpoint,omitempty"`         // e.g. "http://minio:9000" (empty - AWS)
+		ForcePathStyle bool   `json:"force_path_style,omitempty"` // http://endpoint/bucket/object
+		Profile        string `json:"profile,omitempty"`          // named profile in ~/.aws/credentials
+	}
+
+	// CloudConfHDFS configures access to HDFS via WebHDFS REST API: buckets
+	// are the directories under the root, objects - the files in those directories.
+	CloudConfHDFS struct {
+		NameNodes []string `json:"namenodes"`      // e.g. "http://nn1:9870" (more than one - HA)
+		User      string   `json:"user,omitempty"` // `user.name` (simple authentication)
+		Root      string   `json:"root,omitempty"` // default: "/"
+	}
+
+	// CloudConfPOSIX maps buckets to the directories under the root - a shared
+	// filesystem (e.g., NFS or Lustre) mounted at the same path on all targets.
+	CloudConfPOSIX struct {
+		Root string `json:"root"`
+	}
+
 	MirrorConf struct {
 		Copies      int64 `json:"copies"`       // num local copies
 		Burst       int   `json:"burst_buffer"` // channel buffer size
@@ -220,6 +248,8 @@ type (
 		Startup            time.Duration `json:"-"`
 		SendFileStr        string        `json:"send_file_time"`
 		SendFile           time.Duration `json:"-"`
+		MptUploadStr       string        `json:"mpt_upload_time"`
+		MptUpload          time.Duration `json:"-"`
 	}
 	ClientConf struct {
 		TimeoutStr     string        `json:"client_timeout"`
@@ -331,10 +361,20 @@ type (
 
 		// Validate object version upon warm GET.
 		ValidateWarmGet bool `json:"validate_warm_get"`
+
+		// Keep previous versions of objects when they get overwritten or
+		// deleted (ais buckets only).
+		KeepPrevious bool `json:"keep_previous"`
+
+		// Maximum number of previous versions (including delete markers)
+		// retained per object; zero means no limit.
+		MaxVersions int `json:"max_versions"`
 	}
 	VersionConfToUpdate struct {
 		Enabled         *bool `json:"enabled"`
 		ValidateWarmGet *bool `json:"validate_warm_get"`
+		KeepPrevious    *bool `json:"keep_previous"`
+		MaxVersions     *int  `json:"max_versions"`
 	}
 
 	TestfspathConf struct {
@@ -385,6 +425,8 @@ type (
 	AuthConf struct {
 		Secret  string `json:"secret"`
 		Enabled bool   `json:"enabled"`
+		// AuthN tokens to access remote AIS clusters, by alias (see CloudConfAIS)
+		RemoteTokens map[string]string `json:"remote_tokens,omitempty"`
 	}
 	// config for one keepalive tracker
 	// all type of trackers share the same struct, not all fields are used by all trackers
@@ -423,6 +465,13 @@ type (
 		BlockMaxSize int  `json:"block_size"` // *uncompressed* block max size
 		Checksum     bool `json:"checksum"`   // true: checksum lz4 frames
 	}
+	// KMSConf configures the key management service that provides master keys
+	// for server-side encryption (see EncryptionConf and package sse).
+	KMSConf struct {
+		Provider     string `json:"provider"`       // KMSProviderLocal, etc.; empty - no encryption
+		KeyFile      string `json:"key_file"`       // KMSProviderLocal: JSON file of key IDs => base64-encoded keys
+		DefaultKeyID string `json:"default_key_id"` // master key to use when none is specified
+	}
 )
 
 // interface guard
@@ -558,11 +607,17 @@ var (
 	_ Validator = (*FSPathsConf)(nil)
 	_ Validator = (*TestfspathConf)(nil)
 	_ Validator = (*CompressionConf)(nil)
+	_ Validator = (*KMSConf)(nil)
 
 	_ PropsValidator = (*CksumConf)(nil)
 	_ PropsValidator = (*LRUConf)(nil)
 	_ PropsValidator = (*MirrorConf)(nil)
 	_ PropsValidator = (*ECConf)(nil)
+	_ PropsValidator = (*LifecycleConf)(nil)
+	_ PropsValidator = (*ObjectLockConf)(nil)
+	_ PropsValidator = (*ObjCompressionConf)(nil)
+	_ PropsValidator = (*WriteBackConf)(nil)
+	_ PropsValidator = (*RemoteReplConf)(nil)
 
 	_ json.Marshaler   = (*CloudConf)(nil)
 	_ json.Unmarshaler = (*CloudConf)(nil)
@@ -622,6 +677,46 @@ func (c *CloudConf) Validate(_ *Config) (err error) {
 				break
 			}
 			c.Conf[provider] = aisConf
+		case ProviderAmazon:
+			var awsConf CloudConfAWS
+			if err := jsoniter.Unmarshal(b, &awsConf); err != nil {
+				return fmt.Errorf("invalid cloud specification: %v", err)
+			}
+			if err := ValidateEndpoint(awsConf.Endpoint); err != nil {
+				return fmt.Errorf("invalid %q cloud specification: %v", provider, err)
+			}
+			c.Conf[provider] = awsConf
+			c.setProvider(provider)
+		case ProviderHDFS:
+			var hdfsConf CloudConfHDFS
+			if err := jsoniter.Unmarshal(b, &hdfsConf); err != nil {
+				return fmt.Errorf("invalid cloud specification: %v", err)
+			}
+			if len(hdfsConf.NameNodes) == 0 {
+				return fmt.Errorf("no namenode URL(s) to connect to %q", provider)
+			}
+			for _, nn := range hdfsConf.NameNodes {
+				if err := ValidateEndpoint(nn); err != nil {
+					return fmt.Errorf("invalid %q cloud specification: %v", provider, err)
+				}
+			}
+			if hdfsConf.Root == "" {
+				hdfsConf.Root = "/"
+			} else if !strings.HasPrefix(hdfsConf.Root, "/") {
+				return fmt.Errorf("invalid %q cloud specification: root %q must be absolute", provider, hdfsConf.Root)
+			}
+			c.Conf[provider] = hdfsConf
+			c.setProvider(provider)
+		case ProviderPOSIX:
+			var posixConf CloudConfPOSIX
+			if err := jsoniter.Unmarshal(b, &posixConf); err != nil {
+				return fmt.Errorf("invalid cloud specification: %v", err)
+			}
+			if !filepath.IsAbs(posixConf.Root) {
+				return fmt.Errorf("invalid %q cloud specification: root %q must be absolute", provider, posixConf.Root)
+			}
+			c.Conf[provider] = posixConf
+			c.setProvider(provider)
 		case "":
 			continue
 		default:
@@ -634,7 +729,7 @@ func (c *CloudConf) Validate(_ *Config) (err error) {
 func (c *CloudConf) setProvider(provider string) {
 	var ns Ns
 	switch provider {
-	case ProviderAmazon, ProviderGoogle, ProviderAzure:
+	case ProviderAmazon, ProviderGoogle, ProviderAzure, ProviderHDFS, ProviderPOSIX:
 		ns = NsGlobal
 
 	default:
@@ -646,6 +741,22 @@ func (c *CloudConf) setProvider(provider string) {
 	c.Providers[provider] = ns
 }
 
+// ValidateEndpoint checks the endpoint of S3-compatible storage (empty - AWS)
+// or HDFS namenode.
+func ValidateEndpoint(endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %v", endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid endpoint %q: expecting http(s)://host[:port]", endpoint)
+	}
+	return nil
+}
+
 func (c *CloudConf) ProviderConf(provider string, newConf ...interface{}) (conf interface{}, ok bool) {
 	if len(newConf) > 0 {
 		c.Conf[provider] = newConf[0]
@@ -716,6 +827,12 @@ func (c *VersionConf) Validate(_ *Config) error {
 	if !c.Enabled && c.ValidateWarmGet {
 		return errors.New("versioning.validate_warm_get requires versioning to be enabled")
 	}
+	if !c.Enabled && c.KeepPrevious {
+		return errors.New("versioning.keep_previous requires versioning to be enabled")
+	}
+	if c.MaxVersions < 0 {
+		return fmt.Errorf("invalid versioning.max_versions: %d (expected non-negative value)", c.MaxVersions)
+	}
 	return nil
 }
 
@@ -779,6 +896,84 @@ func (c *ECConf) ValidateAsProps(args *ValidationArgs) error {
 	return nil
 }
 
+func (c *LifecycleConf) ValidateAsProps(_ *ValidationArgs) error {
+	if c.Enabled && len(c.Rules) == 0 {
+		return errors.New("lifecycle is enabled but no rules are defined")
+	}
+	if len(c.Rules) > MaxLifecycleRules {
+		return fmt.Errorf("number of lifecycle rules %d exceeds the limit %d", len(c.Rules), MaxLifecycleRules)
+	}
+	ids := make(StringSet, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.ExpireDays < 0 || rule.NoncurrentDays < 0 || rule.EvictDays < 0 {
+			return fmt.Errorf("invalid lifecycle rule %q: the number of days cannot be negative", rule.ID)
+		}
+		if rule.ExpireDays == 0 && rule.NoncurrentDays == 0 && rule.EvictDays == 0 {
+			return fmt.Errorf("invalid lifecycle rule %q: no action defined", rule.ID)
+		}
+		if rule.ID == "" {
+			continue
+		}
+		if ids.Contains(rule.ID) {
+			return fmt.Errorf("duplicate lifecycle rule ID %q", rule.ID)
+		}
+		ids.Add(rule.ID)
+	}
+	return nil
+}
+
+func (c *ObjectLockConf) ValidateAsProps(_ *ValidationArgs) error {
+	if c.Days < 0 {
+		return fmt.Errorf("invalid object_lock.days %d (expected non-negative)", c.Days)
+	}
+	switch c.Mode {
+	case LockModeGovernance, LockModeCompliance:
+	case "":
+		if c.Days > 0 {
+			return errors.New("object_lock.mode must be defined for default retention")
+		}
+	default:
+		return fmt.Errorf("invalid object_lock.mode %q (expected %q or %q)",
+			c.Mode, LockModeGovernance, LockModeCompliance)
+	}
+	return nil
+}
+
+func (c *ObjCompressionConf) ValidateAsProps(_ *ValidationArgs) error {
+	switch c.Algorithm {
+	case LZ4Compression, ZstdCompression:
+	case "":
+		if c.Enabled {
+			c.Algorithm = LZ4Compression
+		}
+	default:
+		return fmt.Errorf("invalid compression.algorithm %q (expected %q or %q)",
+			c.Algorithm, LZ4Compression, ZstdCompression)
+	}
+	return nil
+}
+
+func (c *WriteBackConf) ValidateAsProps(_ *ValidationArgs) error {
+	if c.MaxDirty < 0 {
+		return fmt.Errorf("invalid write_back.max_dirty %d (expected non-negative)", c.MaxDirty)
+	}
+	if c.Retries < 0 {
+		return fmt.Errorf("invalid write_back.retries %d (expected non-negative)", c.Retries)
+	}
+	return nil
+}
+
+func (c *RemoteReplConf) ValidateAsProps(_ *ValidationArgs) error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Alias == "" {
+		return errors.New("replication.alias (remote cluster) must be specified")
+	}
+	return ValidateBckName(c.Bucket)
+}
+
 func (c *TimeoutConf) Validate(_ *Config) (err error) {
 	if c.MaxKeepalive, err = time.ParseDuration(c.MaxKeepaliveStr); err != nil {
 		return fmt.Errorf("invalid timeout.max_keepalive format %s, err %v", c.MaxKeepaliveStr, err)
@@ -795,6 +990,12 @@ func (c *TimeoutConf) Validate(_ *Config) (err error) {
 	if c.MaxHostBusy, err = time.ParseDuration(c.MaxHostBusyStr); err != nil {
 		return fmt.Errorf("invalid timeout.max_host_busy format %s, err %v", c.MaxHostBusyStr, err)
 	}
+	if c.MptUpload, err = time.ParseDuration(c.MptUploadStr); err != nil {
+		return fmt.Errorf("invalid timeout.mpt_upload_time format %s, err %v", c.MptUploadStr, err)
+	}
+	if c.MptUpload <= 0 {
+		return fmt.Errorf("invalid timeout.mpt_upload_time %v (expected positive duration)", c.MptUpload)
+	}
 	return nil
 }
 
@@ -1042,6 +1243,19 @@ func (c *CompressionConf) Validate(_ *Config) (err error) {
 	return nil
 }
 
+func (c *KMSConf) Validate(_ *Config) (err error) {
+	if c.Provider == "" {
+		return nil
+	}
+	if c.DefaultKeyID == "" {
+		return fmt.Errorf("kms.default_key_id must be specified for %q KMS", c.Provider)
+	}
+	if c.Provider == KMSProviderLocal && c.KeyFile == "" {
+		return fmt.Errorf("kms.key_file must be specified for %q KMS", c.Provider)
+	}
+	return nil
+}
+
 func KeepaliveRetryDuration(cs ...*Config) time.Duration {
 	var c *Config
 	if len(cs) != 0 {
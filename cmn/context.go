@@ -21,4 +21,6 @@ const (
 	CtxReadWrapper contextID = "readWrapper" // context key for ReadWrapperFunc
 	CtxSetSize     contextID = "setSize"     // context key for SetSizeFunc
 	CtxOriginalURL contextID = "origURL"     // context key for OriginalURL for HTTP cloud
+
+	CtxBypassGovernance contextID = "bypassGovernance" // context key for bypassing governance-mode retention
 )
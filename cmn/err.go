@@ -81,6 +81,10 @@ type (
 	NotFoundError struct {
 		what string
 	}
+	ObjectLockedError struct {
+		name   string // object's name
+		reason string // retention or legal hold
+	}
 	ETLError struct {
 		Reason string
 		ETLErrorContext
@@ -349,6 +353,11 @@ func NewNotFoundError(format string, a ...interface{}) *NotFoundError {
 
 func (e *NotFoundError) Error() string { return e.what + " not found" }
 
+func NewObjectLockedError(name, reason string) *ObjectLockedError {
+	return &ObjectLockedError{name: name, reason: reason}
+}
+func (e *ObjectLockedError) Error() string { return "object " + e.name + " is locked: " + e.reason }
+
 func NewETLError(ctx *ETLErrorContext, format string, a ...interface{}) *ETLError {
 	e := &ETLError{
 		Reason: fmt.Sprintf(format, a...),
@@ -460,5 +469,9 @@ func IsObjNotExist(err error) bool {
 	_, ok := err.(*NotFoundError)
 	return ok
 }
+func IsErrObjLocked(err error) bool {
+	_, ok := err.(*ObjectLockedError)
+	return ok
+}
 func IsErrBucketLevel(err error) bool { return IsErrBucketNought(err) }
 func IsErrObjLevel(err error) bool    { return IsErrObjNought(err) }
@@ -31,11 +31,14 @@ const (
 	HeaderContentRange          = "Content-Range"
 	HeaderContentRangeValPrefix = "bytes " // Ref: https://tools.ietf.org/html/rfc7233#section-4.2
 	HeaderAcceptRanges          = "Accept-Ranges"
+	HeaderIfRange               = "If-Range" // Ref: https://tools.ietf.org/html/rfc7233#section-3.2
 	HeaderContentType           = "Content-Type"
 	HeaderContentLength         = "Content-Length"
+	HeaderContentEncoding       = "Content-Encoding"
 	HeaderAccept                = "Accept"
 	HeaderLocation              = "Location"
 	HeaderETag                  = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
+	HeaderLastModified          = "Last-Modified"
 )
 
 // Ref: https://www.iana.org/assignments/media-types/media-types.xhtml
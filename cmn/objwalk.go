@@ -6,6 +6,7 @@ package cmn
 
 import (
 	"sort"
+	"strings"
 )
 
 func SortBckEntries(bckEntries []*BucketEntry) {
@@ -137,3 +138,38 @@ func MergeObjLists(lists []*BucketList, maxSize uint) (objs *BucketList) {
 	bckList.ContinuationToken = contiunationToken
 	return bckList
 }
+
+// DirName returns the "directory" (aka S3 common prefix) that contains the
+// object when the names are rolled up by delimiter: the object name up to and
+// including the first occurrence of the delimiter after the prefix.
+// Returns false if the object is not rolled up.
+func DirName(objName, prefix, delim string) (string, bool) {
+	if delim == "" || !strings.HasPrefix(objName, prefix) {
+		return "", false
+	}
+	idx := strings.Index(objName[len(prefix):], delim)
+	if idx < 0 {
+		return "", false
+	}
+	return objName[:len(prefix)+idx+len(delim)], true
+}
+
+// RollUpBckEntries replaces the entries which names contain delimiter (after
+// the prefix) with the corresponding "directory" entries. Entries are sorted
+// and deduplicated. Used for the lists that were not rolled up by the source.
+func RollUpBckEntries(bckEntries []*BucketEntry, prefix, delim string) []*BucketEntry {
+	if delim == "" {
+		return bckEntries
+	}
+	for i, e := range bckEntries {
+		if e.IsDir() {
+			continue
+		}
+		if dir, ok := DirName(e.Name, prefix, delim); ok {
+			bckEntries[i] = &BucketEntry{Name: dir, Flags: EntryIsDir}
+		}
+	}
+	SortBckEntries(bckEntries)
+	bckEntries, _ = deduplicateBckEntries(bckEntries, 0)
+	return bckEntries
+}
@@ -190,6 +190,21 @@ check:
 	goto check
 }
 
+// TryAcquire is non-blocking Acquire: returns false if there are not enough vacant places.
+func (s *DynSemaphore) TryAcquire(cnts ...int) bool {
+	cnt := 1
+	if len(cnts) > 0 {
+		cnt = cnts[0]
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cur+cnt > s.size {
+		return false
+	}
+	s.cur += cnt
+	return true
+}
+
 func (s *DynSemaphore) Release(cnts ...int) {
 	cnt := 1
 	if len(cnts) > 0 {
@@ -146,6 +146,10 @@ var _ = Describe("API", func() {
 						ParitySlices: api.Int(1024),
 						Compression:  api.String("false"),
 					},
+					Lifecycle: &cmn.LifecycleConfToUpdate{
+						Rules:   &cmn.LifecycleRules{{ID: "tmp", Prefix: "tmp/", ExpireDays: 7}},
+						Enabled: api.Bool(true),
+					},
 					Access: api.AccessAttrs(1024),
 				},
 				cmn.BucketProps{
@@ -174,9 +178,59 @@ var _ = Describe("API", func() {
 						ParitySlices: 1024,
 						Compression:  "false",
 					},
+					Lifecycle: cmn.LifecycleConf{
+						Rules:   cmn.LifecycleRules{{ID: "tmp", Prefix: "tmp/", ExpireDays: 7}},
+						Enabled: true,
+					},
 					Access: 1024,
 				},
 			),
 		)
 	})
+
+	Describe("Validate", func() {
+		DescribeTable("should fail to validate lifecycle",
+			func(lifecycle cmn.LifecycleConf) {
+				props := cmn.BucketProps{Provider: cmn.ProviderAIS, Cksum: cmn.CksumConf{Type: cmn.ChecksumXXHash}}
+				props.Lifecycle = lifecycle
+				Expect(props.Validate(1)).To(HaveOccurred())
+			},
+			Entry("enabled without rules",
+				cmn.LifecycleConf{Enabled: true},
+			),
+			Entry("rule without action",
+				cmn.LifecycleConf{Rules: cmn.LifecycleRules{{ID: "tmp", Prefix: "tmp/"}}},
+			),
+			Entry("negative number of days",
+				cmn.LifecycleConf{Rules: cmn.LifecycleRules{{ExpireDays: -1}}},
+			),
+			Entry("duplicate rule ID",
+				cmn.LifecycleConf{Rules: cmn.LifecycleRules{{ID: "a", ExpireDays: 1}, {ID: "a", EvictDays: 1}}},
+			),
+		)
+
+		DescribeTable("should validate S3-compatible endpoint",
+			func(provider string, extra cmn.ExtraProps, valid bool) {
+				props := cmn.BucketProps{Provider: provider, Cksum: cmn.CksumConf{Type: cmn.ChecksumXXHash}}
+				props.Extra = extra
+				if valid {
+					Expect(props.Validate(1)).NotTo(HaveOccurred())
+				} else {
+					Expect(props.Validate(1)).To(HaveOccurred())
+				}
+			},
+			Entry("endpoint and path-style addressing",
+				cmn.ProviderAmazon, cmn.ExtraProps{Endpoint: "http://minio:9000", ForcePathStyle: true}, true,
+			),
+			Entry("named profile",
+				cmn.ProviderAmazon, cmn.ExtraProps{Profile: "rgw"}, true,
+			),
+			Entry("endpoint without scheme",
+				cmn.ProviderAmazon, cmn.ExtraProps{Endpoint: "minio:9000"}, false,
+			),
+			Entry("endpoint of non-aws bucket",
+				cmn.ProviderGoogle, cmn.ExtraProps{Endpoint: "http://minio:9000"}, false,
+			),
+		)
+	})
 })
@@ -0,0 +1,42 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+// Bypassing governance mode retention requires admin permissions (see
+// `proxyrunner.checkBypassGovernance`).
+func TestAuthBypassGovernance(t *testing.T) {
+	const clusterID = "cluster"
+	var (
+		bck   = cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		other = cmn.Bck{Name: "other", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		user  = &cmn.AuthToken{
+			UserID:   "user",
+			Clusters: []*cmn.AuthCluster{{ID: clusterID, Access: cmn.AllAccess()}},
+			Buckets:  []*cmn.AuthBucket{{Bck: bck, Access: cmn.ReadWriteAccess()}},
+		}
+		owner = &cmn.AuthToken{
+			UserID:  "owner",
+			Buckets: []*cmn.AuthBucket{{Bck: bck, Access: cmn.ReadWriteAccess() | cmn.AccessADMIN}},
+		}
+		admin = &cmn.AuthToken{UserID: "admin", IsAdmin: true}
+	)
+
+	tassert.CheckFatal(t, user.CheckPermissions(clusterID, &bck, cmn.AccessObjDELETE))
+	err := user.CheckPermissions(clusterID, &bck, cmn.AccessADMIN)
+	tassert.Fatalf(t, err == cmn.ErrNoPermissions, "expected %v, got %v", cmn.ErrNoPermissions, err)
+
+	tassert.CheckFatal(t, owner.CheckPermissions(clusterID, &bck, cmn.AccessADMIN))
+	err = owner.CheckPermissions(clusterID, &other, cmn.AccessADMIN)
+	tassert.Fatalf(t, err == cmn.ErrNoPermissions, "expected %v, got %v", cmn.ErrNoPermissions, err)
+
+	tassert.CheckFatal(t, admin.CheckPermissions(clusterID, &bck, cmn.AccessADMIN))
+}
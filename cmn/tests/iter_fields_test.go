@@ -73,6 +73,8 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           false,
 					"versioning.validate_warm_get": false,
+					"versioning.keep_previous":     false,
+					"versioning.max_versions":      0,
 
 					"checksum.type":              cmn.ChecksumXXHash,
 					"checksum.validate_warm_get": false,
@@ -87,8 +89,32 @@ var _ = Describe("IterFields", func() {
 					"lru.dont_evict_time":   "",
 					"lru.capacity_upd_time": "",
 
-					"extra.original_url": "",
-					"extra.cloud_region": "",
+					"lifecycle.enabled": false,
+					"lifecycle.rules":   cmn.LifecycleRules(nil),
+
+					"object_lock.mode":    "",
+					"object_lock.days":    0,
+					"object_lock.enabled": false,
+
+					"encryption.key_id":  "",
+					"encryption.enabled": false,
+
+					"compression.algorithm": "",
+					"compression.enabled":   false,
+
+					"write_back.max_dirty": int64(0),
+					"write_back.retries":   0,
+					"write_back.enabled":   false,
+
+					"replication.alias":   "",
+					"replication.bucket":  "",
+					"replication.enabled": false,
+
+					"extra.original_url":     "",
+					"extra.cloud_region":     "",
+					"extra.endpoint":         "",
+					"extra.force_path_style": false,
+					"extra.profile":          "",
 
 					"access":  cmn.AccessAttrs(0),
 					"created": int64(0),
@@ -124,6 +150,8 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           (*bool)(nil),
 					"versioning.validate_warm_get": (*bool)(nil),
+					"versioning.keep_previous":     (*bool)(nil),
+					"versioning.max_versions":      (*int)(nil),
 
 					"checksum.type":              api.String(cmn.ChecksumXXHash),
 					"checksum.validate_warm_get": (*bool)(nil),
@@ -136,7 +164,32 @@ var _ = Describe("IterFields", func() {
 					"lru.highwm":       (*int64)(nil),
 					"lru.out_of_space": (*int64)(nil),
 
+					"lifecycle.enabled": (*bool)(nil),
+					"lifecycle.rules":   (*cmn.LifecycleRules)(nil),
+
+					"object_lock.mode":    (*string)(nil),
+					"object_lock.days":    (*int)(nil),
+					"object_lock.enabled": (*bool)(nil),
+
+					"encryption.key_id":  (*string)(nil),
+					"encryption.enabled": (*bool)(nil),
+
+					"compression.algorithm": (*string)(nil),
+					"compression.enabled":   (*bool)(nil),
+
+					"write_back.max_dirty": (*int64)(nil),
+					"write_back.retries":   (*int)(nil),
+					"write_back.enabled":   (*bool)(nil),
+
+					"replication.alias":   (*string)(nil),
+					"replication.bucket":  (*string)(nil),
+					"replication.enabled": (*bool)(nil),
+
 					"access": api.AccessAttrs(1024),
+
+					"extra.endpoint":         (*string)(nil),
+					"extra.force_path_style": (*bool)(nil),
+					"extra.profile":          (*string)(nil),
 				},
 			),
 			Entry("check for omit tag",
@@ -196,6 +249,8 @@ var _ = Describe("IterFields", func() {
 
 					"checksum.type": cmn.ChecksumXXHash,
 
+					"lifecycle.rules": `[{"prefix": "tmp/", "expire_days": 7}]`, // type == slice
+
 					"access": "12", // type == uint64
 				},
 				&cmn.BucketProps{
@@ -216,6 +271,9 @@ var _ = Describe("IterFields", func() {
 						Enabled:         false,
 						ValidateWarmGet: true,
 					},
+					Lifecycle: cmn.LifecycleConf{
+						Rules: cmn.LifecycleRules{{Prefix: "tmp/", ExpireDays: 7}},
+					},
 					Access: 12,
 				},
 			),
@@ -239,6 +297,8 @@ var _ = Describe("IterFields", func() {
 
 					"checksum.type": cmn.ChecksumXXHash,
 
+					"lifecycle.enabled": "true",
+
 					"access": "12", // type == uint64
 				},
 				&cmn.BucketPropsToUpdate{
@@ -260,6 +320,9 @@ var _ = Describe("IterFields", func() {
 						Type:            api.String(cmn.ChecksumXXHash),
 						ValidateWarmGet: api.Bool(true),
 					},
+					Lifecycle: &cmn.LifecycleConfToUpdate{
+						Enabled: api.Bool(true),
+					},
 					Access: api.AccessAttrs(12),
 				},
 			),
@@ -278,6 +341,9 @@ var _ = Describe("IterFields", func() {
 			Entry("readonly field", &cmn.BucketProps{}, map[string]interface{}{
 				"provider": cmn.ProviderAIS,
 			}),
+			Entry("invalid slice", &cmn.BucketProps{}, map[string]interface{}{
+				"lifecycle.rules": "expire_days=7",
+			}),
 			Entry("field not found", &Foo{}, map[string]interface{}{
 				"foo.bar": 2,
 			}),
@@ -0,0 +1,157 @@
+// Package compress provides on-disk compression of objects.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package compress
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v3"
+)
+
+// The content is split into blocks of BlockSize bytes, each compressed
+// separately; blocks that do not shrink are stored as is. The stored content
+// is followed by the block index (the stored length of each block) and the
+// trailer, so that a range of the content can be read by decompressing only
+// the blocks that it spans:
+//
+// | block 0 | ... | block N-1 | index: N x uint32 | magic | block size | size |
+//
+// Whether to compress the object at all is decided by its first block: if it
+// does not shrink, the object is stored uncompressed.
+
+const (
+	BlockSize = 256 * cmn.KiB
+
+	rawBlock     = uint32(1) << 31 // index: the block is stored uncompressed
+	magic        = uint32(0x41495a31)
+	trailerSize  = 16
+	maxBlockSize = 16 * cmn.MiB // (sanity check when reading)
+)
+
+type (
+	// Info is the compression metadata of an object: the algorithm, and the
+	// size and checksum of the uncompressed content.
+	Info struct {
+		Alg        string `json:"alg"`
+		Size       int64  `json:"size"`
+		CksumType  string `json:"cksum_type,omitempty"`
+		CksumValue string `json:"cksum_value,omitempty"`
+	}
+
+	codec interface {
+		// compress returns nil if the block does not shrink
+		compress(dst, src []byte) []byte
+		decompress(dst, src []byte) ([]byte, error)
+	}
+	lz4Codec struct {
+		ht []int
+	}
+	zstdCodec struct{}
+)
+
+// interface guard
+var (
+	_ codec = (*lz4Codec)(nil)
+	_ codec = (*zstdCodec)(nil)
+)
+
+var (
+	zstdOnce sync.Once
+	zstdEnc  *zstd.Encoder
+	zstdDec  *zstd.Decoder
+)
+
+func IsSupported(alg string) bool {
+	return alg == cmn.LZ4Compression || alg == cmn.ZstdCompression
+}
+
+func ParseInfo(s string) (*Info, error) {
+	info := &Info{}
+	if err := jsoniter.UnmarshalFromString(s, info); err != nil {
+		return nil, fmt.Errorf("invalid compression metadata: %v", err)
+	}
+	return info, nil
+}
+
+func (info *Info) String() string {
+	s, err := jsoniter.MarshalToString(info)
+	cmn.AssertNoErr(err)
+	return s
+}
+
+func (info *Info) Cksum() *cmn.Cksum {
+	if info.CksumType == "" {
+		return nil
+	}
+	return cmn.NewCksum(info.CksumType, info.CksumValue)
+}
+
+func newCodec(alg string) (codec, error) {
+	switch alg {
+	case cmn.LZ4Compression:
+		return &lz4Codec{}, nil
+	case cmn.ZstdCompression:
+		zstdOnce.Do(initZstd)
+		return &zstdCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression algorithm %q", alg)
+	}
+}
+
+//////////////
+// lz4Codec //
+//////////////
+
+func (c *lz4Codec) compress(dst, src []byte) []byte {
+	if c.ht == nil {
+		c.ht = make([]int, 1<<16)
+	}
+	dst = dst[:cap(dst)]
+	if bound := lz4.CompressBlockBound(len(src)); len(dst) < bound {
+		dst = make([]byte, bound)
+	}
+	n, err := lz4.CompressBlock(src, dst, c.ht)
+	if err != nil || n == 0 || n >= len(src) {
+		return nil
+	}
+	return dst[:n]
+}
+
+func (*lz4Codec) decompress(dst, src []byte) ([]byte, error) {
+	n, err := lz4.UncompressBlock(src, dst[:cap(dst)])
+	if err != nil {
+		return nil, err
+	}
+	return dst[:n], nil
+}
+
+///////////////
+// zstdCodec //
+///////////////
+
+// (encoder and decoder are shared: EncodeAll and DecodeAll can be called concurrently)
+func initZstd() {
+	var err error
+	zstdEnc, err = zstd.NewWriter(nil)
+	cmn.AssertNoErr(err)
+	zstdDec, err = zstd.NewReader(nil)
+	cmn.AssertNoErr(err)
+}
+
+func (*zstdCodec) compress(dst, src []byte) []byte {
+	dst = zstdEnc.EncodeAll(src, dst[:0])
+	if len(dst) >= len(src) {
+		return nil
+	}
+	return dst
+}
+
+func (*zstdCodec) decompress(dst, src []byte) ([]byte, error) {
+	return zstdDec.DecodeAll(src, dst[:0])
+}
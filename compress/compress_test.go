@@ -0,0 +1,119 @@
+// Package compress provides on-disk compression of objects.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package compress
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func compress(t *testing.T, alg string, content []byte) ([]byte, bool) {
+	var (
+		buf     = &bytes.Buffer{}
+		cw, err = NewWriter(buf, alg)
+	)
+	tassert.CheckFatal(t, err)
+	// write in odd-sized pieces to exercise block boundaries
+	for b := content; len(b) > 0; {
+		n := cmn.Min(len(b), 100*cmn.KiB+3)
+		_, err = cw.Write(b[:n])
+		tassert.CheckFatal(t, err)
+		b = b[n:]
+	}
+	tassert.CheckFatal(t, cw.Close())
+	tassert.Errorf(t, cw.Size() == int64(buf.Len()), "size %d != %d", cw.Size(), buf.Len())
+	tassert.Errorf(t, cw.ContentSize() == int64(len(content)), "content size %d != %d",
+		cw.ContentSize(), len(content))
+	return buf.Bytes(), cw.Compressed()
+}
+
+func text(size int) []byte {
+	s := strings.Repeat(`{"name": "shard-000123.tar", "size": 1048576, "checksum": "a1b2c3d4"}`+"\n",
+		size/70+1)
+	return []byte(s[:size])
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, alg := range []string{cmn.LZ4Compression, cmn.ZstdCompression} {
+		for _, size := range []int{1, BlockSize - 1, BlockSize, BlockSize + 1, 3*BlockSize + 17} {
+			content := text(size)
+			stored, compressed := compress(t, alg, content)
+			if !compressed {
+				tassert.Fatalf(t, bytes.Equal(stored, content), "%s, size %d: content modified", alg, size)
+				continue
+			}
+			cr, err := NewReader(bytes.NewReader(stored), int64(len(stored)), alg)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, cr.Size() == int64(size), "%s, size %d: content size %d", alg, size, cr.Size())
+			got, err := ioutil.ReadAll(cr)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, bytes.Equal(got, content), "%s, size %d: content mismatch", alg, size)
+
+			// range reads across block boundaries
+			for _, off := range []int{0, size / 2, BlockSize - 5, size - 1} {
+				if off < 0 || off >= size {
+					continue
+				}
+				length := cmn.Min(size-off, BlockSize+10)
+				got := make([]byte, length)
+				_, err := cr.ReadAt(got, int64(off))
+				if err != nil && err != io.EOF {
+					t.Fatal(err)
+				}
+				tassert.Fatalf(t, bytes.Equal(got, content[off:off+length]),
+					"%s, size %d: range [%d, %d) mismatch", alg, size, off, off+length)
+			}
+		}
+	}
+}
+
+func TestShrink(t *testing.T) {
+	content := text(3*BlockSize + 17)
+	stored, compressed := compress(t, cmn.ZstdCompression, content)
+	tassert.Fatalf(t, compressed, "expected text to be compressed")
+	tassert.Errorf(t, len(stored) < len(content)/4, "expected compression ratio > 4, got %d => %d",
+		len(content), len(stored))
+
+	// random content does not shrink and is stored as is
+	random := make([]byte, 2*BlockSize+5)
+	_, err := rand.Read(random)
+	tassert.CheckFatal(t, err)
+	for _, alg := range []string{cmn.LZ4Compression, cmn.ZstdCompression} {
+		stored, compressed := compress(t, alg, random)
+		tassert.Errorf(t, !compressed && bytes.Equal(stored, random), "%s: expected random content as is", alg)
+	}
+	_, compressed = compress(t, cmn.ZstdCompression, nil)
+	tassert.Errorf(t, !compressed, "expected empty content as is")
+
+	// compressible first block => incompressible blocks are stored raw
+	mixed := append(text(BlockSize), random...)
+	stored, compressed = compress(t, cmn.ZstdCompression, mixed)
+	tassert.Fatalf(t, compressed, "expected mixed content to be compressed")
+	cr, err := NewReader(bytes.NewReader(stored), int64(len(stored)), cmn.ZstdCompression)
+	tassert.CheckFatal(t, err)
+	got, err := ioutil.ReadAll(cr)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, bytes.Equal(got, mixed), "mixed content mismatch")
+}
+
+func TestCorrupted(t *testing.T) {
+	stored, _ := compress(t, cmn.ZstdCompression, text(2*BlockSize))
+	_, err := NewReader(bytes.NewReader(stored[:len(stored)-1]), int64(len(stored)-1), cmn.ZstdCompression)
+	tassert.Errorf(t, errors.Is(err, ErrCorrupted), "expected corrupted trailer, got %v", err)
+
+	stored[10] ^= 0xff
+	cr, err := NewReader(bytes.NewReader(stored), int64(len(stored)), cmn.ZstdCompression)
+	tassert.CheckFatal(t, err)
+	_, err = ioutil.ReadAll(cr)
+	tassert.Errorf(t, err != nil, "expected error reading corrupted content")
+}
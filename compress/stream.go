@@ -0,0 +1,288 @@
+// Package compress provides on-disk compression of objects.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package compress
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+type (
+	// Writer compresses the content and writes it to the underlying writer.
+	// Close must be called to write the last block and the block index; it
+	// does not close the underlying writer. If the first block does not
+	// shrink, the content is written as is (see Compressed).
+	Writer struct {
+		w      io.Writer
+		codec  codec
+		alg    string
+		buf    []byte // current block
+		out    []byte // compressed block
+		index  []byte
+		size   int64 // bytes written to the underlying writer
+		csize  int64 // bytes of the content
+		probed bool  // whether the first block has been checked
+		raw    bool  // writing the content as is
+	}
+
+	// Reader decompresses the content read from the underlying reader; in
+	// addition to io.Reader, it implements io.ReaderAt (range reads).
+	Reader struct {
+		r      io.ReaderAt
+		codec  codec
+		offs   []int64  // stored offset of each block
+		lens   []uint32 // index
+		block  []byte   // decompressed block `idx`
+		stored []byte
+		bsize  int64 // block size
+		size   int64 // content size
+		idx    int64
+		off    int64 // (io.Reader)
+	}
+)
+
+// interface guard
+var (
+	_ io.WriteCloser = (*Writer)(nil)
+	_ io.Reader      = (*Reader)(nil)
+	_ io.ReaderAt    = (*Reader)(nil)
+)
+
+var ErrCorrupted = errors.New("compressed content is corrupted")
+
+////////////
+// Writer //
+////////////
+
+func NewWriter(w io.Writer, alg string) (*Writer, error) {
+	codec, err := newCodec(alg)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, codec: codec, alg: alg, buf: make([]byte, 0, BlockSize), out: make([]byte, 0, BlockSize)}, nil
+}
+
+func (cw *Writer) Alg() string { return cw.alg }
+
+// Compressed returns false if the content is written as is; final once the
+// writer is closed.
+func (cw *Writer) Compressed() bool { return !cw.raw }
+
+// Size returns the number of bytes written to the underlying writer so far.
+func (cw *Writer) Size() int64 { return cw.size }
+
+// ContentSize returns the number of the content bytes written so far.
+func (cw *Writer) ContentSize() int64 { return cw.csize }
+
+func (cw *Writer) Write(b []byte) (n int, err error) {
+	if cw.raw {
+		n, err = cw.w.Write(b)
+		cw.size += int64(n)
+		cw.csize += int64(n)
+		return
+	}
+	for len(b) > 0 {
+		m := copy(cw.buf[len(cw.buf):BlockSize], b)
+		cw.buf = cw.buf[:len(cw.buf)+m]
+		n += m
+		b = b[m:]
+		if len(cw.buf) < BlockSize {
+			continue
+		}
+		if err = cw.flush(); err != nil {
+			return
+		}
+		if cw.raw && len(b) > 0 {
+			m, err = cw.Write(b)
+			n += m
+			return
+		}
+	}
+	return
+}
+
+func (cw *Writer) Close() (err error) {
+	if len(cw.buf) > 0 {
+		if err = cw.flush(); err != nil {
+			return
+		}
+	}
+	if !cw.probed {
+		cw.raw = true // empty content
+	}
+	if cw.raw {
+		return nil
+	}
+	var trailer [trailerSize]byte
+	binary.BigEndian.PutUint32(trailer[0:], magic)
+	binary.BigEndian.PutUint32(trailer[4:], BlockSize)
+	binary.BigEndian.PutUint64(trailer[8:], uint64(cw.csize))
+	cw.index = append(cw.index, trailer[:]...)
+	n, err := cw.w.Write(cw.index)
+	cw.size += int64(n)
+	return err
+}
+
+func (cw *Writer) flush() error {
+	var (
+		block = cw.codec.compress(cw.out, cw.buf)
+		l     = uint32(len(block))
+		b4    [cmn.SizeofI32]byte
+	)
+	if !cw.probed {
+		cw.probed = true
+		cw.raw = block == nil
+	}
+	if block == nil {
+		block, l = cw.buf, uint32(len(cw.buf))|rawBlock
+	} else {
+		cw.out = block[:0]
+	}
+	n, err := cw.w.Write(block)
+	cw.size += int64(n)
+	cw.csize += int64(len(cw.buf))
+	cw.buf = cw.buf[:0]
+	if !cw.raw {
+		binary.BigEndian.PutUint32(b4[:], l)
+		cw.index = append(cw.index, b4[:]...)
+	}
+	return err
+}
+
+////////////
+// Reader //
+////////////
+
+// NewReader returns Reader of the compressed content of a given size.
+func NewReader(r io.ReaderAt, size int64, alg string) (*Reader, error) {
+	codec, err := newCodec(alg)
+	if err != nil {
+		return nil, err
+	}
+	var trailer [trailerSize]byte
+	if size < trailerSize {
+		return nil, fmt.Errorf("%w: size %d", ErrCorrupted, size)
+	}
+	if _, err := r.ReadAt(trailer[:], size-trailerSize); err != nil {
+		return nil, err
+	}
+	var (
+		bsize  = int64(binary.BigEndian.Uint32(trailer[4:]))
+		csize  = int64(binary.BigEndian.Uint64(trailer[8:]))
+		blocks int64
+	)
+	if binary.BigEndian.Uint32(trailer[0:]) != magic || bsize == 0 || bsize > maxBlockSize {
+		return nil, fmt.Errorf("%w: invalid trailer", ErrCorrupted)
+	}
+	blocks = (csize + bsize - 1) / bsize
+	end := size - trailerSize - blocks*int64(cmn.SizeofI32)
+	if end < 0 {
+		return nil, fmt.Errorf("%w: invalid number of blocks %d", ErrCorrupted, blocks)
+	}
+	index := make([]byte, blocks*int64(cmn.SizeofI32))
+	if _, err := r.ReadAt(index, end); err != nil {
+		return nil, err
+	}
+	cr := &Reader{
+		r:      r,
+		codec:  codec,
+		offs:   make([]int64, blocks),
+		lens:   make([]uint32, blocks),
+		block:  make([]byte, 0, bsize),
+		stored: make([]byte, 0, bsize),
+		bsize:  bsize,
+		size:   csize,
+		idx:    -1,
+	}
+	var off int64
+	for i := range cr.lens {
+		cr.lens[i] = binary.BigEndian.Uint32(index[i*cmn.SizeofI32:])
+		cr.offs[i] = off
+		off += int64(cr.lens[i] &^ rawBlock)
+	}
+	if off != end {
+		return nil, fmt.Errorf("%w: block index does not match the size", ErrCorrupted)
+	}
+	return cr, nil
+}
+
+// Size returns the size of the decompressed content.
+func (cr *Reader) Size() int64 { return cr.size }
+
+func (cr *Reader) Read(b []byte) (n int, err error) {
+	n, err = cr.ReadAt(b, cr.off)
+	cr.off += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return
+}
+
+func (cr *Reader) ReadAt(b []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, fmt.Errorf("invalid offset %d", off)
+	}
+	for len(b) > 0 && off < cr.size {
+		idx := off / cr.bsize
+		if err = cr.open(idx); err != nil {
+			return
+		}
+		m := copy(b, cr.block[off-idx*cr.bsize:])
+		n += m
+		off += int64(m)
+		b = b[m:]
+	}
+	if len(b) > 0 {
+		err = io.EOF
+	}
+	return
+}
+
+// reads and decompresses a given block
+func (cr *Reader) open(idx int64) (err error) {
+	if idx == cr.idx {
+		return
+	}
+	var (
+		l      = cr.lens[idx] &^ rawBlock
+		raw    = cr.lens[idx]&rawBlock != 0
+		blen   = cmn.MinI64(cr.bsize, cr.size-idx*cr.bsize)
+		stored []byte
+		n      int
+	)
+	cr.idx = -1
+	if int64(l) > cr.bsize {
+		return fmt.Errorf("%w: invalid length of block %d", ErrCorrupted, idx)
+	}
+	if raw {
+		stored = cr.block[:l]
+	} else {
+		stored = cr.stored[:l]
+	}
+	if n, err = cr.r.ReadAt(stored, cr.offs[idx]); n < len(stored) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return
+	}
+	if !raw {
+		var block []byte
+		if block, err = cr.codec.decompress(cr.block[:0], stored); err != nil {
+			return fmt.Errorf("%w (block %d): %v", ErrCorrupted, idx, err)
+		}
+		cr.block = block
+	} else {
+		cr.block = stored
+	}
+	if int64(len(cr.block)) != blen {
+		return fmt.Errorf("%w: invalid size of block %d", ErrCorrupted, idx)
+	}
+	cr.idx = idx
+	return nil
+}
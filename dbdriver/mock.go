@@ -91,7 +91,7 @@ func (bd *DBMock) List(collection, pattern string) ([]string, error) {
 		if strings.HasPrefix(k, filter) {
 			_, key := parsePath(k)
 			if key != "" {
-				keys = append(keys, k)
+				keys = append(keys, key)
 			}
 		}
 	}
@@ -107,7 +107,7 @@ func (bd *DBMock) DeleteCollection(collection string) error {
 		return err
 	}
 	for _, k := range keys {
-		delete(bd.values, k)
+		delete(bd.values, bd.makePath(collection, k))
 	}
 	return nil
 }
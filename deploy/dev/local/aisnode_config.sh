@@ -35,7 +35,8 @@ cat > $AIS_CONF_FILE <<EOL
 		"max_keepalive":        "4s",
 		"max_host_busy":        "20s",
 		"startup_time":         "1m",
-		"send_file_time":       "5m"
+		"send_file_time":       "5m",
+		"mpt_upload_time":      "168h"
 	},
 	"client": {
 		"client_timeout":      "10s",
@@ -84,7 +85,9 @@ cat > $AIS_CONF_FILE <<EOL
 	},
 	"versioning": {
 		"enabled":           true,
-		"validate_warm_get": false
+		"validate_warm_get": false,
+		"keep_previous":     false,
+		"max_versions":      0
 	},
 	"fspaths": {
 		$AIS_FS_PATHS
@@ -141,6 +144,11 @@ cat > $AIS_CONF_FILE <<EOL
 	"downloader": {
 		"timeout": "1h"
 	},
+	"kms": {
+		"provider":       "",
+		"key_file":       "",
+		"default_key_id": ""
+	},
 	"distributed_sort": {
 		"duplicated_records":    "ignore",
 		"missing_shards":        "ignore",
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"path"
 	"regexp"
 	"strings"
@@ -24,7 +25,11 @@ const (
 	DlTypeMulti  DlType = "multi"
 	DlTypeCloud  DlType = "cloud"
 
+	DlTypeManifest DlType = "manifest"
+	DlTypeCrawl    DlType = "crawl"
+
 	DownloadProgressInterval = 10 * time.Second
+	DlChunkMinSize           = 64 * cmn.MiB // default min size of the object to download in chunks (see DlChunks)
 )
 
 type (
@@ -36,6 +41,12 @@ type (
 		json.RawMessage
 	}
 
+	// implemented by all `DlType`-specific request bodies
+	dlTypedBody interface {
+		Validate() error
+		Describe() string
+	}
+
 	// Download POST result returned to the user
 	DlPostResp struct {
 		ID string `json:"id"`
@@ -54,6 +65,19 @@ type (
 		Aborted       bool      `json:"aborted"`
 		StartedTime   time.Time `json:"started_time"`
 		FinishedTime  time.Time `json:"finished_time"`
+
+		Schedule *DlScheduleInfo `json:"schedule,omitempty"` // only for recurring jobs (see `DlBase.Schedule`)
+	}
+
+	// Schedule of a recurring download job along with the outcome of its most recent run.
+	// Each run is a separate (child) download job.
+	DlScheduleInfo struct {
+		Schedule string     `json:"schedule"`
+		NextRun  time.Time  `json:"next_run"`
+		LastRun  time.Time  `json:"last_run"`
+		Runs     int        `json:"runs"`
+		LastJob  *DlJobInfo `json:"last_job,omitempty"`
+		LastErr  string     `json:"last_error,omitempty"` // failed to start the most recent run
 	}
 
 	DlJobInfos []*DlJobInfo
@@ -87,6 +111,23 @@ func (j *DlJobInfo) Aggregate(rhs *DlJobInfo) {
 	}
 }
 
+// LastOutcome summarizes the most recent run of a recurring download job.
+func (s *DlScheduleInfo) LastOutcome() string {
+	switch {
+	case s.LastErr != "":
+		return "Failed: " + s.LastErr
+	case s.LastJob == nil:
+		return "-"
+	case s.LastJob.Aborted:
+		return "Aborted"
+	case s.LastJob.JobFinished():
+		return fmt.Sprintf("Finished (%s): %d downloaded, %d skipped, %d error%s", s.LastJob.ID,
+			s.LastJob.FinishedCnt-s.LastJob.SkippedCnt, s.LastJob.SkippedCnt, s.LastJob.ErrorCnt, cmn.NounEnding(s.LastJob.ErrorCnt))
+	default:
+		return fmt.Sprintf("Running (%s): %d pending", s.LastJob.ID, s.LastJob.PendingCnt())
+	}
+}
+
 func (db DlBody) MarshalJSON() ([]byte, error) {
 	b, err := db.RawMessage.MarshalJSON()
 	if err != nil {
@@ -193,12 +234,23 @@ type DlLimits struct {
 	BytesPerHour int `json:"bytes_per_hour"`
 }
 
+// DlChunks configures parallel download of large objects: an object is split
+// into (up to) `Count` ranges fetched concurrently, each via its own connection.
+// Requires the source to support ranges; the number of connections is limited
+// by `DlLimits.Connections`.
+type DlChunks struct {
+	Count   int   `json:"count"`           // 0 or 1 - no splitting
+	MinSize int64 `json:"min_size,string"` // objects smaller than this are not split (0 - DlChunkMinSize)
+}
+
 type DlBase struct {
 	Description      string   `json:"description"`
 	Bck              cmn.Bck  `json:"bucket"`
 	Timeout          string   `json:"timeout"`
 	ProgressInterval string   `json:"progress_interval"`
 	Limits           DlLimits `json:"limits"`
+	Chunks           DlChunks `json:"chunks"`
+	Schedule         string   `json:"schedule"` // cron-like, in UTC (see `parseSchedule`); empty - run once
 }
 
 func (b *DlBase) Validate() error {
@@ -216,6 +268,17 @@ func (b *DlBase) Validate() error {
 	if b.Limits.BytesPerHour < 0 {
 		return fmt.Errorf("'limit.bytes_per_hour' must be non-negative (got: %d)", b.Limits.BytesPerHour)
 	}
+	if b.Chunks.Count < 0 {
+		return fmt.Errorf("'chunks.count' must be non-negative (got: %d)", b.Chunks.Count)
+	}
+	if b.Chunks.MinSize < 0 {
+		return fmt.Errorf("'chunks.min_size' must be non-negative (got: %d)", b.Chunks.MinSize)
+	}
+	if b.Schedule != "" {
+		if _, err := parseSchedule(b.Schedule); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -273,6 +336,7 @@ type TaskDlInfo struct {
 	Name       string    `json:"name"`
 	Downloaded int64     `json:"downloaded,string"`
 	Total      int64     `json:"total,string,omitempty"`
+	Resumed    int64     `json:"resumed,string,omitempty"` // bytes not downloaded again when resuming (see HTTP Range)
 	StartTime  time.Time `json:"start_time,omitempty"`
 	EndTime    time.Time `json:"end_time,omitempty"`
 	Running    bool      `json:"running"`
@@ -410,6 +474,92 @@ func (b *DlMultiBody) String() string {
 	return fmt.Sprintf("bucket: %q", b.Bck)
 }
 
+// Manifest request: objects to download along with their expected sizes
+// and checksums - validated prior to storing the objects.
+type DlManifestBody struct {
+	DlBase
+	Manifest string `json:"manifest"` // contents of the manifest, see `DlManifestEntry`
+	Format   string `json:"format"`   // one of: ManifestJSONL (default), ManifestCSV, ManifestTSV
+
+	entries []DlManifestEntry
+}
+
+func (b *DlManifestBody) Validate() (err error) {
+	if err := b.DlBase.Validate(); err != nil {
+		return err
+	}
+	if b.Manifest == "" {
+		return errors.New("missing 'manifest' in the request body")
+	}
+	b.entries, err = parseManifest(b.Manifest, b.Format)
+	return
+}
+
+func (b *DlManifestBody) Describe() string {
+	if b.Description != "" {
+		return b.Description
+	}
+	return fmt.Sprintf("manifest-download -> %s", b.Bck)
+}
+
+func (b *DlManifestBody) String() string {
+	return fmt.Sprintf("bucket: %q, format: %q", b.Bck, b.Format)
+}
+
+// Crawl request: recursively walks HTTP directory listing (Apache/nginx autoindex)
+// or S3-style XML bucket listing and downloads all discovered objects.
+type DlCrawlBody struct {
+	DlBase
+	URL     string `json:"url"`
+	Subdir  string `json:"subdir"`
+	Depth   int    `json:"depth"`   // max number of nested directories to descend into (0 - unlimited)
+	Include string `json:"include"` // regex: download only objects with matching names (relative to `URL`)
+	Exclude string `json:"exclude"` // regex: skip objects with matching names (relative to `URL`)
+
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+}
+
+func (b *DlCrawlBody) Validate() (err error) {
+	if err := b.DlBase.Validate(); err != nil {
+		return err
+	}
+	if b.URL == "" {
+		return errors.New("missing 'url' in the request body")
+	}
+	b.URL = cmn.PrependProtocol(b.URL)
+	if u, err := url.Parse(b.URL); err != nil {
+		return fmt.Errorf("invalid 'url' %q: %v", b.URL, err)
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid 'url' %q: expecting http(s) scheme", b.URL)
+	}
+	if b.Depth < 0 {
+		return fmt.Errorf("'depth' must be non-negative (got: %d)", b.Depth)
+	}
+	if b.Include != "" {
+		if b.include, err = regexp.Compile(b.Include); err != nil {
+			return fmt.Errorf("invalid 'include' regex %q: %v", b.Include, err)
+		}
+	}
+	if b.Exclude != "" {
+		if b.exclude, err = regexp.Compile(b.Exclude); err != nil {
+			return fmt.Errorf("invalid 'exclude' regex %q: %v", b.Exclude, err)
+		}
+	}
+	return nil
+}
+
+func (b *DlCrawlBody) Describe() string {
+	if b.Description != "" {
+		return b.Description
+	}
+	return fmt.Sprintf("crawl %s -> %s", b.URL, b.Bck)
+}
+
+func (b *DlCrawlBody) String() string {
+	return fmt.Sprintf("bucket: %q, url: %q, depth: %d", b.Bck, b.URL, b.Depth)
+}
+
 // Cloud request
 type DlCloudBody struct {
 	DlBase
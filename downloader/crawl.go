@@ -0,0 +1,255 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+const (
+	crawlPageTimeout = time.Minute  // default timeout to fetch a single listing page
+	crawlPageMaxSize = 64 * cmn.MiB // listing pages larger than this are truncated
+	s3ListTag        = "<ListBucketResult"
+)
+
+// Matches links on HTML pages (Apache/nginx autoindex and alike).
+var hrefRegex = regexp.MustCompile(`(?i)<a\s[^>]*?href\s*=\s*["']([^"'#]+)["']`)
+
+type (
+	// crawler walks (breadth-first) HTTP directory listing or S3-style XML bucket listing
+	// starting from the root URL. Only links "under" the root are followed; object
+	// names are the links' paths relative to the root.
+	crawler struct {
+		root    *url.URL // root "directory" - the first page's URL up to (and including) the last '/'
+		timeout time.Duration
+		depth   int // max depth (0 - unlimited)
+		include *regexp.Regexp
+		exclude *regexp.Regexp
+
+		queue   []*crawlPage
+		visited cmn.StringSet // pages
+		seen    cmn.StringSet // objects (the same object can be linked from multiple pages)
+	}
+
+	crawlPage struct {
+		u     *url.URL
+		depth int
+	}
+
+	// crawledObj is a single object discovered by crawler.
+	crawledObj struct {
+		objName string
+		link    string
+		etag    string // source validator, if known (S3 listing)
+	}
+
+	s3ListBucketResult struct {
+		Contents []struct {
+			Key  string `xml:"Key"`
+			ETag string `xml:"ETag"`
+		} `xml:"Contents"`
+		CommonPrefixes []struct {
+			Prefix string `xml:"Prefix"`
+		} `xml:"CommonPrefixes"`
+		IsTruncated           bool   `xml:"IsTruncated"`
+		NextMarker            string `xml:"NextMarker"`
+		NextContinuationToken string `xml:"NextContinuationToken"`
+	}
+)
+
+func newCrawler(payload *DlCrawlBody, timeout time.Duration) (*crawler, error) {
+	u, err := url.Parse(payload.URL)
+	if err != nil {
+		return nil, err
+	}
+	if timeout == 0 {
+		timeout = crawlPageTimeout
+	}
+	c := &crawler{
+		timeout: timeout,
+		depth:   payload.Depth,
+		include: payload.include,
+		exclude: payload.exclude,
+		visited: make(cmn.StringSet, 16),
+		seen:    make(cmn.StringSet, 1024),
+	}
+	c.push(u, 0)
+	return c, nil
+}
+
+func (c *crawler) done() bool { return len(c.queue) == 0 }
+
+func (c *crawler) push(u *url.URL, depth int) {
+	u.Fragment = ""
+	if c.visited.Contains(u.String()) {
+		return
+	}
+	c.visited.Add(u.String())
+	c.queue = append(c.queue, &crawlPage{u: u, depth: depth})
+}
+
+// next fetches and parses the next page in the queue, returns objects discovered on it.
+func (c *crawler) next(ctx context.Context) ([]crawledObj, error) {
+	page := c.queue[0]
+	c.queue = c.queue[1:]
+
+	body, u, err := c.fetch(ctx, page.u)
+	if err != nil {
+		return nil, err
+	}
+	if c.root == nil {
+		root := *u
+		root.Path = root.Path[:strings.LastIndex(root.Path, "/")+1]
+		root.RawPath, root.RawQuery, root.Fragment = "", "", ""
+		c.root = &root
+	}
+	if bytes.Contains(body[:cmn.Min(len(body), 512)], []byte(s3ListTag)) {
+		return c.parseS3List(page, body)
+	}
+	return c.parseHTML(page, u, body), nil
+}
+
+func (c *crawler) fetch(ctx context.Context, u *url.URL) ([]byte, *url.URL, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := clientForURL(u.String()).Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("failed to crawl %q: %s", u, resp.Status)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, crawlPageMaxSize))
+	if err != nil {
+		return nil, nil, err
+	}
+	// NOTE: relative links are resolved against the final URL (after redirects).
+	return body, resp.Request.URL, nil
+}
+
+func (c *crawler) parseHTML(page *crawlPage, u *url.URL, body []byte) (objs []crawledObj) {
+	for _, match := range hrefRegex.FindAllSubmatch(body, -1) {
+		ref, err := u.Parse(string(match[1]))
+		if err != nil {
+			continue
+		}
+		// Skip: other hosts, parent and the same directories, and autoindex
+		// sorting links (e.g. "?C=N;O=D").
+		if ref.Scheme != c.root.Scheme || ref.Host != c.root.Host || ref.RawQuery != "" {
+			continue
+		}
+		if !strings.HasPrefix(ref.Path, c.root.Path) {
+			continue
+		}
+		if strings.HasSuffix(ref.Path, "/") {
+			if len(ref.Path) <= len(u.Path) {
+				continue
+			}
+			if c.depth == 0 || page.depth < c.depth {
+				c.push(ref, page.depth+1)
+			}
+			continue
+		}
+		if obj, ok := c.makeObj(strings.TrimPrefix(ref.Path, c.root.Path), ref.String(), ""); ok {
+			objs = append(objs, obj)
+		}
+	}
+	return
+}
+
+// parseS3List parses XML bucket listing (`ListObjects` and `ListObjectsV2`), follows
+// pagination and - when listing with delimiter - common prefixes ("directories").
+func (c *crawler) parseS3List(page *crawlPage, body []byte) ([]crawledObj, error) {
+	var res s3ListBucketResult
+	if err := xml.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("failed to parse bucket listing %q: %v", page.u, err)
+	}
+	// Object keys are relative to the bucket, i.e. to the listing URL's path
+	// (`http://bucket.host/` as well as path-style `http://host/bucket`).
+	base := url.URL{Scheme: page.u.Scheme, User: page.u.User, Host: page.u.Host, Path: page.u.Path}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	objs := make([]crawledObj, 0, len(res.Contents))
+	for _, entry := range res.Contents {
+		if entry.Key == "" || strings.HasSuffix(entry.Key, "/") {
+			continue
+		}
+		link := base
+		link.Path += entry.Key
+		if obj, ok := c.makeObj(entry.Key, link.String(), strings.Trim(entry.ETag, `"`)); ok {
+			objs = append(objs, obj)
+		}
+	}
+	if c.depth == 0 || page.depth < c.depth {
+		for _, prefix := range res.CommonPrefixes {
+			c.push(withQuery(page.u, "prefix", prefix.Prefix, "marker", "", "continuation-token", ""), page.depth+1)
+		}
+	}
+	if res.IsTruncated {
+		var next *url.URL
+		switch {
+		case res.NextContinuationToken != "":
+			next = withQuery(page.u, "continuation-token", res.NextContinuationToken)
+		case res.NextMarker != "":
+			next = withQuery(page.u, "marker", res.NextMarker)
+		case len(res.Contents) > 0:
+			next = withQuery(page.u, "marker", res.Contents[len(res.Contents)-1].Key)
+		default:
+			return objs, fmt.Errorf("failed to paginate bucket listing %q", page.u)
+		}
+		c.push(next, page.depth)
+	}
+	return objs, nil
+}
+
+func (c *crawler) makeObj(objName, link, etag string) (crawledObj, bool) {
+	if objName == "" || c.seen.Contains(objName) {
+		return crawledObj{}, false
+	}
+	if c.include != nil && !c.include.MatchString(objName) {
+		return crawledObj{}, false
+	}
+	if c.exclude != nil && c.exclude.MatchString(objName) {
+		return crawledObj{}, false
+	}
+	c.seen.Add(objName)
+	return crawledObj{objName: objName, link: link, etag: etag}, true
+}
+
+// withQuery returns copy of the URL with the given query parameters (key, value pairs)
+// set or - when the value is empty - deleted.
+func withQuery(u *url.URL, kvs ...string) *url.URL {
+	var (
+		nu    = *u
+		query = nu.Query()
+	)
+	for i := 0; i < len(kvs); i += 2 {
+		if kvs[i+1] == "" {
+			query.Del(kvs[i])
+		} else {
+			query.Set(kvs[i], kvs[i+1])
+		}
+	}
+	nu.RawQuery = query.Encode()
+	return &nu
+}
@@ -0,0 +1,112 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func crawlAll(t *testing.T, body *DlCrawlBody) (names []string, objs map[string]crawledObj) {
+	tassert.CheckFatal(t, body.Validate())
+	c, err := newCrawler(body, 0)
+	tassert.CheckFatal(t, err)
+	objs = make(map[string]crawledObj)
+	for !c.done() {
+		page, err := c.next(context.Background())
+		tassert.CheckFatal(t, err)
+		for _, obj := range page {
+			names = append(names, obj.objName)
+			objs[obj.objName] = obj
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+func TestCrawlAutoindex(t *testing.T) {
+	pages := map[string]string{
+		"/data/": `<a href="../">../</a><a href="?C=N;O=D">Name</a>
+			<a href="a.tar">a.tar</a> <a href="b.tar">b.tar</a> <a href="sub/">sub/</a>
+			<a href="http://other.com/c.tar">c.tar</a> <a href="/data/a.tar#x">a.tar</a>`,
+		"/data/sub/":      `<A HREF="../">../</A> <A HREF="c.tar">c.tar</A> <a href='deep/'>deep/</a>`,
+		"/data/sub/deep/": `<a href="d.txt">d.txt</a>`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/data" {
+			http.Redirect(w, r, "/data/", http.StatusMovedPermanently)
+			return
+		}
+		page, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, "<html><body>"+page+"</body></html>")
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		body     DlCrawlBody
+		expected []string
+	}{
+		{DlCrawlBody{URL: srv.URL + "/data"}, []string{"a.tar", "b.tar", "sub/c.tar", "sub/deep/d.txt"}},
+		{DlCrawlBody{URL: srv.URL + "/data/", Depth: 1}, []string{"a.tar", "b.tar", "sub/c.tar"}},
+		{DlCrawlBody{URL: srv.URL + "/data/", Include: `\.tar$`, Exclude: `^b`}, []string{"a.tar", "sub/c.tar"}},
+		{DlCrawlBody{URL: srv.URL + "/data/sub/"}, []string{"c.tar", "deep/d.txt"}},
+	}
+	for _, test := range tests {
+		test.body.Bck.Name = "bck"
+		names, _ := crawlAll(t, &test.body)
+		tassert.Errorf(t, strings.Join(names, ",") == strings.Join(test.expected, ","),
+			"%s (depth %d): expected %v, got %v", test.body.URL, test.body.Depth, test.expected, names)
+	}
+}
+
+func TestCrawlS3Listing(t *testing.T) {
+	const listTmpl = `<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">%s</ListBucketResult>`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch q := r.URL.Query(); {
+		case q.Get("prefix") == "dir/":
+			body = `<Contents><Key>dir/x</Key><ETag>"3"</ETag></Contents>`
+		case q.Get("marker") == "b":
+			body = `<Contents><Key>c</Key><ETag>"2"</ETag></Contents><CommonPrefixes><Prefix>dir/</Prefix></CommonPrefixes>`
+		default:
+			body = `<IsTruncated>true</IsTruncated><Contents><Key>a</Key><ETag>"0"</ETag></Contents>` +
+				`<Contents><Key>b</Key><ETag>"1"</ETag></Contents>`
+		}
+		fmt.Fprintf(w, listTmpl, body)
+	}))
+	defer srv.Close()
+
+	// virtual-hosted style (bucket in the host name) and path-style listings
+	tests := []struct {
+		url, base string
+	}{
+		{srv.URL + "/?delimiter=/", srv.URL + "/"},
+		{srv.URL + "/bck?list-type=2&delimiter=/", srv.URL + "/bck/"},
+		{srv.URL + "/s3/bck/?delimiter=/", srv.URL + "/s3/bck/"},
+	}
+	for _, test := range tests {
+		body := &DlCrawlBody{URL: test.url}
+		body.Bck.Name = "bck"
+		names, objs := crawlAll(t, body)
+		expected := []string{"a", "b", "c", "dir/x"}
+		tassert.Fatalf(t, strings.Join(names, ",") == strings.Join(expected, ","), "%s: expected %v, got %v", test.url, expected, names)
+		tassert.Errorf(t, objs["dir/x"].etag == "3", "%s: expected ETag %q, got %q", test.url, "3", objs["dir/x"].etag)
+		for _, name := range names {
+			tassert.Errorf(t, objs[name].link == test.base+name, "%s: expected link %q, got %q", test.url, test.base+name, objs[name].link)
+		}
+	}
+}
@@ -7,15 +7,19 @@ package downloader
 import (
 	"errors"
 	"path"
+	"strings"
 	"sync"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/dbdriver"
 )
 
 const (
 	downloaderErrors     = "errors"
 	downloaderTasks      = "tasks"
+	downloaderCrawled    = "crawled"
+	downloaderPartials   = "partials"
 	downloaderCollection = "downloads"
 
 	// Number of errors stored in memory. When the number of errors exceeds
@@ -182,3 +186,56 @@ func (db *downloaderDB) delete(id string) {
 	db.driver.Delete(downloaderCollection, key)
 	db.mtx.Unlock()
 }
+
+func (db *downloaderDB) crawled(key string) (cmn.SimpleKVs, error) {
+	prefix := path.Join(downloaderCrawled, key) + "/"
+	values, err := db.driver.GetAll(downloaderCollection, prefix)
+	if err != nil && !dbdriver.IsErrNotFound(err) {
+		return nil, err
+	}
+	crawled := make(cmn.SimpleKVs, len(values))
+	for k, v := range values {
+		crawled[strings.TrimPrefix(k, prefix)] = v
+	}
+	return crawled, nil
+}
+
+func (db *downloaderDB) persistCrawled(key, objName, validator string) error {
+	return db.driver.SetString(downloaderCollection, path.Join(downloaderCrawled, key, objName), validator)
+}
+
+// partial returns the partial download kept by a failed task (see `keepPartial`).
+func (db *downloaderDB) partial(key string) (*partialDownload, error) {
+	p := &partialDownload{}
+	if err := db.driver.Get(downloaderCollection, path.Join(downloaderPartials, key), p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// partials returns all partial downloads kept by failed tasks: key => partial download.
+func (db *downloaderDB) partials() (map[string]*partialDownload, error) {
+	prefix := downloaderPartials + "/"
+	keys, err := db.driver.List(downloaderCollection, prefix)
+	if err != nil && !dbdriver.IsErrNotFound(err) {
+		return nil, err
+	}
+	partials := make(map[string]*partialDownload, len(keys))
+	for _, key := range keys {
+		p := &partialDownload{}
+		if err := db.driver.Get(downloaderCollection, key, p); err != nil {
+			glog.Error(err)
+			continue
+		}
+		partials[strings.TrimPrefix(key, prefix)] = p
+	}
+	return partials, nil
+}
+
+func (db *downloaderDB) persistPartial(key string, p *partialDownload) error {
+	return db.driver.Set(downloaderCollection, path.Join(downloaderPartials, key), p)
+}
+
+func (db *downloaderDB) deletePartial(key string) {
+	db.driver.Delete(downloaderCollection, path.Join(downloaderPartials, key))
+}
@@ -46,12 +46,16 @@ type (
 	WebResource struct {
 		ObjName string
 		Link    string
+
+		expect *dlExpect
 	}
 
 	DstElement struct {
 		ObjName string
 		Version string
 		Link    string
+
+		expect *dlExpect // expected size and checksums (see `DlManifestBody`)
 	}
 
 	DiffResolverResult struct {
@@ -167,6 +171,7 @@ func (dr *DiffResolver) PushDst(v interface{}) {
 		d = &DstElement{
 			ObjName: x.ObjName,
 			Link:    x.Link,
+			expect:  x.expect,
 		}
 	default:
 		cmn.Assertf(false, "%T", x)
@@ -200,6 +205,11 @@ func (*defaultDiffResolverCtx) CompareObjects(src *cluster.LOM, dst *DstElement)
 		}
 		return false, err
 	}
+	if dst.expect != nil {
+		if equal, ok := dst.expect.compare(src); ok {
+			return equal, nil
+		}
+	}
 	return compareObjects(src, dst)
 }
 
@@ -237,6 +237,7 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 					diffResolver.PushDst(&WebResource{
 						ObjName: obj.objName,
 						Link:    obj.link,
+						expect:  obj.expect,
 					})
 				} else {
 					diffResolver.PushDst(&CloudResource{
@@ -260,6 +261,7 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 					objName:   dst.ObjName,
 					link:      dst.Link,
 					fromCloud: dst.Link == "",
+					expect:    dst.expect,
 				}
 			} else {
 				src := result.Src
@@ -275,6 +277,7 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 
 			if result.Action == DiffResolverSkip {
 				dlStore.incSkipped(job.ID())
+				job.objDone(obj.objName)
 				continue
 			}
 
@@ -423,6 +426,7 @@ func (d *dispatcher) handleRemove(req *request) {
 	}
 
 	dlStore.delJob(req.id)
+	removePartials(req.id)
 	req.writeResp(nil)
 }
 
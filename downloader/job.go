@@ -8,13 +8,17 @@ import (
 	"context"
 	"errors"
 	"path"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/nl"
+	"github.com/OneOfOne/xxhash"
 )
 
 const (
@@ -27,6 +31,7 @@ var (
 	_ DlJob = (*sliceDlJob)(nil)
 	_ DlJob = (*cloudBucketDlJob)(nil)
 	_ DlJob = (*rangeDlJob)(nil)
+	_ DlJob = (*crawlDlJob)(nil)
 )
 
 var errAISBckReq = errors.New("regular download requires ais bucket")
@@ -36,6 +41,7 @@ type (
 		objName   string
 		link      string
 		fromCloud bool
+		expect    *dlExpect // expected size and checksums, if any (see `DlManifestBody`)
 	}
 
 	DlJob interface {
@@ -63,6 +69,12 @@ type (
 
 		throttler() *throttler
 
+		// Called when object is downloaded or found to be up-to-date (skipped).
+		objDone(objName string)
+
+		// Parallel download of large objects (see DlChunks).
+		chunks() DlChunks
+
 		cleanup()
 	}
 
@@ -72,6 +84,7 @@ type (
 		timeout     time.Duration
 		description string
 		t           *throttler
+		dlChunks    DlChunks
 		dlXact      *Downloader
 
 		// notif
@@ -92,6 +105,10 @@ type (
 		*sliceDlJob
 	}
 
+	manifestDlJob struct {
+		*sliceDlJob
+	}
+
 	rangeDlJob struct {
 		baseDlJob
 		t     cluster.Target
@@ -116,6 +133,23 @@ type (
 		continuationToken string
 	}
 
+	crawlDlJob struct {
+		baseDlJob
+		t       cluster.Target
+		ctx     context.Context
+		crawler *crawler
+		dir     string // objects directory(prefix) from request
+		done    bool
+		objs    []dlObj // objects' metas which are ready to be downloaded
+
+		// Deduplication across runs (jobs): `key` identifies the crawled URL and
+		// the destination bucket, `crawled` - objects downloaded by previous runs.
+		key     string
+		crawled cmn.SimpleKVs // object name => source validator (ETag), if known
+		mtx     sync.Mutex
+		etags   cmn.SimpleKVs // object name => ETag (objects being downloaded)
+	}
+
 	downloadJobInfo struct {
 		ID          string `json:"id"`
 		Description string `json:"description"`
@@ -164,6 +198,8 @@ func (j *baseDlJob) ActiveStats() (*DlStatusResp, error) {
 }
 func (j *baseDlJob) checkObj(string) bool  { cmn.Assert(false); return false }
 func (j *baseDlJob) throttler() *throttler { return j.t }
+func (j *baseDlJob) objDone(string)        {}
+func (j *baseDlJob) chunks() DlChunks      { return j.dlChunks }
 func (j *baseDlJob) cleanup() {
 	j.throttler().stop()
 	dlStore.markFinished(j.ID())
@@ -171,7 +207,8 @@ func (j *baseDlJob) cleanup() {
 	nl.OnFinished(j.Notif(), nil)
 }
 
-func newBaseDlJob(t cluster.Target, id string, bck *cluster.Bck, timeout, desc string, limits DlLimits, dlXact *Downloader) *baseDlJob {
+func newBaseDlJob(t cluster.Target, id string, bck *cluster.Bck, timeout, desc string, limits DlLimits, chunks DlChunks,
+	dlXact *Downloader) *baseDlJob {
 	// TODO: this might be inaccurate if we download 1 or 2 objects because then
 	//  other targets will have limits but will not use them.
 	if limits.BytesPerHour > 0 {
@@ -185,6 +222,7 @@ func newBaseDlJob(t cluster.Target, id string, bck *cluster.Bck, timeout, desc s
 		timeout:     td,
 		description: desc,
 		t:           newThrottler(limits),
+		dlChunks:    chunks,
 		dlXact:      dlXact,
 	}
 }
@@ -214,7 +252,7 @@ func newMultiDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlMul
 		objs cmn.SimpleKVs
 		err  error
 	)
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, payload.Chunks, dlXact)
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
 	}
@@ -225,6 +263,19 @@ func newMultiDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlMul
 	return &multiDlJob{sliceDlJob}, nil
 }
 
+func newManifestDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlManifestBody,
+	dlXact *Downloader) (*manifestDlJob, error) {
+	if !bck.IsAIS() {
+		return nil, errAISBckReq
+	}
+	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, payload.Chunks, dlXact)
+	objs, err := buildManifestDlObjs(t, bck, payload.entries)
+	if err != nil {
+		return nil, err
+	}
+	return &manifestDlJob{&sliceDlJob{baseDlJob: *base, objs: objs}}, nil
+}
+
 func newSingleDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlSingleBody, dlXact *Downloader) (*singleDlJob, error) {
 	if !bck.IsAIS() {
 		return nil, errAISBckReq
@@ -234,7 +285,7 @@ func newSingleDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlSi
 		objs cmn.SimpleKVs
 		err  error
 	)
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, payload.Chunks, dlXact)
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
 	}
@@ -355,7 +406,7 @@ func newCloudBucketDlJob(ctx context.Context, t cluster.Target, id string, bck *
 	if !bck.IsCloud() {
 		return nil, errors.New("bucket download requires a cloud bucket")
 	}
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, payload.Chunks, dlXact)
 	job := &cloudBucketDlJob{
 		baseDlJob: *base,
 		t:         t,
@@ -408,7 +459,7 @@ func newRangeDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlRan
 		return nil, err
 	}
 
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, payload.Chunks, dlXact)
 	cnt, err := countObjects(t, pt, payload.Subdir, base.bck)
 	if err != nil {
 		return nil, err
@@ -423,6 +474,108 @@ func newRangeDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlRan
 	return job, nil
 }
 
+func newCrawlDlJob(ctx context.Context, t cluster.Target, id string, bck *cluster.Bck, payload *DlCrawlBody,
+	dlXact *Downloader) (*crawlDlJob, error) {
+	if !bck.IsAIS() {
+		return nil, errAISBckReq
+	}
+	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, payload.Chunks, dlXact)
+	crawler, err := newCrawler(payload, base.timeout)
+	if err != nil {
+		return nil, err
+	}
+	key := strconv.FormatUint(xxhash.ChecksumString64S(bck.MakeUname(payload.URL), cmn.MLCG32), 16)
+	crawled, err := dlStore.crawled(key)
+	if err != nil {
+		return nil, err
+	}
+	job := &crawlDlJob{
+		baseDlJob: *base,
+		t:         t,
+		ctx:       ctx,
+		crawler:   crawler,
+		dir:       payload.Subdir,
+		key:       key,
+		crawled:   crawled,
+		etags:     make(cmn.SimpleKVs),
+	}
+	return job, nil
+}
+
+func (j *crawlDlJob) Len() int { return -1 }
+func (j *crawlDlJob) genNext() ([]dlObj, bool, error) {
+	if j.done {
+		return nil, false, nil
+	}
+	if err := j.getNextObjs(); err != nil {
+		return nil, false, err
+	}
+	return j.objs, true, nil
+}
+
+// Crawls pages until any objects to download are found (so that they are
+// dispatched while crawling continues) or there is nothing left to crawl.
+func (j *crawlDlJob) getNextObjs() error {
+	var (
+		smap = j.t.Sowner().Get()
+		sid  = j.t.Snode().ID()
+	)
+	j.objs = j.objs[:0]
+	for len(j.objs) == 0 {
+		if j.crawler.done() {
+			j.done = true
+			break
+		}
+		crawled, err := j.crawler.next(j.ctx)
+		if err != nil {
+			return err
+		}
+		for _, c := range crawled {
+			obj, err := makeDlObj(smap, sid, j.bck, path.Join(j.dir, c.objName), c.link)
+			if err != nil {
+				if err == errInvalidTarget {
+					continue
+				}
+				return err
+			}
+			if j.isCrawled(obj.objName, c.etag) {
+				dlStore.incScheduled(j.ID())
+				dlStore.incSkipped(j.ID())
+				continue
+			}
+			j.mtx.Lock()
+			j.etags[obj.objName] = c.etag
+			j.mtx.Unlock()
+			j.objs = append(j.objs, obj)
+		}
+	}
+	return nil
+}
+
+// isCrawled returns true if the object has been downloaded by one of the previous
+// runs, has not changed since (if known), and is still present in the bucket.
+func (j *crawlDlJob) isCrawled(objName, etag string) bool {
+	prev, ok := j.crawled[objName]
+	if !ok || (etag != "" && etag != prev) {
+		return false
+	}
+	lom := &cluster.LOM{T: j.t, ObjName: objName}
+	if err := lom.Init(j.Bck()); err != nil {
+		return false
+	}
+	return lom.Load() == nil
+}
+
+func (j *crawlDlJob) objDone(objName string) {
+	j.mtx.Lock()
+	etag := j.etags[objName]
+	delete(j.etags, objName)
+	j.mtx.Unlock()
+	if err := dlStore.persistCrawled(j.key, objName, etag); err != nil {
+		glog.Error(err)
+	}
+}
+
 func (d *downloadJobInfo) ToDlJobInfo() DlJobInfo {
 	return DlJobInfo{
 		ID:            d.ID,
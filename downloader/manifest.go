@@ -0,0 +1,285 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/OneOfOne/xxhash"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Manifest formats (see `DlManifestBody`)
+const (
+	ManifestJSONL = "jsonl" // one JSON object (`DlManifestEntry`) per line
+	ManifestCSV   = "csv"   // header with column names (see `DlManifestEntry`) followed by rows
+	ManifestTSV   = "tsv"   // same as CSV, tab-separated
+)
+
+type (
+	// Single manifest entry: object to download along with its expected size and checksum(s).
+	// NOTE: unlike `cmn.ChecksumSHA256` (SHA-512/256), manifest's "sha256" is the standard
+	// SHA-256 - as published by datasets.
+	DlManifestEntry struct {
+		Link    string `json:"link"`
+		ObjName string `json:"object_name"` // default: the last element of the link's path
+		Size    *int64 `json:"size,omitempty"`
+		MD5     string `json:"md5,omitempty"`
+		SHA256  string `json:"sha256,omitempty"`
+		XXHash  string `json:"xxhash,omitempty"`
+	}
+
+	// Expected size and checksums of a downloaded object (see `verify`).
+	dlExpect struct {
+		size   int64         // -1 if not specified
+		cksums cmn.SimpleKVs // checksum type => expected value (lowercase hex)
+	}
+
+	// Downloaded content does not match the manifest - retried (see `downloadLocal`).
+	errMismatch struct {
+		what     string
+		expected string
+		got      string
+	}
+)
+
+//////////////
+// manifest //
+//////////////
+
+func parseManifest(manifest, format string) (entries []DlManifestEntry, err error) {
+	switch format {
+	case "", ManifestJSONL:
+		entries, err = parseManifestJSONL(manifest)
+	case ManifestCSV:
+		entries, err = parseManifestCSV(manifest, ',')
+	case ManifestTSV:
+		entries, err = parseManifestCSV(manifest, '\t')
+	default:
+		return nil, fmt.Errorf("invalid manifest format %q (expecting one of: %q, %q, %q)",
+			format, ManifestJSONL, ManifestCSV, ManifestTSV)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("manifest is empty")
+	}
+	for i := range entries {
+		if err := entries[i].validate(); err != nil {
+			return nil, fmt.Errorf("manifest entry #%d: %v", i+1, err)
+		}
+	}
+	return entries, nil
+}
+
+func parseManifestJSONL(manifest string) ([]DlManifestEntry, error) {
+	var (
+		entries = make([]DlManifestEntry, 0, 16)
+		scanner = bufio.NewScanner(strings.NewReader(manifest))
+		lineNum int
+	)
+	scanner.Buffer(nil, cmn.MiB)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry DlManifestEntry
+		if err := jsoniter.UnmarshalFromString(line, &entry); err != nil {
+			return nil, fmt.Errorf("manifest line %d: %v", lineNum, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// NOTE: columns other than the ones defined by `DlManifestEntry` are ignored.
+func parseManifestCSV(manifest string, delim rune) ([]DlManifestEntry, error) {
+	r := csv.NewReader(strings.NewReader(manifest))
+	r.Comma = delim
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	cols := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := cols["link"]; !ok {
+		return nil, errors.New("manifest header is missing 'link' column")
+	}
+	get := func(row []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	entries := make([]DlManifestEntry, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		entry := DlManifestEntry{
+			Link:    get(row, "link"),
+			ObjName: get(row, "object_name"),
+			MD5:     get(row, cmn.ChecksumMD5),
+			SHA256:  get(row, cmn.ChecksumSHA256),
+			XXHash:  get(row, cmn.ChecksumXXHash),
+		}
+		if s := get(row, "size"); s != "" {
+			size, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("manifest row %d: invalid size %q", i+1, s)
+			}
+			entry.Size = &size
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+/////////////////////
+// DlManifestEntry //
+/////////////////////
+
+func (e *DlManifestEntry) validate() error {
+	if e.Link == "" {
+		return errors.New("missing 'link'")
+	}
+	if e.ObjName == "" {
+		objName := path.Base(e.Link)
+		if objName == "." || objName == "/" {
+			return fmt.Errorf("can not extract a valid 'object_name' from the provided download 'link': %q", e.Link)
+		}
+		e.ObjName = objName
+	}
+	if e.Size != nil && *e.Size < 0 {
+		return fmt.Errorf("'size' must be non-negative (got: %d)", *e.Size)
+	}
+	for ty, value := range e.cksums() {
+		b, err := hex.DecodeString(value)
+		if err != nil || len(b) != newManifestHash(ty).Size() {
+			return fmt.Errorf("invalid %s checksum %q", ty, value)
+		}
+	}
+	return nil
+}
+
+func (e *DlManifestEntry) cksums() cmn.SimpleKVs {
+	cksums := make(cmn.SimpleKVs, 1)
+	for ty, value := range map[string]string{
+		cmn.ChecksumMD5: e.MD5, cmn.ChecksumSHA256: e.SHA256, cmn.ChecksumXXHash: e.XXHash,
+	} {
+		if value != "" {
+			cksums[ty] = strings.ToLower(value)
+		}
+	}
+	return cksums
+}
+
+func (e *DlManifestEntry) expect() *dlExpect {
+	exp := &dlExpect{size: -1, cksums: e.cksums()}
+	if e.Size != nil {
+		exp.size = *e.Size
+	}
+	if exp.size < 0 && len(exp.cksums) == 0 {
+		return nil
+	}
+	return exp
+}
+
+//////////////
+// dlExpect //
+//////////////
+
+func newManifestHash(ty string) hash.Hash {
+	switch ty {
+	case cmn.ChecksumMD5:
+		return md5.New()
+	case cmn.ChecksumSHA256:
+		return sha256.New()
+	case cmn.ChecksumXXHash:
+		return xxhash.New64()
+	default:
+		cmn.Assertf(false, "%q", ty)
+		return nil
+	}
+}
+
+// checkSize is a shortcut to fail early, prior to downloading (see `tryDownloadLocal`).
+func (e *dlExpect) checkSize(size int64) error {
+	if e.size >= 0 && size != e.size {
+		return &errMismatch{what: "size", expected: strconv.FormatInt(e.size, 10), got: strconv.FormatInt(size, 10)}
+	}
+	return nil
+}
+
+// verify validates the content downloaded into a given workfile.
+func (e *dlExpect) verify(fqn string) error {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+	var (
+		hashes  = make(map[string]hash.Hash, len(e.cksums))
+		writers = make([]io.Writer, 0, len(e.cksums))
+	)
+	for ty := range e.cksums {
+		h := newManifestHash(ty)
+		hashes[ty] = h
+		writers = append(writers, h)
+	}
+	size, err := io.Copy(io.MultiWriter(writers...), fh)
+	if err != nil {
+		return err
+	}
+	if err := e.checkSize(size); err != nil {
+		return err
+	}
+	for ty, h := range hashes {
+		if got := hex.EncodeToString(h.Sum(nil)); got != e.cksums[ty] {
+			return &errMismatch{what: ty, expected: e.cksums[ty], got: got}
+		}
+	}
+	return nil
+}
+
+// compare checks existing object against the expectations; `ok` is false if
+// inconclusive - when the object's checksum is of a different type.
+func (e *dlExpect) compare(lom *cluster.LOM) (equal, ok bool) {
+	if e.size >= 0 && lom.Size() != e.size {
+		return false, true
+	}
+	cksum := lom.Cksum()
+	if cksum.IsEmpty() || cksum.Type() == cmn.ChecksumSHA256 { // (not the same SHA-256, see above)
+		return false, false
+	}
+	if value, exists := e.cksums[cksum.Type()]; exists {
+		return value == cksum.Value(), true
+	}
+	return false, false
+}
+
+func (e *errMismatch) Error() string {
+	return fmt.Sprintf("%s mismatch: expected %s, got %s", e.what, e.expected, e.got)
+}
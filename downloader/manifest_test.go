@@ -0,0 +1,84 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+const (
+	helloMD5    = "5eb63bbbe01eeed093cb22bb8f5acdc3"
+	helloSHA256 = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+)
+
+func TestParseManifest(t *testing.T) {
+	tests := []struct {
+		manifest string
+		format   string
+		valid    bool
+	}{
+		{`{"link": "http://a.com/x/a.tar", "size": 11, "md5": "` + helloMD5 + `"}`, ManifestJSONL, true},
+		{"\n" + `{"link": "http://a.com/b", "object_name": "obj"}` + "\n\n", "", true},
+		{"link,object_name,size,sha256,comment\nhttp://a.com/a,obj,11," + helloSHA256 + ",ignored\n", ManifestCSV, true},
+		{"link\tsize\nhttp://a.com/a\t11\n", ManifestTSV, true},
+
+		{"", ManifestJSONL, false},
+		{`{"link": "http://a.com/a"}`, "xml", false},
+		{`{"object_name": "obj"}`, ManifestJSONL, false},
+		{`{"link": "http://a.com/a", "size": -1}`, ManifestJSONL, false},
+		{`{"link": "http://a.com/a", "md5": "abc"}`, ManifestJSONL, false},
+		{`{"link": "http://a.com/a", "md5": "` + helloSHA256 + `"}`, ManifestJSONL, false},
+		{"object_name,size\nobj,11\n", ManifestCSV, false},
+		{"link,size\nhttp://a.com/a,eleven\n", ManifestCSV, false},
+	}
+	for _, test := range tests {
+		_, err := parseManifest(test.manifest, test.format)
+		if test.valid {
+			tassert.Errorf(t, err == nil, "expected manifest %q to be valid, got: %v", test.manifest, err)
+		} else {
+			tassert.Errorf(t, err != nil, "expected manifest %q to be invalid", test.manifest)
+		}
+	}
+
+	entries, err := parseManifest("link,md5\nhttp://a.com/x/a.tar,"+helloMD5+"\n", ManifestCSV)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(entries) == 1, "expected 1 entry, got %d", len(entries))
+	tassert.Errorf(t, entries[0].ObjName == "a.tar", "expected object name %q, got %q", "a.tar", entries[0].ObjName)
+	exp := entries[0].expect()
+	tassert.Fatalf(t, exp != nil, "expected checksum to be set")
+	tassert.Errorf(t, exp.size == -1, "expected size to be unknown, got %d", exp.size)
+}
+
+func TestManifestVerify(t *testing.T) {
+	fh, err := ioutil.TempFile("", "")
+	tassert.CheckFatal(t, err)
+	defer os.Remove(fh.Name())
+	_, err = fh.WriteString("hello world")
+	tassert.CheckFatal(t, err)
+	fh.Close()
+
+	size := int64(11)
+	entry := DlManifestEntry{Link: "http://a.com/a", Size: &size, MD5: helloMD5, SHA256: helloSHA256}
+	tassert.CheckFatal(t, entry.validate())
+	tassert.CheckError(t, entry.expect().verify(fh.Name()))
+
+	size = 12
+	err = entry.expect().verify(fh.Name())
+	_, ok := err.(*errMismatch)
+	tassert.Errorf(t, ok, "expected size mismatch, got: %v", err)
+
+	size = 11
+	entry.MD5 = "00000000000000000000000000000000"
+	err = entry.expect().verify(fh.Name())
+	_, ok = err.(*errMismatch)
+	tassert.Errorf(t, ok, "expected checksum mismatch, got: %v", err)
+
+	entry = DlManifestEntry{Link: "http://a.com/a"}
+	tassert.Errorf(t, entry.expect() == nil, "expected nothing to verify")
+}
@@ -0,0 +1,428 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/hk"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ================================ Schedule ===================================
+//
+// A download request with non-empty `DlBase.Schedule` is not executed right
+// away. Instead, the request is kept by all proxies (see ais/prxdl.go) and the
+// primary runs it on schedule, every time as a new (child) download job with
+// ID `<schedule ID>-<scheduled time>` started on the targets of the current
+// Smap and registered with IC - the same way as any other download job.
+//
+// Each run is a regular download job and, as such, downloads only the objects
+// that are missing or differ from the source (see `DiffResolver`).
+//
+// Schedule is either:
+//   * cron expression with 5 fields: "minute hour day-of-month month day-of-week"
+//     where each field is "*", a number, a range ("1-5"), or a list thereof
+//     ("1,3,10-12"), optionally followed by a step ("*/15", "0-30/10");
+//   * one of the predefined: @yearly (@annually), @monthly, @weekly,
+//     @daily (@midnight), @hourly;
+//   * "@every <duration>", e.g., "@every 6h" (runs are aligned to the multiples
+//     of the duration since Unix epoch).
+//
+// ================================ Schedule ===================================
+
+const (
+	scheduleEvery    = "@every "
+	scheduleMinEvery = time.Minute
+
+	scheduledRunFmt = "20060102T1504"
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronFields = [...]struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day-of-month", 1, 31},
+	{"month", 1, 12},
+	{"day-of-week", 0, 7}, // both 0 and 7 are Sunday
+}
+
+type (
+	cronSchedule struct {
+		minute, hour, dom, month, dow uint64 // bitmasks
+		domStar, dowStar              bool
+		every                         time.Duration
+	}
+
+	// ScheduledJob is a recurring download job along with its schedule.
+	ScheduledJob struct {
+		Info DlJobInfo `json:"info"`
+		Body DlBody    `json:"body"`
+
+		cron *cronSchedule
+	}
+	ScheduledJobs []*ScheduledJob
+
+	// SchedRunner is implemented by the proxy that owns the Scheduler.
+	SchedRunner interface {
+		// only the primary starts scheduled runs
+		IsPrimary() bool
+		// starts a run as a regular download job on all targets
+		StartRun(childID string, dlb DlBody) error
+		RunInProgress(childID string) bool
+		// persists the jobs and, if primary, replicates them to other proxies
+		SaveSchedules(sjs ScheduledJobs)
+	}
+
+	Scheduler struct {
+		mtx    sync.Mutex
+		runner SchedRunner
+		jobs   map[string]*ScheduledJob
+	}
+)
+
+//////////////////
+// cronSchedule //
+//////////////////
+
+func parseSchedule(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, scheduleEvery) {
+		every, err := time.ParseDuration(strings.TrimSpace(expr[len(scheduleEvery):]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %v", expr, err)
+		}
+		if every < scheduleMinEvery {
+			return nil, fmt.Errorf("invalid schedule %q: must be at least %v", expr, scheduleMinEvery)
+		}
+		return &cronSchedule{every: every}, nil
+	}
+	if cron, ok := cronDescriptors[expr]; ok {
+		expr = cron
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields (minute hour day-of-month month day-of-week)",
+			expr)
+	}
+	var (
+		cs   = &cronSchedule{}
+		bits = [...]*uint64{&cs.minute, &cs.hour, &cs.dom, &cs.month, &cs.dow}
+	)
+	for i, field := range fields {
+		b, err := parseCronField(field, i)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %v", expr, err)
+		}
+		*bits[i] = b
+	}
+	if cs.dow&(1<<7) != 0 {
+		cs.dow |= 1
+	}
+	cs.domStar = strings.HasPrefix(fields[2], "*")
+	cs.dowStar = strings.HasPrefix(fields[4], "*")
+	if cs.next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("invalid schedule %q: never runs", expr)
+	}
+	return cs, nil
+}
+
+func parseCronField(field string, idx int) (bits uint64, err error) {
+	f := cronFields[idx]
+	for _, part := range strings.Split(field, ",") {
+		var (
+			lo, hi = f.min, f.max
+			step   = 1
+			rng    = part
+		)
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid %s step in %q", f.name, part)
+			}
+			rng = part[:i]
+		}
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			i := strings.IndexByte(rng, '-')
+			if lo, err = strconv.Atoi(rng[:i]); err != nil {
+				return 0, fmt.Errorf("invalid %s %q", f.name, part)
+			}
+			if hi, err = strconv.Atoi(rng[i+1:]); err != nil {
+				return 0, fmt.Errorf("invalid %s %q", f.name, part)
+			}
+		default:
+			if lo, err = strconv.Atoi(rng); err != nil {
+				return 0, fmt.Errorf("invalid %s %q", f.name, part)
+			}
+			if step == 1 {
+				hi = lo
+			}
+		}
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, fmt.Errorf("%s %q out of range [%d, %d]", f.name, part, f.min, f.max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// next returns the earliest scheduled time strictly after the given one
+// (zero time if there's none within the next few years).
+func (cs *cronSchedule) next(after time.Time) time.Time {
+	if cs.every > 0 {
+		// NOTE: not `after.Truncate(cs.every)` - it aligns to Go's zero time
+		every := int64(cs.every)
+		return time.Unix(0, (after.UnixNano()/every+1)*every).In(after.Location())
+	}
+	var (
+		t     = after.UTC()
+		limit = t.AddDate(5, 0, 0)
+	)
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, time.UTC)
+	for t.Before(limit) {
+		switch {
+		case cs.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !cs.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case cs.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+		case cs.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// as in cron: if both day-of-month and day-of-week are restricted, either one matches
+func (cs *cronSchedule) dayMatches(t time.Time) bool {
+	var (
+		dom = cs.dom&(1<<uint(t.Day())) != 0
+		dow = cs.dow&(1<<uint(t.Weekday())) != 0
+	)
+	if cs.domStar || cs.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+///////////////
+// Scheduler //
+///////////////
+
+// NewScheduler returns scheduler of recurring download jobs; the schedules
+// are set (upon startup) and updated by the caller (see Sync).
+func NewScheduler(runner SchedRunner) *Scheduler {
+	return &Scheduler{runner: runner, jobs: make(map[string]*ScheduledJob)}
+}
+
+// Add validates and adds recurring download job.
+func (s *Scheduler) Add(id string, dlb DlBody) error {
+	var base DlBase
+	body, err := parseDlBody(dlb)
+	if err != nil {
+		return err
+	}
+	if err := jsoniter.Unmarshal(dlb.RawMessage, &base); err != nil {
+		return err
+	}
+	cron, err := parseSchedule(base.Schedule)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	sj := &ScheduledJob{
+		Info: DlJobInfo{
+			ID:          id,
+			Description: body.Describe(),
+			StartedTime: now,
+			Schedule:    &DlScheduleInfo{Schedule: base.Schedule, NextRun: cron.next(now)},
+		},
+		Body: dlb,
+		cron: cron,
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.jobs[id]; ok {
+		return fmt.Errorf("download job %q is already scheduled", id)
+	}
+	s.jobs[id] = sj
+	s.reg(sj)
+	s.runner.SaveSchedules(s.all())
+	return nil
+}
+
+// Remove removes recurring download job; returns false if there's no such job.
+func (s *Scheduler) Remove(id string) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.jobs[id]; !ok {
+		return false
+	}
+	delete(s.jobs, id)
+	hk.Unreg(hkName(id))
+	s.runner.SaveSchedules(s.all())
+	return true
+}
+
+func (s *Scheduler) Exists(id string) bool {
+	s.mtx.Lock()
+	_, ok := s.jobs[id]
+	s.mtx.Unlock()
+	return ok
+}
+
+// Sync replaces all recurring download jobs with the given ones - e.g., upon
+// startup or when the jobs are updated by the primary.
+func (s *Scheduler) Sync(sjs ScheduledJobs) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	jobs := make(map[string]*ScheduledJob, len(sjs))
+	for _, sj := range sjs {
+		var err error
+		if sj.cron, err = parseSchedule(sj.Info.Schedule.Schedule); err != nil {
+			glog.Errorf("scheduled download %q: %v", sj.Info.ID, err)
+			continue
+		}
+		jobs[sj.Info.ID] = sj
+	}
+	for id := range s.jobs {
+		if _, ok := jobs[id]; !ok {
+			hk.Unreg(hkName(id))
+		}
+	}
+	for id, sj := range jobs {
+		if _, ok := s.jobs[id]; !ok {
+			s.reg(sj)
+		}
+	}
+	s.jobs = jobs
+}
+
+// List returns recurring download jobs with the most recent runs as of the
+// last sync (see DlScheduleInfo.LastJob).
+func (s *Scheduler) List(regex *regexp.Regexp) []DlJobInfo {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	list := make([]DlJobInfo, 0, len(s.jobs))
+	for _, sj := range s.jobs {
+		if regex != nil && !regex.MatchString(sj.Info.Description) {
+			continue
+		}
+		info, sched := sj.Info, *sj.Info.Schedule
+		if sched.LastJob != nil {
+			lastJob := *sched.LastJob
+			sched.LastJob = &lastJob
+		}
+		info.Schedule = &sched
+		list = append(list, info)
+	}
+	return list
+}
+
+// NOTE: must be called under lock
+func (s *Scheduler) all() ScheduledJobs {
+	sjs := make(ScheduledJobs, 0, len(s.jobs))
+	for _, sj := range s.jobs {
+		sjs = append(sjs, sj)
+	}
+	return sjs
+}
+
+func (s *Scheduler) reg(sj *ScheduledJob) {
+	id := sj.Info.ID
+	hk.Reg(hkName(id), func() time.Duration { return s.run(id) }, time.Until(sj.Info.Schedule.NextRun))
+}
+
+func hkName(id string) string { return "download-schedule-" + id }
+
+// run is the housekeeping callback that starts scheduled runs.
+func (s *Scheduler) run(id string) time.Duration {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sj, ok := s.jobs[id]
+	if !ok {
+		return hk.DayInterval // removed in the meantime
+	}
+	var (
+		sched = sj.Info.Schedule
+		now   = time.Now()
+	)
+	if now.Before(sched.NextRun) {
+		return time.Until(sched.NextRun)
+	}
+	if !s.runner.IsPrimary() {
+		// the primary runs the job and updates the schedule - see Sync
+		return untilNext(sj.cron.next(now))
+	}
+
+	// if some runs were missed (e.g., while the cluster was down) run only the latest
+	slot := sched.NextRun
+	for next := sj.cron.next(slot); !next.IsZero() && !next.After(now); next = sj.cron.next(next) {
+		slot = next
+	}
+	if last := sched.LastJob; last != nil && sched.LastErr == "" && s.runner.RunInProgress(last.ID) {
+		glog.Warningf("scheduled download %q: previous run %q is still running - skipping %v", id, last.ID, slot)
+	} else {
+		childID := id + "-" + slot.UTC().Format(scheduledRunFmt)
+		sched.LastRun = now
+		sched.LastJob = &DlJobInfo{ID: childID, Description: sj.Info.Description, StartedTime: now}
+		sched.LastErr = ""
+		sched.Runs++
+		go s.start(id, childID, sj.Body)
+	}
+	sched.NextRun = sj.cron.next(now)
+	s.runner.SaveSchedules(s.all())
+	return untilNext(sched.NextRun)
+}
+
+func untilNext(next time.Time) time.Duration {
+	if next.IsZero() {
+		return hk.DayInterval
+	}
+	return time.Until(next)
+}
+
+func (s *Scheduler) start(id, childID string, dlb DlBody) {
+	err := s.runner.StartRun(childID, dlb)
+	if err == nil {
+		glog.Infof("scheduled download %q: started %q", id, childID)
+		return
+	}
+	glog.Errorf("scheduled download %q: failed to start %q: %v", id, childID, err)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sj, ok := s.jobs[id]
+	if !ok || sj.Info.Schedule.LastJob == nil || sj.Info.Schedule.LastJob.ID != childID {
+		return
+	}
+	sj.Info.Schedule.LastErr = err.Error()
+	s.runner.SaveSchedules(s.all())
+}
@@ -0,0 +1,109 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+func init() {
+	go hk.DefaultHK.Run()
+}
+
+func TestParseSchedule(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "*/0 * * * *",
+		"a * * * *", "5-1 * * * *", "0 0 30 2 *", "@every 10s", "@every x", "@sometimes"} {
+		_, err := parseSchedule(expr)
+		tassert.Errorf(t, err != nil, "expected %q to fail", expr)
+	}
+	for _, expr := range []string{"* * * * *", "0 3 * * *", "*/15 0-6,22,23 1-31/2 * 1-5", "0 0 * * 7", "@daily",
+		"@every 6h"} {
+		_, err := parseSchedule(expr)
+		tassert.Errorf(t, err == nil, "expected %q to succeed, err: %v", expr, err)
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	from := time.Date(2020, time.December, 31, 23, 30, 15, 0, time.UTC) // Thursday
+	tests := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2020, time.December, 31, 23, 31, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2021, time.January, 1, 3, 0, 0, 0, time.UTC)},
+		{"*/20 23 * * *", time.Date(2020, time.December, 31, 23, 40, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC)},
+		// both day-of-month and day-of-week restricted: either one matches
+		{"0 0 15 * 6", time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{"@every 2h", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 7m", time.Date(2020, time.December, 31, 23, 34, 0, 0, time.UTC)},
+		// aligned to Unix epoch (Thursday)
+		{"@every 168h", time.Date(2021, time.January, 7, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		cs, err := parseSchedule(test.expr)
+		tassert.CheckFatal(t, err)
+		next := cs.next(from)
+		tassert.Errorf(t, next.Equal(test.next), "%q: expected %v, got %v", test.expr, test.next, next)
+	}
+}
+
+type schedRunnerMock struct {
+	primary bool
+	running bool
+	started chan string
+	saved   ScheduledJobs
+}
+
+func (r *schedRunnerMock) IsPrimary() bool                 { return r.primary }
+func (r *schedRunnerMock) RunInProgress(string) bool       { return r.running }
+func (r *schedRunnerMock) SaveSchedules(sjs ScheduledJobs) { r.saved = sjs }
+func (r *schedRunnerMock) StartRun(childID string, _ DlBody) error {
+	r.started <- childID
+	return nil
+}
+
+func TestSchedulerRun(t *testing.T) {
+	var (
+		runner = &schedRunnerMock{started: make(chan string, 1)}
+		s      = NewScheduler(runner)
+		dlb    = DlBody{Type: DlTypeSingle}
+	)
+	dlb.RawMessage = []byte(`{"bucket": {"name": "bck"}, "link": "http://example.com/obj", "object_name": "obj",
+		"schedule": "@every 1m"}`)
+	tassert.CheckFatal(t, s.Add("sched", dlb))
+	tassert.Fatalf(t, len(runner.saved) == 1, "expected the schedule to be saved")
+	tassert.Errorf(t, s.Add("sched", dlb) != nil, "expected the job to be scheduled only once")
+
+	// not primary: the run is left to the primary
+	sj := runner.saved[0]
+	sj.Info.Schedule.NextRun = time.Now().Add(-time.Second)
+	s.run("sched")
+	tassert.Errorf(t, sj.Info.Schedule.Runs == 0 && len(runner.started) == 0, "expected no run")
+
+	runner.primary = true
+	s.run("sched")
+	childID := <-runner.started
+	tassert.Errorf(t, sj.Info.Schedule.Runs == 1 && sj.Info.Schedule.LastJob.ID == childID,
+		"expected run %q, got %+v", childID, sj.Info.Schedule)
+	tassert.Errorf(t, sj.Info.Schedule.NextRun.After(time.Now()), "expected the next run to be scheduled")
+
+	// previous run still in progress
+	runner.running = true
+	sj.Info.Schedule.NextRun = time.Now().Add(-time.Second)
+	s.run("sched")
+	tassert.Errorf(t, sj.Info.Schedule.Runs == 1, "expected the run to be skipped")
+
+	// the primary has removed the job
+	s.Sync(nil)
+	tassert.Errorf(t, !s.Exists("sched"), "expected the job to be removed")
+}
@@ -11,18 +11,24 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/OneOfOne/xxhash"
 )
 
 const (
 	retryCnt         = 10               // number of retries to external resource
+	mismatchRetryCnt = 3                // number of retries when content does not match the manifest
 	reqTimeoutFactor = 1.2              // newTimeout = prevTimeout * reqTimeoutFactor
 	headReqTimeout   = 15 * time.Second // timeout for HEAD request to get the Content-Length
 	internalErrorMsg = "internal server error"
@@ -52,10 +58,38 @@ type (
 
 		currentSize atomic.Int64 // the current size of the file (updated as the download progresses)
 		totalSize   atomic.Int64 // the total size of the file (nonzero only if Content-Length header was provided by the source of the file)
+		resumed     atomic.Int64 // the number of bytes that were not downloaded again upon retries (see `partialDownload`)
+
+		partial *partialDownload // content downloaded so far (nil if the source does not support ranges)
 
 		downloadCtx context.Context    // context with cancel function
 		cancelFunc  context.CancelFunc // used to cancel the download after the request commences
 	}
+
+	// partialDownload is a workfile that holds the content downloaded so far -
+	// to resume with HTTP `Range` request upon failure. The source is
+	// expected to advertise `Accept-Ranges` and provide ETag or Last-Modified
+	// to make sure that the resumed download continues the same content.
+	// When the task fails, the workfile is kept for the next task downloading
+	// the same link into the same object (see `keepPartial`) - until either
+	// the object is downloaded or the job is removed.
+	partialDownload struct {
+		JobID     string        `json:"job_id"`
+		Link      string        `json:"link"`
+		FQN       string        `json:"fqn"`
+		Validator string        `json:"validator"` // ETag or Last-Modified (see `sourceValidator`)
+		MD        cmn.SimpleKVs `json:"-"`         // custom metadata of the source object (see `roiFromLink`)
+
+		key  string // see `partialKey`
+		fh   *os.File
+		size int64
+	}
+
+	// offsetWriter writes into a file starting at a given offset (see `downloadChunk`).
+	offsetWriter struct {
+		fh  *os.File
+		off int64
+	}
 )
 
 func (t *singleObjectTask) download() {
@@ -88,6 +122,7 @@ func (t *singleObjectTask) download() {
 	}
 
 	dlStore.incFinished(t.id())
+	t.job.objDone(t.obj.objName)
 
 	t.parent.statsT.AddMany(
 		stats.NamedVal64{Name: stats.DownloadSize, Value: t.currentSize.Load()},
@@ -112,6 +147,13 @@ func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Durat
 		req.Header.Add("User-Agent", cmn.GcsUA)
 	}
 
+	// Resume partial download (if any) unless the source has changed.
+	p := t.partial
+	if p != nil {
+		req.Header.Set(cmn.HeaderRange, fmt.Sprintf("%s%d-", cmn.HeaderRangeValPrefix, p.size))
+		req.Header.Set(cmn.HeaderIfRange, p.Validator)
+	}
+
 	resp, err := clientForURL(t.obj.link).Do(req)
 	if err != nil {
 		return err
@@ -119,20 +161,68 @@ func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Durat
 	defer cmn.Close(resp.Body)
 
 	if resp.StatusCode >= http.StatusBadRequest {
+		if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			t.removePartial()
+		}
 		return fmt.Errorf("request failed with %d status code (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
 	}
 
-	var (
-		r   = t.wrapReader(ctx, resp.Body)
-		roi = roiFromLink(t.obj.link, resp)
-	)
+	if p != nil {
+		if resp.StatusCode == http.StatusPartialContent {
+			if start, ok := parseContentRangeStart(resp.Header.Get(cmn.HeaderContentRange)); !ok || start != p.size ||
+				!sameSource(resp, p.Validator) {
+				t.removePartial()
+				return fmt.Errorf("failed to resume download at offset %d (%s: %q)",
+					p.size, cmn.HeaderContentRange, resp.Header.Get(cmn.HeaderContentRange))
+			}
+			if p.MD == nil { // resuming download of a previous task (see `loadPartial`)
+				p.MD = roiFromLink(t.obj.link, resp).md
+			}
+			t.resumed.Add(p.size)
+			return t.finishPartial(ctx, lom, resp.Body)
+		}
+		// the source has changed (or ignored the range) - start over
+		glog.Warningf("%s: source changed or does not support ranges (status %d), restarting download", t, resp.StatusCode)
+		t.removePartial()
+		t.reset()
+	}
 
+	roi := roiFromLink(t.obj.link, resp)
 	t.setTotalSize(roi.size)
+	if t.obj.expect != nil && roi.size > 0 {
+		if err := t.obj.expect.checkSize(roi.size); err != nil {
+			return err
+		}
+	}
+
+	// When the source supports ranges, download into a workfile first - to be
+	// able to resume upon failure - or, if configured, download large object
+	// in chunks concurrently.
+	if validator := sourceValidator(resp); validator != "" {
+		if n := t.numChunks(roi.size); n > 1 {
+			cmn.Close(resp.Body) // not needed, each chunk is requested separately
+			return t.downloadChunks(ctx, lom, n, validator, roi)
+		}
+		if err := t.createPartial(lom, validator, roi.md); err != nil {
+			return err
+		}
+		return t.finishPartial(ctx, lom, resp.Body)
+	}
+
+	// The content must be validated against the manifest prior to PUT.
+	if t.obj.expect != nil {
+		if err := t.createPartial(lom, "", roi.md); err != nil {
+			return err
+		}
+		err := t.finishPartial(ctx, lom, resp.Body)
+		t.removePartial() // cannot resume
+		return err
+	}
 
 	lom.SetCustomMD(roi.md)
 	params := cluster.PutObjectParams{
 		Tag:          "dl",
-		Reader:       r,
+		Reader:       t.wrapReader(ctx, resp.Body),
 		RecvType:     cluster.ColdGet,
 		Started:      t.started.Load(),
 		WithFinalize: true,
@@ -148,9 +238,19 @@ func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Durat
 
 func (t *singleObjectTask) downloadLocal(lom *cluster.LOM) (err error) {
 	var (
-		httpErr = &cmn.HTTPError{}
-		timeout = t.initialTimeout()
+		httpErr     = &cmn.HTTPError{}
+		mismatchErr = &errMismatch{}
+		mismatches  int
+		timeout     = t.initialTimeout()
 	)
+	t.loadPartial(lom)
+	defer func() {
+		if err != nil {
+			t.keepPartial()
+		} else {
+			t.removePartial()
+		}
+	}()
 	for i := 0; i < retryCnt; i++ {
 		err = t.tryDownloadLocal(lom, timeout)
 		if err == nil {
@@ -158,6 +258,12 @@ func (t *singleObjectTask) downloadLocal(lom *cluster.LOM) (err error) {
 		} else if errors.Is(err, context.Canceled) || errors.Is(err, errThrottlerStopped) {
 			// Download was canceled or stopped, so just return.
 			return err
+		} else if errors.As(err, &mismatchErr) {
+			mismatches++
+			glog.Warningf("%s [retries: %d/%d]: %v", t, mismatches, mismatchRetryCnt, err)
+			if mismatches >= mismatchRetryCnt {
+				return fmt.Errorf("%v (attempts: %d)", err, mismatches)
+			}
 		} else if errors.Is(err, context.DeadlineExceeded) {
 			glog.Warningf("%s [retries: %d/%d]: context exceeded with timeout (%v), increasing and retrying...", t, i, retryCnt, timeout)
 			timeout = time.Duration(float64(timeout) * reqTimeoutFactor)
@@ -174,11 +280,292 @@ func (t *singleObjectTask) downloadLocal(lom *cluster.LOM) (err error) {
 			glog.Warningf("%s [retries: %d/%d]: unexpected error (%v), retrying...", t, i, retryCnt, err)
 		}
 
-		t.reset()
+		if t.partial == nil {
+			t.reset()
+		} else {
+			t.currentSize.Store(t.partial.size) // to resume from
+		}
+	}
+	return
+}
+
+// createPartial creates a workfile to download the object into (see `tryDownloadLocal`).
+func (t *singleObjectTask) createPartial(lom *cluster.LOM, validator string, md cmn.SimpleKVs) error {
+	fqn := fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfileDownload)
+	fh, err := lom.CreateFile(fqn)
+	if err != nil {
+		return err
+	}
+	t.partial = &partialDownload{
+		JobID:     t.id(),
+		Link:      t.obj.link,
+		FQN:       fqn,
+		Validator: validator,
+		MD:        md,
+		key:       partialKey(lom),
+		fh:        fh,
+	}
+	return nil
+}
+
+// loadPartial picks up the workfile kept by a previously failed task that
+// downloaded the same link into the same object (see `keepPartial`), if any.
+func (t *singleObjectTask) loadPartial(lom *cluster.LOM) {
+	key := partialKey(lom)
+	p, err := dlStore.partial(key)
+	if err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			glog.Error(err)
+		}
+		return
+	}
+	p.key = key
+	t.partial = p
+	if p.Link != t.obj.link {
+		t.removePartial() // the object is now downloaded from a different source
+		return
+	}
+	if p.fh, err = os.OpenFile(p.FQN, os.O_WRONLY|os.O_APPEND, 0); err == nil {
+		var finfo os.FileInfo
+		if finfo, err = p.fh.Stat(); err == nil {
+			p.size = finfo.Size()
+		}
+	}
+	if err != nil {
+		glog.Warningf("%s: cannot resume from %s, err: %v", t, p.FQN, err)
+		t.removePartial()
+		return
+	}
+	p.JobID = t.id()
+	t.currentSize.Store(p.size)
+	if glog.V(4) {
+		glog.Infof("%s: resuming partial download of %d bytes", t, p.size)
+	}
+}
+
+// keepPartial persists the workfile of a failed task so that the next task
+// downloading the same link into the same object could resume from it.
+func (t *singleObjectTask) keepPartial() {
+	p := t.partial
+	if p == nil {
+		return
+	}
+	if p.fh != nil {
+		cmn.Close(p.fh)
+		p.fh = nil
+	}
+	if p.Validator == "" || p.size == 0 {
+		t.removePartial() // nothing to resume
+		return
+	}
+	if err := dlStore.persistPartial(p.key, p); err != nil {
+		glog.Error(err)
+		t.removePartial()
+		return
+	}
+	t.partial = nil
+}
+
+// finishPartial appends the remaining content to the workfile and, once
+// the content is complete, PUTs the object. Upon failure, the workfile is
+// kept to resume from.
+func (t *singleObjectTask) finishPartial(ctx context.Context, lom *cluster.LOM, body io.ReadCloser) error {
+	var (
+		p      = t.partial
+		r      = t.wrapReader(ctx, body)
+		n, err = io.Copy(p.fh, r)
+	)
+	p.size += n
+	if err != nil {
+		return err
+	}
+	if total := t.totalSize.Load(); total > 0 && p.size != total {
+		t.removePartial()
+		return fmt.Errorf("downloaded size %d does not match the expected %d", p.size, total)
+	}
+	err = p.fh.Close()
+	p.fh = nil
+	if err == nil {
+		err = t.putWorkfile(lom, p.FQN, p.MD)
+	}
+	t.removePartial()
+	return err
+}
+
+// putWorkfile PUTs the object downloaded into a given workfile - the checksum
+// gets computed (and, if configured, validated) once, over the entire content.
+// The content is validated against the manifest (if any) prior to PUT.
+func (t *singleObjectTask) putWorkfile(lom *cluster.LOM, fqn string, md cmn.SimpleKVs) error {
+	if t.obj.expect != nil {
+		if err := t.obj.expect.verify(fqn); err != nil {
+			return err
+		}
+	}
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return err
+	}
+	lom.SetCustomMD(md)
+	params := cluster.PutObjectParams{
+		Tag:          "dl",
+		Reader:       fh, // NOTE: closed by PutObject
+		RecvType:     cluster.ColdGet,
+		Started:      t.started.Load(),
+		WithFinalize: true,
+	}
+	if _, err = t.parent.t.PutObject(lom, params); err != nil {
+		return err
+	}
+	return lom.Load()
+}
+
+// numChunks returns the number of ranges to download a given object in
+// (see DlChunks) and acquires the corresponding additional connections -
+// as many as available.
+func (t *singleObjectTask) numChunks(size int64) (n int) {
+	conf := t.job.chunks()
+	minSize := conf.MinSize
+	if minSize == 0 {
+		minSize = DlChunkMinSize
+	}
+	if conf.Count <= 1 || size < minSize {
+		return 1
+	}
+	for n = 1; n < conf.Count && int64(n) < size/cmn.MiB; n++ {
+		if !t.job.throttler().tryAcquire() {
+			break
+		}
 	}
 	return
 }
 
+// downloadChunks downloads an object of a known size by splitting it into
+// ranges that are fetched concurrently into one workfile.
+func (t *singleObjectTask) downloadChunks(ctx context.Context, lom *cluster.LOM, n int, validator string,
+	roi remoteObjInfo) (err error) {
+	var (
+		wg        = &sync.WaitGroup{}
+		errCh     = make(chan error, n)
+		chunkSize = (roi.size + int64(n) - 1) / int64(n)
+		fqn       = fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfileDownload)
+	)
+	defer func() {
+		for i := 1; i < n; i++ {
+			t.job.throttler().release()
+		}
+	}()
+	fh, err := lom.CreateFile(fqn)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if errRm := cmn.RemoveFile(fqn); errRm != nil {
+			glog.Errorf("%s: failed to remove %s, err: %v", t, fqn, errRm)
+		}
+	}()
+	if glog.V(4) {
+		glog.Infof("%s: downloading %d bytes in %d chunks", t, roi.size, n)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	for start := int64(0); start < roi.size; start += chunkSize {
+		end := cmn.MinI64(start+chunkSize, roi.size) - 1
+		wg.Add(1)
+		go func(start, end int64) {
+			defer wg.Done()
+			if err := t.downloadChunk(ctx, fh, start, end, validator); err != nil {
+				errCh <- err
+				cancel() // no need to proceed with the rest of the chunks
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	cancel()
+	close(errCh)
+	err = <-errCh // the first one, if any
+	if errClose := fh.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return err
+	}
+	return t.putWorkfile(lom, fqn, roi.md)
+}
+
+// downloadChunk downloads a given range [start, end] into a given file.
+func (t *singleObjectTask) downloadChunk(ctx context.Context, fh *os.File, start, end int64, validator string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.obj.link, nil)
+	if err != nil {
+		return err
+	}
+	if cmn.IsGoogleStorageURL(req.URL) {
+		req.Header.Add("User-Agent", cmn.GcsUA)
+	}
+	req.Header.Set(cmn.HeaderRange, fmt.Sprintf("%s%d-%d", cmn.HeaderRangeValPrefix, start, end))
+	req.Header.Set(cmn.HeaderIfRange, validator)
+	resp, err := clientForURL(t.obj.link).Do(req)
+	if err != nil {
+		return err
+	}
+	defer cmn.Close(resp.Body)
+	if resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("range [%d, %d] request failed with %d status code (%s)",
+			start, end, resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	if first, ok := parseContentRangeStart(resp.Header.Get(cmn.HeaderContentRange)); !ok || first != start ||
+		!sameSource(resp, validator) {
+		return fmt.Errorf("unexpected range [%d, %d] response (%s: %q)",
+			start, end, cmn.HeaderContentRange, resp.Header.Get(cmn.HeaderContentRange))
+	}
+	var (
+		size = end - start + 1
+		r    = t.wrapReader(ctx, resp.Body)
+		w    = &offsetWriter{fh: fh, off: start}
+	)
+	n, err := io.Copy(w, io.LimitReader(r, size))
+	if err == nil && n != size {
+		err = fmt.Errorf("range [%d, %d]: expected %d bytes, got %d", start, end, size, n)
+	}
+	return err
+}
+
+func (t *singleObjectTask) removePartial() {
+	p := t.partial
+	if p == nil {
+		return
+	}
+	if p.fh != nil {
+		cmn.Close(p.fh)
+	}
+	if err := cmn.RemoveFile(p.FQN); err != nil {
+		glog.Errorf("%s: failed to remove %s, err: %v", t, p.FQN, err)
+	}
+	dlStore.deletePartial(p.key)
+	t.partial = nil
+}
+
+// removePartials removes workfiles kept by the failed tasks of a given job.
+func removePartials(id string) {
+	partials, err := dlStore.partials()
+	if err != nil {
+		glog.Error(err)
+		return
+	}
+	for key, p := range partials {
+		if p.JobID != id {
+			continue
+		}
+		if err := cmn.RemoveFile(p.FQN); err != nil {
+			glog.Errorf("failed to remove %s, err: %v", p.FQN, err)
+		}
+		dlStore.deletePartial(key)
+	}
+}
+
+// partialKey returns the key to persist partial download of a given object.
+func partialKey(lom *cluster.LOM) string {
+	return strconv.FormatUint(xxhash.ChecksumString64S(lom.Uname(), cmn.MLCG32), 16)
+}
+
 func (t *singleObjectTask) wrapReader(ctx context.Context, r io.ReadCloser) io.ReadCloser {
 	// Create a custom reader to monitor progress every time we read from response body stream.
 	r = &progressReader{
@@ -256,6 +643,7 @@ func (t *singleObjectTask) ToTaskDlInfo() TaskDlInfo {
 		Name:       t.obj.objName,
 		Downloaded: t.currentSize.Load(),
 		Total:      t.totalSize.Load(),
+		Resumed:    t.resumed.Load(),
 
 		StartTime: t.started.Load(),
 		EndTime:   ended,
@@ -264,6 +652,12 @@ func (t *singleObjectTask) ToTaskDlInfo() TaskDlInfo {
 	}
 }
 
+func (w *offsetWriter) Write(p []byte) (n int, err error) {
+	n, err = w.fh.WriteAt(p, w.off)
+	w.off += int64(n)
+	return
+}
+
 func (t *singleObjectTask) String() (str string) {
 	return fmt.Sprintf(
 		"{id: %q, obj_name: %q, link: %q, from_cloud: %v, bucket: %q}",
@@ -0,0 +1,176 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// putTargetMock stores the objects PUT by the downloader.
+type putTargetMock struct {
+	*cluster.TargetMock
+}
+
+func (*putTargetMock) PutObject(lom *cluster.LOM, params cluster.PutObjectParams) (string, error) {
+	defer cmn.Close(params.Reader)
+	fh, err := lom.CreateFile(lom.FQN)
+	if err != nil {
+		return "", err
+	}
+	n, err := io.Copy(fh, params.Reader)
+	cmn.Close(fh)
+	if err != nil {
+		return "", err
+	}
+	lom.SetSize(n)
+	return "", lom.Persist()
+}
+
+func TestNumChunks(t *testing.T) {
+	newTask := func(limits DlLimits, chunks DlChunks) *singleObjectTask {
+		job := &sliceDlJob{baseDlJob: baseDlJob{t: newThrottler(limits), dlChunks: chunks}}
+		return &singleObjectTask{job: job}
+	}
+
+	task := newTask(DlLimits{}, DlChunks{})
+	tassert.Errorf(t, task.numChunks(cmn.GiB) == 1, "expected no splitting by default")
+
+	task = newTask(DlLimits{}, DlChunks{Count: 8})
+	tassert.Errorf(t, task.numChunks(DlChunkMinSize-1) == 1, "expected small object not to be split")
+	tassert.Errorf(t, task.numChunks(cmn.GiB) == 8, "expected 8 chunks, got %d", task.numChunks(cmn.GiB))
+
+	task = newTask(DlLimits{}, DlChunks{Count: 8, MinSize: cmn.MiB})
+	tassert.Errorf(t, task.numChunks(4*cmn.MiB) == 4, "expected at most one chunk per MiB, got %d",
+		task.numChunks(4*cmn.MiB))
+
+	// the task itself holds one connection (see dispatcher)
+	task = newTask(DlLimits{Connections: 3}, DlChunks{Count: 8})
+	task.job.throttler().acquire()
+	n := task.numChunks(cmn.GiB)
+	tassert.Fatalf(t, n == 3, "expected the number of chunks to be limited by connections, got %d", n)
+	tassert.Errorf(t, task.numChunks(cmn.GiB) == 1, "expected no connections left")
+	for i := 1; i < n; i++ {
+		task.job.throttler().release()
+	}
+	tassert.Errorf(t, task.numChunks(cmn.GiB) == 3, "expected connections to be released")
+}
+
+func TestOffsetWriter(t *testing.T) {
+	fh, err := ioutil.TempFile("", "")
+	tassert.CheckFatal(t, err)
+	defer os.Remove(fh.Name())
+	defer fh.Close()
+
+	for _, chunk := range []struct {
+		off  int64
+		data string
+	}{{6, "world"}, {0, "hello "}} {
+		w := &offsetWriter{fh: fh, off: chunk.off}
+		_, err := w.Write([]byte(chunk.data))
+		tassert.CheckFatal(t, err)
+	}
+	b, err := ioutil.ReadFile(fh.Name())
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, string(b) == "hello world", "expected %q, got %q", "hello world", string(b))
+}
+
+func TestResumePartialDownload(t *testing.T) {
+	mpath, err := ioutil.TempDir("", "dl-resume")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+
+	fs.Init()
+	fs.DisableFsIDCheck()
+	_, err = fs.Add(mpath, "daeID")
+	tassert.CheckFatal(t, err)
+	defer fs.Remove(mpath)
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	initInfoStore(dbdriver.NewDBMock())
+
+	var (
+		content  = []byte(strings.Repeat("0123456789", 10*1024))
+		half     = int64(len(content) / 2)
+		failing  atomic.Bool
+		resumeAt string
+	)
+	failing.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(cmn.HeaderETag, `"etag"`)
+		w.Header().Set(cmn.HeaderAcceptRanges, "bytes")
+		switch {
+		case failing.Load() && r.Header.Get(cmn.HeaderRange) == "":
+			// send half of the content and break the connection
+			w.Header().Set(cmn.HeaderContentLength, cmn.I2S(int64(len(content))))
+			w.Write(content[:half])
+		case failing.Load():
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			if resumeAt == "" {
+				resumeAt = r.Header.Get(cmn.HeaderRange)
+			}
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+		}
+	}))
+	defer srv.Close()
+
+	var (
+		bck   = cluster.NewBck("bck", cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{Cksum: cmn.CksumConf{Type: cmn.ChecksumNone}})
+		tgt   = &putTargetMock{cluster.NewTargetMock(cluster.NewBaseBownerMock(bck))}
+		job   = &sliceDlJob{baseDlJob: baseDlJob{id: "job", bck: bck, timeout: time.Minute, t: newThrottler(DlLimits{}), notif: &NotifDownload{}}}
+		lom   = &cluster.LOM{T: tgt, ObjName: "obj"}
+		runDl = func() (*singleObjectTask, error) {
+			task := &singleObjectTask{
+				parent:      &Downloader{t: tgt},
+				job:         job,
+				obj:         dlObj{objName: "obj", link: srv.URL + "/obj"},
+				downloadCtx: context.Background(),
+			}
+			return task, task.downloadLocal(lom)
+		}
+	)
+	errs := fs.CreateBuckets("test", bck.Bck)
+	tassert.Fatalf(t, len(errs) == 0, "failed to create bucket: %v", errs)
+	tassert.CheckFatal(t, lom.Init(bck.Bck))
+
+	// the first run fails - the workfile is kept
+	_, err = runDl()
+	tassert.Fatalf(t, err != nil, "expected the first run to fail")
+	p, err := dlStore.partial(partialKey(lom))
+	tassert.CheckFatal(t, err)
+	finfo, err := os.Stat(p.FQN)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, finfo.Size() == half, "expected %d bytes downloaded, got %d", half, finfo.Size())
+
+	// the second run resumes
+	failing.Store(false)
+	task, err := runDl()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, resumeAt == "bytes="+cmn.I2S(half)+"-", "expected to resume at %d, got %q", half, resumeAt)
+	tassert.Errorf(t, task.resumed.Load() == half, "expected %d bytes resumed, got %d", half, task.resumed.Load())
+	b, err := ioutil.ReadFile(lom.FQN)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(b, content), "downloaded content does not match")
+	_, err = os.Stat(p.FQN)
+	tassert.Errorf(t, os.IsNotExist(err), "expected workfile %s to be removed, err: %v", p.FQN, err)
+	_, err = dlStore.partial(partialKey(lom))
+	tassert.Errorf(t, dbdriver.IsErrNotFound(err), "expected partial download to be removed, err: %v", err)
+}
@@ -99,6 +99,14 @@ func (t *throttler) acquire() {
 	t.sema.Acquire()
 }
 
+// tryAcquire is non-blocking acquire (used for additional connections, see `DlChunks`).
+func (t *throttler) tryAcquire() bool {
+	if t.sema == nil {
+		return true
+	}
+	return t.sema.TryAcquire()
+}
+
 func (t *throttler) release() {
 	if t.sema == nil {
 		return
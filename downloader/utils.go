@@ -41,6 +41,28 @@ func buildDlObjs(t cluster.Target, bck *cluster.Bck, objects cmn.SimpleKVs) ([]d
 	return objs, nil
 }
 
+// buildManifestDlObjs returns list of manifest's objects that must be downloaded by target.
+func buildManifestDlObjs(t cluster.Target, bck *cluster.Bck, entries []DlManifestEntry) ([]dlObj, error) {
+	var (
+		smap = t.Sowner().Get()
+		sid  = t.Snode().ID()
+	)
+
+	objs := make([]dlObj, 0, len(entries))
+	for i := range entries {
+		obj, err := makeDlObj(smap, sid, bck, entries[i].ObjName, entries[i].Link)
+		if err != nil {
+			if err == errInvalidTarget {
+				continue
+			}
+			return nil, err
+		}
+		obj.expect = entries[i].expect()
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
 func makeDlObj(smap *cluster.Smap, sid string, bck *cluster.Bck, objName, link string) (dlObj, error) {
 	objName, err := normalizeObjName(objName)
 	if err != nil {
@@ -79,54 +101,54 @@ func normalizeObjName(objName string) (string, error) {
 }
 
 func ParseStartDownloadRequest(ctx context.Context, t cluster.Target, bck *cluster.Bck, id string, dlb DlBody, dlXact *Downloader) (DlJob, error) {
-	switch dlb.Type {
-	case DlTypeCloud:
-		dp := &DlCloudBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
-		if err != nil {
-			return nil, err
-		}
-		if err := dp.Validate(); err != nil {
-			return nil, err
-		}
+	body, err := parseDlBody(dlb)
+	if err != nil {
+		return nil, err
+	}
+	switch dp := body.(type) {
+	case *DlCloudBody:
 		return newCloudBucketDlJob(ctx, t, id, bck, dp, dlXact)
-
-	case DlTypeMulti:
-		dp := &DlMultiBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
-		if err != nil {
-			return nil, err
-		}
-		if err := dp.Validate(); err != nil {
-			return nil, err
-		}
+	case *DlMultiBody:
 		return newMultiDlJob(t, id, bck, dp, dlXact)
-
-	case DlTypeRange:
-		dp := &DlRangeBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
-		if err != nil {
-			return nil, err
-		}
-		if err := dp.Validate(); err != nil {
-			return nil, err
-		}
+	case *DlRangeBody:
 		return newRangeDlJob(t, id, bck, dp, dlXact)
-
-	case DlTypeSingle:
-		dp := &DlSingleBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
-		if err != nil {
-			return nil, err
-		}
-		if err := dp.Validate(); err != nil {
-			return nil, err
-		}
+	case *DlSingleBody:
 		return newSingleDlJob(t, id, bck, dp, dlXact)
+	case *DlManifestBody:
+		return newManifestDlJob(t, id, bck, dp, dlXact)
+	case *DlCrawlBody:
+		return newCrawlDlJob(ctx, t, id, bck, dp, dlXact)
+	default:
+		cmn.Assertf(false, "%T", dp)
+		return nil, nil
+	}
+}
 
+// parseDlBody unmarshals and validates type-specific part of the request.
+func parseDlBody(dlb DlBody) (body dlTypedBody, err error) {
+	switch dlb.Type {
+	case DlTypeCloud:
+		body = &DlCloudBody{}
+	case DlTypeMulti:
+		body = &DlMultiBody{}
+	case DlTypeRange:
+		body = &DlRangeBody{}
+	case DlTypeSingle:
+		body = &DlSingleBody{}
+	case DlTypeManifest:
+		body = &DlManifestBody{}
+	case DlTypeCrawl:
+		body = &DlCrawlBody{}
 	default:
-		return nil, errors.New("input does not match any of the supported formats (single, range, multi, cloud)")
+		return nil, errors.New("input does not match any of the supported formats (single, range, multi, cloud, manifest, crawl)")
+	}
+	if err := jsoniter.Unmarshal(dlb.RawMessage, body); err != nil {
+		return nil, err
 	}
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+	return body, nil
 }
 
 //
@@ -207,6 +229,40 @@ func roiFromLink(link string, resp *http.Response) (roi remoteObjInfo) {
 	return
 }
 
+// sourceValidator returns the (strong) ETag or, if not available, Last-Modified
+// of a given response - to make sure that resumed download continues the same
+// content (see `If-Range`). Empty string means that the download is not resumable.
+func sourceValidator(resp *http.Response) string {
+	if resp.Header.Get(cmn.HeaderAcceptRanges) != "bytes" {
+		return ""
+	}
+	if etag := resp.Header.Get(cmn.HeaderETag); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return resp.Header.Get(cmn.HeaderLastModified)
+}
+
+// sameSource returns true if a given (partial content) response carries the
+// same validator - ETag or Last-Modified - as the one that was initially received.
+func sameSource(resp *http.Response, validator string) bool {
+	return resp.Header.Get(cmn.HeaderETag) == validator || resp.Header.Get(cmn.HeaderLastModified) == validator
+}
+
+// parseContentRangeStart returns the first byte position of a given
+// `Content-Range: bytes <first>-<last>/<size>` header.
+func parseContentRangeStart(hdr string) (int64, bool) {
+	if !strings.HasPrefix(hdr, cmn.HeaderContentRangeValPrefix) {
+		return 0, false
+	}
+	hdr = strings.TrimPrefix(hdr, cmn.HeaderContentRangeValPrefix)
+	i := strings.IndexByte(hdr, '-')
+	if i <= 0 {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(hdr[:i], 10, 64)
+	return start, err == nil
+}
+
 func parseGoogleCksumHeader(hdr []string) cmn.SimpleKVs {
 	var (
 		h      = cmn.CloudHelpers.Google
@@ -247,6 +303,10 @@ func roiFromObjMeta(objMeta cmn.SimpleKVs) (roi remoteObjInfo) {
 		roi.md[cluster.SourceObjMD] = cluster.SourceAmazonObjMD
 	case cmn.ProviderAzure:
 		roi.md[cluster.SourceObjMD] = cluster.SourceAzureObjMD
+	case cmn.ProviderHDFS:
+		roi.md[cluster.SourceObjMD] = cluster.SourceHDFSObjMD
+	case cmn.ProviderPOSIX:
+		roi.md[cluster.SourceObjMD] = cluster.SourcePOSIXObjMD
 	default:
 		return
 	}
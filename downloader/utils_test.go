@@ -87,6 +87,38 @@ func TestCompareObject(t *testing.T) {
 	tassert.Errorf(t, equal, "expected the objects to be equal")
 }
 
+func TestResumeHeaders(t *testing.T) {
+	resp := &http.Response{Header: make(http.Header)}
+	resp.Header.Set(cmn.HeaderETag, `"abc"`)
+	resp.Header.Set(cmn.HeaderLastModified, "Wed, 21 Oct 2020 07:28:00 GMT")
+	tassert.Errorf(t, sourceValidator(resp) == "", "expected not resumable without %s", cmn.HeaderAcceptRanges)
+
+	resp.Header.Set(cmn.HeaderAcceptRanges, "bytes")
+	tassert.Errorf(t, sourceValidator(resp) == `"abc"`, "expected ETag, got %q", sourceValidator(resp))
+	resp.Header.Set(cmn.HeaderETag, `W/"abc"`)
+	tassert.Errorf(t, sourceValidator(resp) == "Wed, 21 Oct 2020 07:28:00 GMT",
+		"expected Last-Modified instead of weak ETag, got %q", sourceValidator(resp))
+	tassert.Errorf(t, sameSource(resp, "Wed, 21 Oct 2020 07:28:00 GMT"), "expected the same source")
+	tassert.Errorf(t, !sameSource(resp, `"abc"`), "expected a different source")
+
+	contentRangeTests := []struct {
+		hdr   string
+		start int64
+		ok    bool
+	}{
+		{"bytes 100-199/200", 100, true},
+		{"bytes 0-0/*", 0, true},
+		{"bytes */200", 0, false},
+		{"100-199/200", 0, false},
+		{"", 0, false},
+	}
+	for _, test := range contentRangeTests {
+		start, ok := parseContentRangeStart(test.hdr)
+		tassert.Errorf(t, start == test.start && ok == test.ok,
+			"parseContentRangeStart(%q): expected (%d, %v), got (%d, %v)", test.hdr, test.start, test.ok, start, ok)
+	}
+}
+
 func downloadObject(link string) (string, error) {
 	resp, err := http.Get(link)
 	if err != nil {
@@ -99,7 +99,7 @@ func (m *Manager) start() (err error) {
 	// Phase 3. - run only by the final target
 	if curTargetIsFinal {
 		shardSize := m.rs.OutputShardSize
-		if m.extractCreator.UsingCompression() {
+		if m.extractCreator.UsingCompression() && m.shardCreator.UsingCompression() {
 			// By making the assumption that the input content is reasonably
 			// uniform across all shards, the output shard size required (such
 			// that each gzip compressed output shard will have a size close to
@@ -199,17 +199,31 @@ func (m *Manager) extractShard(name string, metrics *LocalExtraction) func() err
 			lom.Unlock(false)
 			return errors.Errorf("unable to open local file, err: %v", err)
 		}
+		var (
+			src  io.ReaderAt = f
+			size             = lom.Size()
+		)
+		if lom.Encoded() {
+			cr, err := lom.ContentReader(f, nil)
+			if err != nil {
+				cmn.Close(f)
+				phaseInfo.adjuster.releaseSema(lom.ParsedFQN.MpathInfo)
+				lom.Unlock(false)
+				return errors.Errorf("unable to decode %s, err: %v", lom, err)
+			}
+			src, size = cr, cr.Size()
+		}
 		var compressedSize int64
 		if m.extractCreator.UsingCompression() {
-			compressedSize = lom.Size()
+			compressedSize = size
 		}
 
-		expectedUncompressedSize := uint64(float64(lom.Size()) / m.avgCompressionRatio())
+		expectedUncompressedSize := uint64(float64(size) / m.avgCompressionRatio())
 		toDisk := m.dsorter.preShardExtraction(expectedUncompressedSize)
 
 		beforeExtraction := mono.NanoTime()
 
-		reader := io.NewSectionReader(f, 0, lom.Size())
+		reader := io.NewSectionReader(src, 0, size)
 		extractedSize, extractedCount, err := m.extractCreator.ExtractShard(lom, reader, m.recManager, toDisk)
 		cmn.Close(f)
 
@@ -362,7 +376,7 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 				WithFinalize: true,
 			}
 			_, err = m.ctx.t.PutObject(lom, params)
-			n = lom.Size()
+			n = lom.ContentSize()
 		} else {
 			n, err = io.Copy(ioutil.Discard, r)
 		}
@@ -370,7 +384,7 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 		wg.Done()
 	}()
 
-	_, err = m.extractCreator.CreateShard(s, w, loadContent)
+	_, err = m.shardCreator.CreateShard(s, w, loadContent)
 	w.CloseWithError(err)
 	if err != nil {
 		r.CloseWithError(err)
@@ -413,18 +427,21 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 			goto exit
 		}
 
-		file, err := cmn.NewFileHandle(lom.FQN)
+		file, err := lom.Open() // (decoded shard gets encoded again by the receiver)
 		if err != nil {
 			return err
 		}
 
-		cksumType, cksumValue := lom.Cksum().Get()
+		var cksumType, cksumValue string
+		if cksum := lom.ContentCksum(); cksum != nil {
+			cksumType, cksumValue = cksum.Get()
+		}
 		o := transport.AllocSend()
 		o.Hdr = transport.ObjHdr{
 			Bck:     lom.Bck().Bck,
 			ObjName: shardName,
 			ObjAttrs: transport.ObjectAttrs{
-				Size:       lom.Size(),
+				Size:       lom.ContentSize(),
 				CksumType:  cksumType,
 				CksumValue: cksumValue,
 			},
@@ -632,7 +649,7 @@ func (m *Manager) generateShardsWithTemplate(maxSize int64) ([]*extract.Shard, e
 			return nil, errors.Errorf("number of shards to be created exceeds expected number of shards (%d)", shardCount)
 		}
 		shard := &extract.Shard{
-			Name: name + m.rs.Extension,
+			Name: name + m.rs.OutputExtension,
 		}
 
 		shard.Size = curShardSize
@@ -713,7 +730,7 @@ func (m *Manager) generateShardsWithOrderingFile(maxSize int64) ([]*extract.Shar
 		}
 
 		shards := shardsBuilder[shardNameFmt]
-		recordSize := r.TotalSize() + m.extractCreator.MetadataSize()*int64(len(r.Objects))
+		recordSize := r.TotalSize() + m.shardCreator.MetadataSize()*int64(len(r.Objects))
 		shardCount := len(shards)
 		if shardCount == 0 || shards[shardCount-1].Size > maxSize {
 			shard := &extract.Shard{
@@ -7,11 +7,14 @@ package extract
 import (
 	"bytes"
 	"crypto/md5"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"hash"
 	"io"
 	"io/ioutil"
 	"strconv"
+	"strings"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/pkg/errors"
@@ -21,12 +24,19 @@ const (
 	FormatTypeInt    = "int"
 	FormatTypeFloat  = "float"
 	FormatTypeString = "string"
+
+	// Supported formats of the sidecar object holding composite key fields.
+	SidecarFormatJSON = ".json"
+	SidecarFormatCSV  = ".csv"
 )
 
 var (
 	supportedFormatTypes = []string{FormatTypeInt, FormatTypeFloat, FormatTypeString}
 
 	errInvalidAlgorithmFormatTypes = fmt.Errorf("invalid algorithm format type provided, shoule be one of: %+v", supportedFormatTypes)
+	errInvalidSidecarExtension     = fmt.Errorf("invalid sidecar extension provided, should end with either %q or %q",
+		SidecarFormatJSON, SidecarFormatCSV)
+	errMissingSortKeyName = errors.New("sort key must have a name")
 )
 
 type (
@@ -51,6 +61,21 @@ type (
 		ty  string // type of key extracted, supported: supportedFormatTypes
 		ext string // extension of object record whose content will be read
 	}
+
+	// SortKey describes single field of the composite key.
+	SortKey struct {
+		Name       string `json:"name" yaml:"name"`               // name of the field (JSON key or CSV column)
+		FormatType string `json:"format_type" yaml:"format_type"` // supported: supportedFormatTypes
+		Decreasing bool   `json:"decreasing" yaml:"decreasing"`
+	}
+
+	// compositeKeyExtractor reads the composite key from the record's sidecar
+	// object - JSON object or CSV (header and single row of values). The key
+	// is a slice of the fields' values in the order of `keys`.
+	compositeKeyExtractor struct {
+		keys []SortKey
+		ext  string // extension of the sidecar object
+	}
 )
 
 func NewMD5KeyExtractor() (KeyExtractor, error) {
@@ -108,8 +133,95 @@ func (ke *contentKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (interface{},
 		return nil, err
 	}
 
-	key := string(b)
-	switch ke.ty {
+	return parseKey(string(b), ke.ty)
+}
+
+func NewCompositeKeyExtractor(keys []SortKey, ext string) (KeyExtractor, error) {
+	if err := ValidateSortKeys(keys, ext); err != nil {
+		return nil, err
+	}
+	return &compositeKeyExtractor{keys: keys, ext: ext}, nil
+}
+
+func (ke *compositeKeyExtractor) PrepareExtractor(name string, r cmn.ReadSizer, ext string) (cmn.ReadSizer, *SingleKeyExtractor, bool) {
+	if ke.ext != ext {
+		return r, nil, false
+	}
+
+	buf := &bytes.Buffer{}
+	tee := cmn.NewSizedReader(io.TeeReader(r, buf), r.Size())
+	return tee, &SingleKeyExtractor{name: name, buf: buf}, true
+}
+
+func (ke *compositeKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (interface{}, error) {
+	if ske == nil { // is not valid to be read
+		return nil, nil
+	}
+
+	b, err := ioutil.ReadAll(ske.buf)
+	ske.buf = nil
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]string
+	if strings.HasSuffix(ke.ext, SidecarFormatJSON) {
+		fields, err = parseJSONSidecar(b)
+	} else {
+		fields, err = parseCSVSidecar(b)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse sidecar of %q", ske.name)
+	}
+
+	key := make([]interface{}, len(ke.keys))
+	for i, k := range ke.keys {
+		value, ok := fields[k.Name]
+		if !ok {
+			return nil, errors.Errorf("sort key %q is missing in sidecar of %q", k.Name, ske.name)
+		}
+		if key[i], err = parseKey(value, k.FormatType); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse sort key %q of %q", k.Name, ske.name)
+		}
+	}
+	return key, nil
+}
+
+func parseJSONSidecar(b []byte) (map[string]string, error) {
+	var (
+		raw    map[string]json.RawMessage
+		fields = make(map[string]string)
+	)
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	for name, value := range raw {
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			s = string(bytes.TrimSpace(value)) // number, bool, etc.
+		}
+		fields[name] = s
+	}
+	return fields, nil
+}
+
+func parseCSVSidecar(b []byte) (map[string]string, error) {
+	rows, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) != 2 {
+		return nil, errors.Errorf("expected header and single row of values, got %d rows", len(rows))
+	}
+	fields := make(map[string]string, len(rows[0]))
+	for i, name := range rows[0] {
+		fields[strings.TrimSpace(name)] = rows[1][i]
+	}
+	return fields, nil
+}
+
+func parseKey(key, ty string) (interface{}, error) {
+	switch ty {
 	case FormatTypeInt:
 		return strconv.ParseInt(key, 10, 64)
 	case FormatTypeFloat:
@@ -117,7 +229,7 @@ func (ke *contentKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (interface{},
 	case FormatTypeString:
 		return key, nil
 	default:
-		return nil, errors.Errorf("not implemented extractor type: %s", ke.ty)
+		return nil, errors.Errorf("not implemented extractor type: %s", ty)
 	}
 }
 
@@ -128,3 +240,18 @@ func ValidateAlgorithmFormatType(ty string) error {
 
 	return nil
 }
+
+func ValidateSortKeys(keys []SortKey, ext string) error {
+	if !strings.HasSuffix(ext, SidecarFormatJSON) && !strings.HasSuffix(ext, SidecarFormatCSV) {
+		return errInvalidSidecarExtension
+	}
+	for _, k := range keys {
+		if k.Name == "" {
+			return errMissingSortKeyName
+		}
+		if err := ValidateAlgorithmFormatType(k.FormatType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyExtractor", func() {
+	keys := []SortKey{
+		{Name: "label", FormatType: FormatTypeString},
+		{Name: "timestamp", FormatType: FormatTypeInt, Decreasing: true},
+		{Name: "score", FormatType: FormatTypeFloat},
+	}
+
+	extractKey := func(ke KeyExtractor, ext, content string) (interface{}, error) {
+		var r cmn.ReadSizer = cmn.NewSizedReader(bytes.NewReader([]byte(content)), int64(len(content)))
+		r, ske, needRead := ke.PrepareExtractor("sample", r, ext)
+		if needRead {
+			_, err := ioutil.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		return ke.ExtractKey(ske)
+	}
+
+	Context("composite", func() {
+		It("should extract key from JSON sidecar", func() {
+			ke, err := NewCompositeKeyExtractor(keys, ".json")
+			Expect(err).NotTo(HaveOccurred())
+			key, err := extractKey(ke, ".json", `{"score": 0.5, "label": "cat", "timestamp": 1600000000, "other": [1]}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal([]interface{}{"cat", int64(1600000000), 0.5}))
+		})
+
+		It("should extract key from CSV sidecar", func() {
+			ke, err := NewCompositeKeyExtractor(keys, ".meta.csv")
+			Expect(err).NotTo(HaveOccurred())
+			key, err := extractKey(ke, ".meta.csv", "timestamp,label,score\n1600000000,\"cat, black\",0.5\n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal([]interface{}{"cat, black", int64(1600000000), 0.5}))
+		})
+
+		It("should not extract key from other objects", func() {
+			ke, err := NewCompositeKeyExtractor(keys, ".json")
+			Expect(err).NotTo(HaveOccurred())
+			key, err := extractKey(ke, ".jpg", "image")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(BeNil())
+		})
+
+		It("should fail when key field is missing or invalid", func() {
+			ke, err := NewCompositeKeyExtractor(keys, ".json")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = extractKey(ke, ".json", `{"label": "cat", "score": 0.5}`)
+			Expect(err).To(HaveOccurred())
+			_, err = extractKey(ke, ".json", `{"label": "cat", "timestamp": "now", "score": 0.5}`)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail to create extractor with invalid keys", func() {
+			_, err := NewCompositeKeyExtractor(keys, ".cls")
+			Expect(err).To(Equal(errInvalidSidecarExtension))
+			_, err = NewCompositeKeyExtractor([]SortKey{{FormatType: FormatTypeInt}}, ".json")
+			Expect(err).To(Equal(errMissingSortKeyName))
+			_, err = NewCompositeKeyExtractor([]SortKey{{Name: "label", FormatType: "date"}}, ".json")
+			Expect(err).To(Equal(errInvalidAlgorithmFormatTypes))
+		})
+	})
+})
@@ -0,0 +1,173 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// msgpackKeyField is the name of the field holding the sample's key (WebDataset convention).
+const msgpackKeyField = "__key__"
+
+// interface guard
+var _ ExtractCreator = (*msgpackExtractCreator)(nil)
+
+type (
+	// msgpackExtractCreator handles WebDataset-style msgpack shards: a sequence of
+	// msgpack maps, one per sample (record). Each map contains the sample's key
+	// (`__key__`) and its objects - extension (without the leading dot) to content,
+	// e.g. `{"__key__": "sample-0001", "jpg": <bin>, "cls": <bin>}`.
+	msgpackExtractCreator struct {
+		t cluster.Target
+	}
+
+	msgpackField struct {
+		name  string
+		value []byte
+	}
+)
+
+func NewMsgpackExtractCreator(t cluster.Target) ExtractCreator {
+	return &msgpackExtractCreator{t: t}
+}
+
+// ExtractShard reads the msgpack shard and extracts its records.
+func (m *msgpackExtractCreator) ExtractShard(lom *cluster.LOM, r *io.SectionReader, extractor RecordExtractor,
+	toDisk bool) (extractedSize int64, extractedCount int, err error) {
+	var (
+		size   int64
+		fqn    = lom.ParsedFQN
+		mr     = msgp.NewReader(r)
+		fields = make([]msgpackField, 0, 4)
+	)
+
+	buf, slab := m.t.MMSA().Alloc(r.Size())
+	defer slab.Free(buf)
+
+	for {
+		var key string
+		fields = fields[:0]
+		if key, fields, err = readMsgpackSample(mr, fields); err != nil {
+			if msgp.Cause(err) == io.EOF {
+				return extractedSize, extractedCount, nil
+			}
+			return extractedSize, extractedCount, err
+		}
+
+		extractMethod := ExtractToMem
+		if toDisk {
+			extractMethod = ExtractToDisk
+		}
+		for _, field := range fields {
+			args := extractRecordArgs{
+				shardName:     fqn.ObjName,
+				fileType:      fqn.ContentType,
+				recordName:    key + "." + field.name,
+				r:             cmn.NewSizedReader(bytes.NewReader(field.value), int64(len(field.value))),
+				extractMethod: extractMethod,
+				buf:           buf,
+			}
+			if size, err = extractor.ExtractRecordWithBuffer(args); err != nil {
+				return extractedSize, extractedCount, err
+			}
+			extractedSize += size
+			extractedCount++
+		}
+	}
+}
+
+// readMsgpackSample reads a single sample (msgpack map); returns `io.EOF`
+// if there are no more samples.
+func readMsgpackSample(mr *msgp.Reader, fields []msgpackField) (key string, _ []msgpackField, err error) {
+	sz, err := mr.ReadMapHeader()
+	if err != nil {
+		return "", fields, err
+	}
+	for i := uint32(0); i < sz; i++ {
+		name, err := mr.ReadMapKeyPtr()
+		if err != nil {
+			return "", fields, err
+		}
+		if string(name) == msgpackKeyField {
+			if key, err = mr.ReadString(); err != nil {
+				return "", fields, err
+			}
+			continue
+		}
+		field := msgpackField{name: string(name)}
+		typ, err := mr.NextType()
+		if err != nil {
+			return "", fields, err
+		}
+		switch typ {
+		case msgp.BinType:
+			field.value, err = mr.ReadBytes(nil)
+		case msgp.StrType:
+			field.value, err = mr.ReadStringAsBytes(nil)
+		default:
+			err = fmt.Errorf("field %q: expected binary or string value, got %s", field.name, typ)
+		}
+		if err != nil {
+			return "", fields, err
+		}
+		fields = append(fields, field)
+	}
+	if key == "" {
+		return "", fields, fmt.Errorf("sample is missing %q field", msgpackKeyField)
+	}
+	return key, fields, nil
+}
+
+// CreateShard creates a new shard locally based on the Shard.
+func (m *msgpackExtractCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (written int64, err error) {
+	var (
+		n  int64
+		mw = msgp.NewWriter(w)
+	)
+	for _, rec := range s.Records.All() {
+		if err = mw.WriteMapHeader(uint32(len(rec.Objects) + 1)); err != nil {
+			return written, err
+		}
+		if err = mw.WriteString(msgpackKeyField); err != nil {
+			return written, err
+		}
+		// record's name is "<shard name>|<sample key>" (see `genRecordUniqueName`)
+		if err = mw.WriteString(rec.Name[strings.IndexByte(rec.Name, '|')+1:]); err != nil {
+			return written, err
+		}
+		for _, obj := range rec.Objects {
+			if err = mw.WriteString(strings.TrimPrefix(obj.Extension, ".")); err != nil {
+				return written, err
+			}
+			if err = mw.WriteBytesHeader(uint32(obj.Size)); err != nil {
+				return written, err
+			}
+			if n, err = loadContent(mw, rec, obj); err != nil {
+				return written + n, err
+			}
+			written += n
+		}
+	}
+	return written, mw.Flush()
+}
+
+func (m *msgpackExtractCreator) UsingCompression() bool {
+	return false
+}
+
+func (m *msgpackExtractCreator) SupportsOffset() bool {
+	return false
+}
+
+func (m *msgpackExtractCreator) MetadataSize() int64 {
+	return 0 // no per-object metadata (object's name is the sample's key and extension)
+}
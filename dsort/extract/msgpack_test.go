@@ -0,0 +1,94 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/memsys"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type recordsCollector struct {
+	records map[string][]byte // record name => content
+}
+
+func (rc *recordsCollector) ExtractRecordWithBuffer(args extractRecordArgs) (int64, error) {
+	b, err := ioutil.ReadAll(args.r)
+	rc.records[args.recordName] = b
+	return int64(len(b)), err
+}
+
+// makeTestShard returns shard with the given records (record name => extension => content)
+// along with the function to load the records' content.
+func makeTestShard(contents map[string]map[string]string, metadata func(name string) []byte) (*Shard, LoadContentFunc) {
+	shard := &Shard{Records: NewRecords(len(contents))}
+	for name, objs := range contents {
+		rec := &Record{Key: name, Name: "shard|" + name}
+		for ext, content := range objs {
+			rec.Objects = append(rec.Objects, &RecordObj{
+				Extension:    ext,
+				Size:         int64(len(content)),
+				MetadataSize: int64(len(metadata(name + ext))),
+				StoreType:    SGLStoreType,
+			})
+		}
+		shard.Records.Insert(rec)
+	}
+	loadContent := func(w io.Writer, rec *Record, obj *RecordObj) (int64, error) {
+		name := rec.Name[len("shard|"):]
+		r := io.MultiReader(bytes.NewReader(metadata(name+obj.Extension)), bytes.NewReader([]byte(contents[name][obj.Extension])))
+		return io.Copy(w, r)
+	}
+	return shard, loadContent
+}
+
+var _ = Describe("Msgpack", func() {
+	BeforeEach(func() {
+		// small allocations go to the (sibling) small-size MMSA
+		memsys.DefaultSmallMM()
+	})
+
+	It("should create and extract msgpack shard", func() {
+		var (
+			t        = cluster.NewTargetMock(nil)
+			ec       = NewMsgpackExtractCreator(t)
+			contents = map[string]map[string]string{
+				"sample-0": {".jpg": "image-0", ".cls": "0"},
+				"sample-1": {".jpg": "image-1", ".cls": "1", ".seg.png": ""},
+			}
+			buf = &bytes.Buffer{}
+		)
+		shard, loadContent := makeTestShard(contents, func(string) []byte { return nil })
+		_, err := ec.CreateShard(shard, buf, loadContent)
+		Expect(err).NotTo(HaveOccurred())
+
+		rc := &recordsCollector{records: make(map[string][]byte)}
+		lom := &cluster.LOM{}
+		r := io.NewSectionReader(bytes.NewReader(buf.Bytes()), 0, int64(buf.Len()))
+		_, count, err := ec.ExtractShard(lom, r, rc, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(5))
+		for name, objs := range contents {
+			for ext, content := range objs {
+				Expect(string(rc.records[name+ext])).To(Equal(content))
+			}
+		}
+	})
+
+	It("should fail to extract sample without key", func() {
+		var (
+			ec  = NewMsgpackExtractCreator(cluster.NewTargetMock(nil))
+			buf = []byte{0x81, 0xa3, 'j', 'p', 'g', 0xc4, 0x01, 'x'} // {"jpg": <bin "x">}
+			r   = io.NewSectionReader(bytes.NewReader(buf), 0, int64(len(buf)))
+		)
+		_, _, err := ec.ExtractShard(&cluster.LOM{}, r, &recordsCollector{records: make(map[string][]byte)}, false)
+		Expect(err).To(HaveOccurred())
+	})
+})
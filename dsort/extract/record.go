@@ -216,32 +216,72 @@ func (r *Records) Less(i, j int, formatType string) (bool, error) {
 		return false, errors.Errorf("key is missing for %q", r.arr[j].Name)
 	}
 
-	switch formatType {
-	case FormatTypeInt:
-		ilhs, lok := lhs.(int64)
-		irhs, rok := rhs.(int64)
-		if lok && rok {
-			return ilhs < irhs, nil
-		}
+	if less, ok := lessKey(lhs, rhs, formatType); ok {
+		return less, nil
+	}
+
+	cmn.Assertf(false, "lhs: %v, rhs: %v, arr[i]: %v, arr[j]: %v", lhs, rhs, r.arr[i], r.arr[j])
+	return false, nil
+}
+
+// LessByKeys compares composite keys (see `compositeKeyExtractor`) field by field:
+// the first field which differs determines the order.
+func (r *Records) LessByKeys(i, j int, keys []SortKey) (bool, error) {
+	lhs, lok := r.arr[i].Key.([]interface{})
+	rhs, rok := r.arr[j].Key.([]interface{})
+	if !lok || len(lhs) != len(keys) {
+		return false, errors.Errorf("composite key is missing for %q", r.arr[i].Name)
+	} else if !rok || len(rhs) != len(keys) {
+		return false, errors.Errorf("composite key is missing for %q", r.arr[j].Name)
+	}
 
-		// One side was parsed as float64 - javascript does not support
-		// int64 type and it fallback to float64
-		if !lok {
-			ilhs = int64(lhs.(float64))
+	for idx, key := range keys {
+		less, ok := lessKey(lhs[idx], rhs[idx], key.FormatType)
+		if !ok {
+			return false, errors.Errorf("invalid %q key field of %q or %q", key.Name, r.arr[i].Name, r.arr[j].Name)
 		}
-		if !rok {
-			irhs = int64(rhs.(float64))
+		if less {
+			return !key.Decreasing, nil
 		}
+		// NOTE: `lessKey` succeeded for (lhs, rhs) so it will succeed for (rhs, lhs).
+		if greater, _ := lessKey(rhs[idx], lhs[idx], key.FormatType); greater {
+			return key.Decreasing, nil
+		}
+	}
+	return false, nil
+}
 
-		return ilhs < irhs, nil
+// lessKey compares keys of the given format type, returns false if keys
+// are not of the expected type.
+func lessKey(lhs, rhs interface{}, formatType string) (less, ok bool) {
+	switch formatType {
+	case FormatTypeInt:
+		ilhs, lok := intKey(lhs)
+		irhs, rok := intKey(rhs)
+		return ilhs < irhs, lok && rok
 	case FormatTypeFloat:
-		return lhs.(float64) < rhs.(float64), nil
+		flhs, lok := lhs.(float64)
+		frhs, rok := rhs.(float64)
+		return flhs < frhs, lok && rok
 	case FormatTypeString:
-		return lhs.(string) < rhs.(string), nil
+		slhs, lok := lhs.(string)
+		srhs, rok := rhs.(string)
+		return slhs < srhs, lok && rok
 	}
+	return false, false
+}
 
-	cmn.Assertf(false, "lhs: %v, rhs: %v, arr[i]: %v, arr[j]: %v", lhs, rhs, r.arr[i], r.arr[j])
-	return false, nil
+func intKey(key interface{}) (int64, bool) {
+	switch v := key.(type) {
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), true
+	case float64:
+		// javascript does not support int64 type and it fallbacks to float64
+		return int64(v), true
+	}
+	return 0, false
 }
 
 func (r *Records) objectCount() int {
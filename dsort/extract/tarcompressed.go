@@ -8,6 +8,7 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"io"
+	"io/ioutil"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
@@ -15,17 +16,77 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/dsort/filetype"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v3"
 )
 
 // interface guard
-var _ ExtractCreator = (*targzExtractCreator)(nil)
+var _ ExtractCreator = (*tarCompressedExtractCreator)(nil)
+
+type (
+	// tarCompressedExtractCreator handles compressed tarballs (.tar.gz, .tar.zst,
+	// .tar.lz4). The shard is extracted into (uncompressed) tarball in the work
+	// file, so that records can be read by offset and the records' content is
+	// the same as in the case of plain tarballs.
+	tarCompressedExtractCreator struct {
+		t         cluster.Target
+		newReader func(r io.Reader) (io.ReadCloser, error)
+		newWriter func(w io.Writer) (io.WriteCloser, error)
+	}
+
+	// zstdReadCloser adapts `zstd.Decoder` to `io.ReadCloser`.
+	zstdReadCloser struct {
+		*zstd.Decoder
+	}
+)
+
+func NewTargzExtractCreator(t cluster.Target) ExtractCreator {
+	return &tarCompressedExtractCreator{
+		t: t,
+		newReader: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriterLevel(w, gzip.BestSpeed)
+		},
+	}
+}
+
+func NewTarzstExtractCreator(t cluster.Target) ExtractCreator {
+	return &tarCompressedExtractCreator{
+		t: t,
+		newReader: func(r io.Reader) (io.ReadCloser, error) {
+			zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+			if err != nil {
+				return nil, err
+			}
+			return &zstdReadCloser{zr}, nil
+		},
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+		},
+	}
+}
+
+func NewTarlz4ExtractCreator(t cluster.Target) ExtractCreator {
+	return &tarCompressedExtractCreator{
+		t: t,
+		newReader: func(r io.Reader) (io.ReadCloser, error) {
+			return ioutil.NopCloser(lz4.NewReader(r)), nil
+		},
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return lz4.NewWriter(w), nil
+		},
+	}
+}
 
-type targzExtractCreator struct {
-	t cluster.Target
+func (zr *zstdReadCloser) Close() error {
+	zr.Decoder.Close()
+	return nil
 }
 
-// ExtractShard reads the tarball f and extracts its metadata.
-func (t *targzExtractCreator) ExtractShard(lom *cluster.LOM, r *io.SectionReader, extractor RecordExtractor,
+// ExtractShard reads the compressed tarball f and extracts its metadata.
+func (t *tarCompressedExtractCreator) ExtractShard(lom *cluster.LOM, r *io.SectionReader, extractor RecordExtractor,
 	toDisk bool) (extractedSize int64, extractedCount int, err error) {
 	var (
 		size    int64
@@ -34,12 +95,12 @@ func (t *targzExtractCreator) ExtractShard(lom *cluster.LOM, r *io.SectionReader
 		workFQN = fs.CSM.GenContentParsedFQN(fqn, filetype.DSortFileType, "") // tarFQN
 	)
 
-	gzr, err := gzip.NewReader(r)
+	cr, err := t.newReader(r)
 	if err != nil {
 		return 0, 0, err
 	}
-	defer cmn.Close(gzr)
-	tr := tar.NewReader(gzr)
+	defer cmn.Close(cr)
+	tr := tar.NewReader(cr)
 
 	// extract to .tar
 	f, err := cmn.CreateFile(workFQN)
@@ -114,25 +175,26 @@ func (t *targzExtractCreator) ExtractShard(lom *cluster.LOM, r *io.SectionReader
 	}
 }
 
-func NewTargzExtractCreator(t cluster.Target) ExtractCreator {
-	return &targzExtractCreator{t: t}
-}
-
 // CreateShard creates a new shard locally based on the Shard.
-// Note that the order of closing must be trw, gzw, then finally tarball.
-func (t *targzExtractCreator) CreateShard(s *Shard, tarball io.Writer, loadContent LoadContentFunc) (written int64, err error) {
+// Note that the order of closing must be trw, cw, then finally tarball.
+func (t *tarCompressedExtractCreator) CreateShard(s *Shard, tarball io.Writer, loadContent LoadContentFunc) (written int64, err error) {
 	var (
 		n         int64
 		needFlush bool
-		gzw, _    = gzip.NewWriterLevel(tarball, gzip.BestSpeed)
-		tw        = tar.NewWriter(gzw)
-		rdReader  = newTarRecordDataReader(t.t)
+	)
+	cw, err := t.newWriter(tarball)
+	if err != nil {
+		return 0, err
+	}
+	var (
+		tw       = tar.NewWriter(cw)
+		rdReader = newTarRecordDataReader(t.t)
 	)
 
 	defer func() {
 		rdReader.free()
 		cmn.Close(tw)
-		cmn.Close(gzw)
+		cmn.Close(cw)
 	}()
 
 	for _, rec := range s.Records.All() {
@@ -148,14 +210,14 @@ func (t *targzExtractCreator) CreateShard(s *Shard, tarball io.Writer, loadConte
 					needFlush = false
 				}
 
-				if n, err = loadContent(gzw, rec, obj); err != nil {
+				if n, err = loadContent(cw, rec, obj); err != nil {
 					return written + n, err
 				}
 
 				// pad to 512 bytes
 				diff := paddedSize(n) - n
 				if diff > 0 {
-					if _, err = gzw.Write(padBuf[:diff]); err != nil {
+					if _, err = cw.Write(padBuf[:diff]); err != nil {
 						return written + n, err
 					}
 					n += diff
@@ -180,14 +242,14 @@ func (t *targzExtractCreator) CreateShard(s *Shard, tarball io.Writer, loadConte
 	return written, nil
 }
 
-func (t *targzExtractCreator) UsingCompression() bool {
+func (t *tarCompressedExtractCreator) UsingCompression() bool {
 	return true
 }
 
-func (t *targzExtractCreator) SupportsOffset() bool {
+func (t *tarCompressedExtractCreator) SupportsOffset() bool {
 	return true
 }
 
-func (t *targzExtractCreator) MetadataSize() int64 {
+func (t *tarCompressedExtractCreator) MetadataSize() int64 {
 	return tarBlockSize // size of tar header with padding
 }
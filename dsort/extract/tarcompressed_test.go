@@ -0,0 +1,65 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TarCompressed", func() {
+	contents := map[string]map[string]string{
+		"sample-0": {".jpg": "image-0", ".cls": "0"},
+		"sample-1": {".jpg": "image-1"},
+	}
+	tarMetadata := func(name string) []byte {
+		return cmn.MustMarshal(tarFileHeader{Name: name, Typeflag: tar.TypeReg, Mode: 0o644})
+	}
+
+	DescribeTable("should create compressed tarball",
+		func(newExtractCreator func(t cluster.Target) ExtractCreator) {
+			var (
+				ec  = newExtractCreator(cluster.NewTargetMock(nil)).(*tarCompressedExtractCreator)
+				buf = &bytes.Buffer{}
+			)
+			Expect(ec.UsingCompression()).To(BeTrue())
+			shard, loadContent := makeTestShard(contents, tarMetadata)
+			_, err := ec.CreateShard(shard, buf, loadContent)
+			Expect(err).NotTo(HaveOccurred())
+
+			cr, err := ec.newReader(buf)
+			Expect(err).NotTo(HaveOccurred())
+			defer cr.Close()
+			var (
+				tr    = tar.NewReader(cr)
+				count int
+			)
+			for {
+				header, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).NotTo(HaveOccurred())
+				b, err := ioutil.ReadAll(tr)
+				Expect(err).NotTo(HaveOccurred())
+				ext := Ext(header.Name)
+				Expect(string(b)).To(Equal(contents[header.Name[:len(header.Name)-len(ext)]][ext]))
+				count++
+			}
+			Expect(count).To(Equal(3))
+		},
+		Entry(".tar.gz", NewTargzExtractCreator),
+		Entry(".tar.zst", NewTarzstExtractCreator),
+		Entry(".tar.lz4", NewTarlz4ExtractCreator),
+	)
+})
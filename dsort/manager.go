@@ -102,7 +102,8 @@ type (
 		smap *cluster.Smap
 
 		recManager     *extract.RecordManager
-		extractCreator extract.ExtractCreator
+		extractCreator extract.ExtractCreator // input shards
+		shardCreator   extract.ExtractCreator // output shards (see `OutputExtension`)
 
 		startShardCreation chan struct{}
 		rs                 *ParsedRequestSpec
@@ -171,6 +172,9 @@ func (m *Manager) init(rs *ParsedRequestSpec) error {
 	targetCount := m.smap.CountActiveTargets()
 
 	m.rs = rs
+	if m.rs.OutputExtension == "" {
+		m.rs.OutputExtension = m.rs.Extension
+	}
 	m.Metrics = newMetrics(rs.Description, rs.ExtendedMetrics)
 	m.startShardCreation = make(chan struct{}, 1)
 
@@ -317,6 +321,7 @@ func (m *Manager) cleanup() {
 	cmn.Assertf(!m.inProgress(), "%s: was still in progress", m.ManagerUUID)
 
 	m.extractCreator = nil
+	m.shardCreator = nil
 	m.client = nil
 
 	m.ctx.smapOwner.Listeners().Unreg(m)
@@ -440,7 +445,11 @@ func (m *Manager) setExtractCreator() (err error) {
 
 	switch m.rs.Algorithm.Kind {
 	case SortKindContent:
-		keyExtractor, err = extract.NewContentKeyExtractor(m.rs.Algorithm.FormatType, m.rs.Algorithm.Extension)
+		if len(m.rs.Algorithm.Keys) > 0 {
+			keyExtractor, err = extract.NewCompositeKeyExtractor(m.rs.Algorithm.Keys, m.rs.Algorithm.Extension)
+		} else {
+			keyExtractor, err = extract.NewContentKeyExtractor(m.rs.Algorithm.FormatType, m.rs.Algorithm.Extension)
+		}
 	case SortKindMD5:
 		keyExtractor, err = extract.NewMD5KeyExtractor()
 	default:
@@ -455,22 +464,18 @@ func (m *Manager) setExtractCreator() (err error) {
 		return m.react(m.rs.DuplicatedRecords, msg)
 	}
 
-	var extractCreator extract.ExtractCreator
-	switch m.rs.Extension {
-	case cmn.ExtTar:
-		extractCreator = extract.NewTarExtractCreator(m.ctx.t)
-	case cmn.ExtTarTgz, cmn.ExtTgz:
-		extractCreator = extract.NewTargzExtractCreator(m.ctx.t)
-	case cmn.ExtZip:
-		extractCreator = extract.NewZipExtractCreator(m.ctx.t)
-	default:
-		cmn.Assertf(false, "unknown extension %s", m.rs.Extension)
+	extractCreator := newExtractCreator(m.ctx.t, m.rs.Extension)
+	shardCreator := extractCreator
+	if m.rs.OutputExtension != m.rs.Extension {
+		shardCreator = newExtractCreator(m.ctx.t, m.rs.OutputExtension)
 	}
 
 	if !m.rs.DryRun {
 		m.extractCreator = extractCreator
+		m.shardCreator = shardCreator
 	} else {
 		m.extractCreator = extract.NopExtractCreator(extractCreator)
+		m.shardCreator = extract.NopExtractCreator(shardCreator)
 	}
 
 	m.recManager = extract.NewRecordManager(m.ctx.t, m.ctx.node.DaemonID, m.rs.Bucket, m.rs.Provider,
@@ -479,6 +484,26 @@ func (m *Manager) setExtractCreator() (err error) {
 	return nil
 }
 
+func newExtractCreator(t cluster.Target, ext string) (extractCreator extract.ExtractCreator) {
+	switch ext {
+	case cmn.ExtTar:
+		extractCreator = extract.NewTarExtractCreator(t)
+	case cmn.ExtTarTgz, cmn.ExtTgz:
+		extractCreator = extract.NewTargzExtractCreator(t)
+	case cmn.ExtTarZst:
+		extractCreator = extract.NewTarzstExtractCreator(t)
+	case cmn.ExtTarLz4:
+		extractCreator = extract.NewTarlz4ExtractCreator(t)
+	case cmn.ExtZip:
+		extractCreator = extract.NewZipExtractCreator(t)
+	case cmn.ExtMsgpack:
+		extractCreator = extract.NewMsgpackExtractCreator(t)
+	default:
+		cmn.Assertf(false, "unknown extension %s", ext)
+	}
+	return
+}
+
 // updateFinishedAck marks daemonID as finished. If all daemons ack then the
 // finalCleanup is dispatched in separate goroutine.
 func (m *Manager) updateFinishedAck(daemonID string) {
@@ -55,6 +55,36 @@ var _ = Describe("Init", func() {
 		Expect(m.init(sr)).NotTo(HaveOccurred())
 		Expect(m.extractCreator.UsingCompression()).To(BeTrue())
 	})
+
+	It("should init with tar.zst extension", func() {
+		m := &Manager{ctx: dsortContext{t: cluster.NewTargetMock(nil)}}
+		sr := &ParsedRequestSpec{Extension: cmn.ExtTarZst, Algorithm: &SortAlgorithm{Kind: SortKindNone}, MaxMemUsage: cmn.ParsedQuantity{Type: cmn.QuantityPercent, Value: 0}, DSorterType: DSorterGeneralType}
+		Expect(m.init(sr)).NotTo(HaveOccurred())
+		Expect(m.extractCreator.UsingCompression()).To(BeTrue())
+	})
+
+	It("should init with tar.lz4 extension", func() {
+		m := &Manager{ctx: dsortContext{t: cluster.NewTargetMock(nil)}}
+		sr := &ParsedRequestSpec{Extension: cmn.ExtTarLz4, Algorithm: &SortAlgorithm{Kind: SortKindNone}, MaxMemUsage: cmn.ParsedQuantity{Type: cmn.QuantityPercent, Value: 0}, DSorterType: DSorterGeneralType}
+		Expect(m.init(sr)).NotTo(HaveOccurred())
+		Expect(m.extractCreator.UsingCompression()).To(BeTrue())
+	})
+
+	It("should init with msgpack extension", func() {
+		m := &Manager{ctx: dsortContext{t: cluster.NewTargetMock(nil)}}
+		sr := &ParsedRequestSpec{Extension: cmn.ExtMsgpack, Algorithm: &SortAlgorithm{Kind: SortKindNone}, MaxMemUsage: cmn.ParsedQuantity{Type: cmn.QuantityPercent, Value: 0}, DSorterType: DSorterGeneralType}
+		Expect(m.init(sr)).NotTo(HaveOccurred())
+		Expect(m.extractCreator.UsingCompression()).To(BeFalse())
+		Expect(m.extractCreator.SupportsOffset()).To(BeFalse())
+	})
+
+	It("should init with different output extension", func() {
+		m := &Manager{ctx: dsortContext{t: cluster.NewTargetMock(nil)}}
+		sr := &ParsedRequestSpec{Extension: cmn.ExtTar, OutputExtension: cmn.ExtTarZst, Algorithm: &SortAlgorithm{Kind: SortKindNone}, MaxMemUsage: cmn.ParsedQuantity{Type: cmn.QuantityPercent, Value: 0}, DSorterType: DSorterGeneralType}
+		Expect(m.init(sr)).NotTo(HaveOccurred())
+		Expect(m.extractCreator.UsingCompression()).To(BeFalse())
+		Expect(m.shardCreator.UsingCompression()).To(BeTrue())
+	})
 })
 
 func BenchmarkRecordsMarshal(b *testing.B) {
@@ -26,7 +26,8 @@ const (
 
 var (
 	errMissingBucket            = errors.New("missing field 'bucket'")
-	errInvalidExtension         = errors.New("extension must be one of '.tar', '.tar.gz', or '.tgz'")
+	errInvalidExtension         = fmt.Errorf("extension must be one of: %v", supportedExtensions)
+	errIncompatibleExtensions   = errors.New("output extension must be the same as input extension (or both must be tarballs: '.tar', '.tar.gz', '.tgz', '.tar.zst', '.tar.lz4')")
 	errNegOutputShardSize       = errors.New("output shard size must be >= 0")
 	errEmptyOutputShardSize     = errors.New("output shard size must be set (cannot be 0)")
 	errNegativeConcurrencyLimit = fmt.Errorf("concurrency max limit must be 0 (limits will be calculated) or > 0")
@@ -42,7 +43,12 @@ var (
 )
 
 // supportedExtensions is a list of supported extensions by dSort
-var supportedExtensions = []string{cmn.ExtTar, cmn.ExtTgz, cmn.ExtTarTgz, cmn.ExtZip}
+var supportedExtensions = []string{cmn.ExtTar, cmn.ExtTgz, cmn.ExtTarTgz, cmn.ExtZip, cmn.ExtTarZst, cmn.ExtTarLz4,
+	cmn.ExtMsgpack}
+
+// tarExtensions - extensions of (possibly compressed) tarballs; shards can be
+// transcoded from any one of them to any other (see `RequestSpec.OutputExtension`).
+var tarExtensions = []string{cmn.ExtTar, cmn.ExtTgz, cmn.ExtTarTgz, cmn.ExtTarZst, cmn.ExtTarLz4}
 
 // TODO: maybe this struct should be composed of `type` and `template` where
 // template is interface and each template has it's own struct. Then we could
@@ -78,6 +84,8 @@ type RequestSpec struct {
 
 	// Optional
 	Description string `json:"description" yaml:"description"`
+	// Default: same as `extension` field
+	OutputExtension string `json:"output_extension" yaml:"output_extension"`
 	// Default: same as `bucket` field
 	OutputBucket string `json:"output_bucket" yaml:"output_bucket"`
 	// Default: alphanumeric, increasing
@@ -116,6 +124,7 @@ type ParsedRequestSpec struct {
 	Provider            string                `json:"provider"`
 	OutputProvider      string                `json:"output_provider"`
 	Extension           string                `json:"extension"`
+	OutputExtension     string                `json:"output_extension"`
 	OutputShardSize     int64                 `json:"output_shard_size,string"`
 	InputFormat         *parsedInputTemplate  `json:"input_format"`
 	OutputFormat        *parsedOutputTemplate `json:"output_format"`
@@ -148,6 +157,10 @@ type SortAlgorithm struct {
 	// Kind: content
 	Extension  string `json:"extension"`
 	FormatType string `json:"format_type"`
+
+	// Kind: content - when set, the content (JSON or CSV sidecar) is parsed and
+	// records are sorted by the composite key made of these fields (in order).
+	Keys []extract.SortKey `json:"keys,omitempty" yaml:"keys,omitempty"`
 }
 
 // Parse returns a non-nil error if a RequestSpec is invalid. When RequestSpec
@@ -186,6 +199,17 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 		return nil, errInvalidExtension
 	}
 	parsedRS.Extension = rs.Extension
+	parsedRS.OutputExtension = rs.OutputExtension
+	if parsedRS.OutputExtension == "" {
+		parsedRS.OutputExtension = parsedRS.Extension
+	}
+	if !validateExtension(parsedRS.OutputExtension) {
+		return nil, errInvalidExtension
+	}
+	if parsedRS.OutputExtension != parsedRS.Extension && (!cmn.StringInSlice(parsedRS.Extension, tarExtensions) ||
+		!cmn.StringInSlice(parsedRS.OutputExtension, tarExtensions)) {
+		return nil, errIncompatibleExtensions
+	}
 
 	parsedRS.OutputShardSize, err = cmn.S2B(rs.OutputShardSize)
 	if err != nil {
@@ -331,7 +355,11 @@ func parseAlgorithm(algo SortAlgorithm) (parsedAlgo *SortAlgorithm, err error) {
 			return nil, errInvalidAlgorithmExtension
 		}
 
-		if err := extract.ValidateAlgorithmFormatType(algo.FormatType); err != nil {
+		if len(algo.Keys) > 0 {
+			if err := extract.ValidateSortKeys(algo.Keys, algo.Extension); err != nil {
+				return nil, err
+			}
+		} else if err := extract.ValidateAlgorithmFormatType(algo.FormatType); err != nil {
 			return nil, err
 		}
 	} else {
@@ -11,6 +11,7 @@ import (
 	"math"
 
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dsort/extract"
 	"github.com/NVIDIA/aistore/fs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -157,6 +158,41 @@ var _ = Describe("RequestSpec", func() {
 			Expect(parsed.Extension).To(Equal(cmn.ExtZip))
 		})
 
+		It("should parse spec with .tar.zst, .tar.lz4 and .msgpack extensions", func() {
+			for _, ext := range []string{cmn.ExtTarZst, cmn.ExtTarLz4, cmn.ExtMsgpack} {
+				rs := RequestSpec{
+					Bucket:          "test",
+					Extension:       ext,
+					InputFormat:     "prefix-{0010..0111}-suffix",
+					OutputFormat:    "prefix-{0010..0111}-suffix",
+					OutputShardSize: "10KB",
+					Algorithm:       SortAlgorithm{Kind: SortKindNone},
+				}
+				parsed, err := rs.Parse()
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Expect(parsed.Extension).To(Equal(ext))
+				Expect(parsed.OutputExtension).To(Equal(ext))
+			}
+		})
+
+		It("should parse spec with output extension of different tarball format", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       cmn.ExtTgz,
+				OutputExtension: cmn.ExtTarZst,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(parsed.Extension).To(Equal(cmn.ExtTgz))
+			Expect(parsed.OutputExtension).To(Equal(cmn.ExtTarZst))
+		})
+
 		It("should parse spec with %06d syntax", func() {
 			rs := RequestSpec{
 				Bucket:          "test",
@@ -360,6 +396,60 @@ var _ = Describe("RequestSpec", func() {
 			Expect(err).To(Equal(errInvalidExtension))
 		})
 
+		It("should parse spec with composite sort keys", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       cmn.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm: SortAlgorithm{
+					Kind:      SortKindContent,
+					Extension: ".json",
+					Keys: []extract.SortKey{
+						{Name: "label", FormatType: extract.FormatTypeString},
+						{Name: "timestamp", FormatType: extract.FormatTypeInt, Decreasing: true},
+					},
+				},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(parsed.Algorithm.Keys).To(Equal(rs.Algorithm.Keys))
+		})
+
+		It("should fail due to invalid sidecar extension of composite sort keys", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       cmn.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm: SortAlgorithm{
+					Kind:      SortKindContent,
+					Extension: ".cls",
+					Keys:      []extract.SortKey{{Name: "label", FormatType: extract.FormatTypeString}},
+				},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should fail due to incompatible output extension", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       cmn.ExtZip,
+				OutputExtension: cmn.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(Equal(errIncompatibleExtensions))
+		})
+
 		It("should fail due to invalid mem usage specification", func() {
 			rs := RequestSpec{
 				Bucket:          "test",
@@ -20,7 +20,7 @@ const (
 	SortKindNone         = "none"         // none, used for resharding
 	SortKindMD5          = "md5"
 	SortKindShuffle      = "shuffle" // shuffle randomly, can be used with seed to get reproducible results
-	SortKindContent      = "content" // sort by content of given file
+	SortKindContent      = "content" // sort by content of given file (or by composite key read from it)
 )
 
 var supportedAlgorithms = []string{sortKindEmpty, SortKindAlphanumeric, SortKindMD5, SortKindShuffle, SortKindContent, SortKindNone}
@@ -30,6 +30,7 @@ type (
 		*extract.Records
 		decreasing bool
 		formatType string
+		keys       []extract.SortKey // composite key fields, if any
 		err        error
 	}
 )
@@ -44,7 +45,10 @@ func (s *alphaByKey) Less(i, j int) bool {
 	)
 
 	if s.decreasing {
-		less, err = s.Records.Less(j, i, s.formatType)
+		i, j = j, i
+	}
+	if len(s.keys) > 0 {
+		less, err = s.Records.LessByKeys(i, j, s.keys)
 	} else {
 		less, err = s.Records.Less(i, j, s.formatType)
 	}
@@ -74,7 +78,7 @@ func sortRecords(r *extract.Records, algo *SortAlgorithm) (err error) {
 			r.Swap(i, j)
 		}
 	} else {
-		keys := &alphaByKey{r, algo.Decreasing, algo.FormatType, nil}
+		keys := &alphaByKey{r, algo.Decreasing, algo.FormatType, algo.Keys, nil}
 		sort.Sort(keys)
 
 		if keys.err != nil {
@@ -88,6 +88,58 @@ var _ = Describe("SortRecords", func() {
 		Expect(fm).To(Equal(expected))
 	})
 
+	It("should sort records by composite key", func() {
+		keys := []extract.SortKey{
+			{Name: "label", FormatType: extract.FormatTypeString},
+			{Name: "timestamp", FormatType: extract.FormatTypeInt, Decreasing: true},
+		}
+		expected := createRecords(
+			[]interface{}{"cat", int64(20)},
+			[]interface{}{"cat", int64(10)},
+			[]interface{}{"dog", int64(30)},
+			[]interface{}{"dog", int64(5)},
+		)
+		fm := createRecords(
+			[]interface{}{"dog", int64(5)},
+			[]interface{}{"cat", int64(10)},
+			[]interface{}{"dog", int64(30)},
+			[]interface{}{"cat", int64(20)},
+		)
+		err := sortRecords(fm, &SortAlgorithm{Kind: SortKindContent, Keys: keys})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fm).To(Equal(expected))
+	})
+
+	It("should sort records by composite key decreasing", func() {
+		keys := []extract.SortKey{
+			{Name: "label", FormatType: extract.FormatTypeString},
+			{Name: "timestamp", FormatType: extract.FormatTypeInt, Decreasing: true},
+		}
+		expected := createRecords(
+			[]interface{}{"dog", float64(5)},
+			[]interface{}{"dog", int64(30)},
+			[]interface{}{"cat", int64(10)},
+		)
+		// NOTE: keys received from other targets (JSON) may have ints as floats.
+		fm := createRecords(
+			[]interface{}{"cat", int64(10)},
+			[]interface{}{"dog", int64(30)},
+			[]interface{}{"dog", float64(5)},
+		)
+		err := sortRecords(fm, &SortAlgorithm{Kind: SortKindContent, Decreasing: true, Keys: keys})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fm).To(Equal(expected))
+	})
+
+	It("should return error when composite key is missing", func() {
+		keys := []extract.SortKey{{Name: "label", FormatType: extract.FormatTypeString}}
+		fm := createRecords([]interface{}{"cat"}, []interface{}{"dog"})
+		fm.All()[0].Key = nil
+
+		err := sortRecords(fm, &SortAlgorithm{Kind: SortKindContent, Keys: keys})
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("should return error when some keys are missing", func() {
 		fm := createRecords("def", "abc")
 		fm.All()[0].Key = nil
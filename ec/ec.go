@@ -410,6 +410,8 @@ func LomFromHeader(t cluster.Target, hdr transport.ObjHdr) (*cluster.LOM, error)
 		return nil, err
 	}
 	lom.SetSize(hdr.ObjAttrs.Size)
+	lom.SetSSE(hdr.ObjAttrs.SSE)
+	lom.SetCompression(hdr.ObjAttrs.Compress)
 	if hdr.ObjAttrs.Version != "" {
 		lom.SetVersion(hdr.ObjAttrs.Version)
 	}
@@ -221,6 +221,8 @@ func (c *getJogger) restoreReplicatedFromMemory(req *Request, meta *Metadata, no
 	}
 
 	req.LOM.SetSize(writer.Size())
+	req.LOM.SetSSE(meta.ObjSSE)
+	req.LOM.SetCompression(meta.ObjCompress)
 	args := &WriteArgs{
 		Reader:     memsys.NewReader(writer),
 		MD:         cmn.MustMarshal(meta),
@@ -266,6 +268,8 @@ func (c *getJogger) restoreReplicatedFromDisk(req *Request, meta *Metadata, node
 		if err == nil && n != 0 {
 			// a valid replica is found - break and do not free SGL
 			req.LOM.SetSize(n)
+			req.LOM.SetSSE(meta.ObjSSE)
+			req.LOM.SetCompression(meta.ObjCompress)
 			writer = w
 			break
 		}
@@ -591,6 +595,8 @@ func (c *getJogger) restoreMainObj(req *Request, meta *Metadata, slices []*slice
 		req.LOM.SetVersion(version)
 	}
 	req.LOM.SetSize(meta.Size)
+	req.LOM.SetSSE(meta.ObjSSE)
+	req.LOM.SetCompression(meta.ObjCompress)
 	mainMeta := *meta
 	mainMeta.SliceID = 0
 	args := &WriteArgs{
@@ -160,12 +160,14 @@ func (c *putJogger) encode(req *Request) error {
 		cksumType, cksumValue = req.LOM.Cksum().Get()
 	}
 	meta := &Metadata{
-		Size:      req.LOM.Size(),
-		Data:      ecConf.DataSlices,
-		Parity:    ecConf.ParitySlices,
-		IsCopy:    req.IsCopy,
-		ObjCksum:  cksumValue,
-		CksumType: cksumType,
+		Size:        req.LOM.Size(),
+		Data:        ecConf.DataSlices,
+		Parity:      ecConf.ParitySlices,
+		IsCopy:      req.IsCopy,
+		ObjCksum:    cksumValue,
+		CksumType:   cksumType,
+		ObjSSE:      req.LOM.SSE(),
+		ObjCompress: req.LOM.Compression(),
 	}
 
 	// calculate the number of targets required to encode the object
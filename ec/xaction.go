@@ -179,6 +179,8 @@ func (r *xactECBase) newReplicaResponse(attrs *transport.ObjectAttrs, bck *clust
 	attrs.Size = lom.Size()
 	attrs.Version = lom.Version()
 	attrs.Atime = lom.AtimeUnix()
+	attrs.SSE = lom.SSE()
+	attrs.Compress = lom.Compression()
 	if lom.Cksum() != nil {
 		attrs.CksumType, attrs.CksumValue = lom.Cksum().Get()
 	}
@@ -361,9 +363,11 @@ func (r *xactECBase) writeRemote(daemonIDs []string, lom *cluster.LOM, src *data
 	mm := r.t.SmallMMSA()
 	putData := req.NewPack(mm)
 	objAttrs := transport.ObjectAttrs{
-		Size:    src.size,
-		Version: lom.Version(),
-		Atime:   lom.AtimeUnix(),
+		Size:     src.size,
+		Version:  lom.Version(),
+		Atime:    lom.AtimeUnix(),
+		SSE:      lom.SSE(),
+		Compress: lom.Compression(),
 	}
 	if src.metadata != nil && src.metadata.SliceID != 0 {
 		// for a slice read everything from slice's metadata
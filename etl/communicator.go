@@ -158,7 +158,7 @@ func (pc *pushComm) tryDoRequest(lom *cluster.LOM) (*http.Response, error) {
 	}
 
 	// `fh` is closed by Do(req).
-	fh, err := cmn.NewFileHandle(lom.GetFQN())
+	fh, err := lom.Open()
 	if err != nil {
 		return nil, err
 	}
@@ -167,7 +167,7 @@ func (pc *pushComm) tryDoRequest(lom *cluster.LOM) (*http.Response, error) {
 		return nil, err
 	}
 
-	req.ContentLength = lom.Size()
+	req.ContentLength = lom.ContentSize()
 	req.Header.Set(cmn.HeaderContentType, cmn.ContentBinary)
 	return pc.t.Client().Do(req)
 }
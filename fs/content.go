@@ -33,6 +33,11 @@ const (
 	contentTypeLen = 2
 	ObjectType     = "ob"
 	WorkfileType   = "wk"
+	VersionType    = "vr" // noncurrent object versions and delete markers
+	MptType        = "mp" // parts and manifests of S3 multipart uploads in progress
+
+	// separates object name and version in the base name of a version file
+	VersionSepa = ";"
 )
 
 type (
@@ -160,6 +165,8 @@ func (f *ContentSpecMgr) PermToProcess(fqn string) (ok bool) {
 type (
 	ObjectContentResolver   struct{}
 	WorkfileContentResolver struct{}
+	VersionContentResolver  struct{}
+	MptContentResolver      struct{}
 )
 
 func (wf *ObjectContentResolver) PermToMove() bool    { return true }
@@ -211,3 +218,46 @@ func (wf *WorkfileContentResolver) ParseUniqueFQN(base string) (orig string, old
 
 	return base[:tieIndex], filePID != pid, true
 }
+
+// Noncurrent versions are stored on the same mountpath as the object itself
+// (the one determined by the object's HRW) - rebalance and resilver move them
+// along with the object.
+func (vr *VersionContentResolver) PermToMove() bool    { return true }
+func (vr *VersionContentResolver) PermToEvict() bool   { return false }
+func (vr *VersionContentResolver) PermToProcess() bool { return false }
+
+func (vr *VersionContentResolver) GenUniqueFQN(base, version string) string {
+	return base + VersionSepa + version
+}
+
+func (vr *VersionContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	orig, _, ok = SplitVersion(base)
+	return
+}
+
+// SplitVersion splits the name of a version file, e.g. "a/b/c;3", into
+// the object name and its version.
+func SplitVersion(name string) (objName, version string, ok bool) {
+	i := strings.LastIndex(name, VersionSepa)
+	if i <= 0 || i == len(name)-1 {
+		return "", "", false
+	}
+	if _, err := strconv.ParseUint(name[i+1:], 10, 64); err != nil {
+		return "", "", false
+	}
+	return name[:i], name[i+1:], true
+}
+
+// Multipart uploads are kept by the target (and mountpath) the upload has
+// started on - until the upload completes, gets aborted or expires.
+func (mp *MptContentResolver) PermToMove() bool    { return false }
+func (mp *MptContentResolver) PermToEvict() bool   { return false }
+func (mp *MptContentResolver) PermToProcess() bool { return false }
+
+func (mp *MptContentResolver) GenUniqueFQN(base, _ string) string {
+	return base
+}
+
+func (mp *MptContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
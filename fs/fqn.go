@@ -21,11 +21,12 @@ const (
 
 const (
 	// prefixes for workfiles created by various services
-	WorkfileRemote  = "remote" // getting object from neighbor target while rebalance is running
-	WorkfileColdget = "cold"   // object GET: coldget
-	WorkfilePut     = "put"    // object PUT
-	WorkfileAppend  = "append" // object APPEND
-	WorkfileFSHC    = "fshc"   // FSHC test file
+	WorkfileRemote   = "remote" // getting object from neighbor target while rebalance is running
+	WorkfileColdget  = "cold"   // object GET: coldget
+	WorkfilePut      = "put"    // object PUT
+	WorkfileAppend   = "append" // object APPEND
+	WorkfileDownload = "dl"     // downloader: partially downloaded object (resumed via HTTP Range)
+	WorkfileFSHC     = "fshc"   // FSHC test file
 )
 
 type ParsedFQN struct {
@@ -279,7 +279,11 @@ func (j *jogger) visitFQN(fqn string, buf []byte) error {
 	}
 
 	if j.opts.SkipGloballyMisplaced {
-		uname := ct.Bck().MakeUname(ct.ObjName())
+		objName := ct.ObjName()
+		if ct.ContentType() == fs.VersionType {
+			objName, _, _ = fs.SplitVersion(objName) // versions follow their objects
+		}
+		uname := ct.Bck().MakeUname(objName)
 		tsi, err := cluster.HrwTarget(uname, j.opts.T.Sowner().Get()) // TODO: should we get smap once?
 		if err != nil {
 			return err
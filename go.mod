@@ -23,7 +23,7 @@ require (
 	github.com/jacobsa/fuse v0.0.0-20200706075950-f8927095af03
 	github.com/json-iterator/go v1.1.10
 	github.com/karrick/godirwalk v1.16.1
-	github.com/klauspost/compress v1.11.0 // indirect
+	github.com/klauspost/compress v1.11.0
 	github.com/klauspost/cpuid v1.3.1 // indirect
 	github.com/klauspost/reedsolomon v1.9.9
 	github.com/kylelemons/godebug v1.1.0 // indirect
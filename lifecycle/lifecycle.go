@@ -0,0 +1,332 @@
+// Package lifecycle enforces bucket lifecycle rules: removes expired objects and
+// evicts cached copies of remote objects that have not been accessed for a while.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+)
+
+// Lifecycle rules are defined on a per-bucket basis (see cmn.LifecycleConf)
+// and get applied by the `lifecycle` extended action. Each target runs the
+// xaction periodically (every `Interval`) provided that at least one bucket
+// has its lifecycle enabled; the xaction can be also started via API.
+//
+// Similar to LRU, the xaction runs a single jogger per mountpath. Each jogger
+// traverses the objects of the buckets with enabled lifecycle and, for each
+// object that has its name matching a rule's prefix:
+//   - removes the object if it was last modified more than `expire_days` ago;
+//   - evicts the object if the bucket is remote and the object was not accessed
+//     for more than `evict_days`.
+// In ais buckets that keep previous versions of objects (see
+// `versioning.keep_previous`), removing an object creates a delete marker, and
+// previous versions get removed `noncurrent_days` after they became
+// noncurrent. Delete markers are removed only when the object exists.
+
+const (
+	Interval = time.Hour // between periodic runs
+
+	day           = 24 * time.Hour
+	throttleCheck = 256 // check mountpath utilization every so many objects
+)
+
+type (
+	InitLifecycle struct {
+		T       cluster.Target
+		Xaction *Xaction
+	}
+
+	// parent - contains mpath joggers
+	lcP struct {
+		wg      sync.WaitGroup
+		joggers map[string]*lcJ
+		ini     InitLifecycle
+		bcks    []*cluster.Bck
+	}
+
+	// lcJ represents a single /jogger/ that traverses and applies
+	// lifecycle rules to the objects stored on a given mountpath.
+	lcJ struct {
+		// runtime
+		bck *cluster.Bck
+		now time.Time
+		cnt int64
+		// init-time
+		p         *lcP
+		ini       *InitLifecycle
+		stopCh    chan struct{}
+		mpathInfo *fs.MountpathInfo
+		config    *cmn.Config
+	}
+
+	XactProvider struct {
+		xact *Xaction
+
+		id string
+	}
+
+	Xaction struct {
+		xaction.XactBase
+	}
+)
+
+// interface guard
+var _ cluster.Xact = (*Xaction)(nil)
+
+func init() {
+	xreg.RegisterGlobalXact(&XactProvider{})
+}
+
+func (*XactProvider) New(args xreg.XactArgs) xreg.GlobalEntry {
+	return &XactProvider{id: args.UUID}
+}
+
+func (p *XactProvider) Start(_ cmn.Bck) error {
+	p.xact = &Xaction{XactBase: *xaction.NewXactBase(xaction.XactBaseID(p.id), cmn.ActLifecycle)}
+	return nil
+}
+func (*XactProvider) Kind() string                         { return cmn.ActLifecycle }
+func (p *XactProvider) Get() cluster.Xact                  { return p.xact }
+func (*XactProvider) PreRenewHook(_ xreg.GlobalEntry) bool { return true } // keep the running one
+func (*XactProvider) PostRenewHook(_ xreg.GlobalEntry)     {}
+func (r *Xaction) Run() error                              { cmn.Assert(false); return nil }
+
+// Buckets returns the buckets that have lifecycle enabled.
+func Buckets(bmd *cluster.BMD) (bcks []*cluster.Bck) {
+	bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+		if bck.Props.Lifecycle.Enabled && len(bck.Props.Lifecycle.Rules) > 0 {
+			bcks = append(bcks, bck)
+		}
+		return false
+	})
+	return
+}
+
+func Run(ini *InitLifecycle) {
+	var (
+		xlc               = ini.Xaction
+		config            = cmn.GCO.Get()
+		availablePaths, _ = fs.Get()
+		joggers           = make(map[string]*lcJ, len(availablePaths))
+		parent            = &lcP{joggers: joggers, ini: *ini, bcks: Buckets(ini.T.Bowner().Get())}
+		fail              atomic.Bool
+	)
+	glog.Infof("%s: %s started: %d bucket(s)", ini.T.Snode(), xlc, len(parent.bcks))
+	if len(availablePaths) == 0 {
+		glog.Errorln(cmn.NoMountpaths)
+		return
+	}
+	if len(parent.bcks) == 0 {
+		return
+	}
+	for mpath, mpathInfo := range availablePaths {
+		joggers[mpath] = &lcJ{
+			stopCh:    make(chan struct{}, 1),
+			mpathInfo: mpathInfo,
+			config:    config,
+			ini:       &parent.ini,
+			p:         parent,
+		}
+	}
+	for _, j := range joggers {
+		parent.wg.Add(1)
+		go func(j *lcJ) {
+			defer j.p.wg.Done()
+			if err := j.jog(); err != nil && !os.IsNotExist(err) {
+				glog.Errorf("%s: exited with err %v", j, err)
+				if fail.CAS(false, true) {
+					for _, j := range joggers {
+						j.stop()
+					}
+				}
+			}
+		}(j)
+	}
+	parent.wg.Wait()
+}
+
+/////////
+// lcJ //
+/////////
+
+func (j *lcJ) String() string {
+	return fmt.Sprintf("%s: (%s, %s)", j.ini.T.Snode(), j.ini.Xaction, j.mpathInfo)
+}
+func (j *lcJ) stop() { j.stopCh <- struct{}{} }
+
+func (j *lcJ) jog() error {
+	for _, bck := range j.p.bcks {
+		j.bck = bck
+		j.now = time.Now()
+		opts := &fs.Options{
+			Mpath:    j.mpathInfo,
+			Bck:      bck.Bck,
+			CTs:      []string{fs.ObjectType, fs.VersionType},
+			Callback: j.walk,
+			Sorted:   false,
+		}
+		if err := fs.Walk(opts); err != nil {
+			if os.IsNotExist(err) {
+				continue // nothing stored on this mountpath
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *lcJ) walk(fqn string, de fs.DirEntry) error {
+	if de.IsDir() {
+		return nil
+	}
+	if err := j.yieldTerm(); err != nil {
+		return err
+	}
+	lom := &cluster.LOM{T: j.ini.T, FQN: fqn}
+	if err := lom.Init(j.bck.Bck, j.config); err != nil {
+		return nil
+	}
+	if lom.ParsedFQN.ContentType == fs.VersionType {
+		j.walkVersion(lom)
+		return nil
+	}
+	expire, evict, _ := periods(j.bck.Props.Lifecycle.Rules, lom.ObjName)
+	if expire == 0 && evict == 0 {
+		return nil
+	}
+	if err := lom.Load(false); err != nil {
+		return nil
+	}
+	// skip copies and misplaced objects - the latter are handled by resilver and LRU
+	if !lom.IsHRW() {
+		return nil
+	}
+	if expire > 0 {
+		if finfo, err := os.Stat(lom.FQN); err == nil && finfo.ModTime().Add(expire).Before(j.now) {
+			j.remove(lom, false /*evict*/)
+			return nil
+		}
+	}
+	if evict > 0 && j.bck.IsRemote() && lom.Atime().Add(evict).Before(j.now) {
+		j.remove(lom, true /*evict*/)
+	}
+	return nil
+}
+
+// removes a previous version of the object if it is noncurrent for too long
+func (j *lcJ) walkVersion(ct *cluster.LOM) {
+	objName, version, ok := fs.SplitVersion(ct.ObjName)
+	if !ok {
+		return
+	}
+	_, _, noncurrent := periods(j.bck.Props.Lifecycle.Rules, objName)
+	if noncurrent == 0 {
+		return
+	}
+	if finfo, err := os.Stat(ct.FQN); err != nil || !finfo.ModTime().Add(noncurrent).Before(j.now) {
+		return
+	}
+	lom := &cluster.LOM{T: j.ini.T, ObjName: objName}
+	if err := lom.Init(j.bck.Bck, j.config); err != nil {
+		return
+	}
+	// skip misplaced versions - resilver and rebalance move them to the object's mountpath
+	if fqn, err := lom.VersionFQN(version); err != nil || fqn != ct.FQN {
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	vlom, err := lom.LoadVersion(version)
+	if err != nil || vlom.CheckRetention(false /*bypass governance*/) != nil {
+		return
+	}
+	// the delete marker of a (currently) deleted object is the object's current version
+	if vlom.IsDelMarker() && fs.Access(lom.FQN) != nil {
+		return
+	}
+	if err := cmn.RemoveFile(vlom.FQN); err != nil {
+		glog.Errorf("%s: failed to remove %s version %q, err: %v", j, lom, version, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: removed %s version %q", j, lom, version)
+	}
+	j.ini.Xaction.ObjectsInc()
+	j.ini.Xaction.BytesAdd(vlom.Size())
+}
+
+func (j *lcJ) remove(lom *cluster.LOM, evict bool) {
+	size := lom.Size()
+	if _, err := j.ini.T.DeleteObject(context.Background(), lom, evict); err != nil {
+		if !cmn.IsObjNotExist(err) && !cmn.IsErrObjLocked(err) {
+			glog.Errorf("%s: failed to remove %s (evict: %t), err: %v", j, lom, evict, err)
+		}
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: removed %s (evict: %t)", j, lom, evict)
+	}
+	j.ini.Xaction.ObjectsInc()
+	j.ini.Xaction.BytesAdd(size)
+}
+
+func (j *lcJ) yieldTerm() error {
+	xlc := j.ini.Xaction
+	select {
+	case <-xlc.ChanAbort():
+		return cmn.NewAbortedError(xlc.String())
+	case <-j.stopCh:
+		return cmn.NewAbortedError(xlc.String())
+	default:
+		break
+	}
+	if xlc.Finished() {
+		return cmn.NewAbortedError(xlc.String())
+	}
+	// throttle self when the mountpath is busy
+	j.cnt++
+	if j.cnt%throttleCheck == 0 {
+		if curr := fs.GetMpathUtil(j.mpathInfo.Path); curr >= j.config.Disk.DiskUtilHighWM {
+			time.Sleep(cmn.ThrottleMax)
+		}
+	}
+	return nil
+}
+
+// Returns the expiration, eviction, and noncurrent version expiration periods
+// that apply to a given object: when multiple rules match the object name,
+// the shortest period wins. Zero period means that the corresponding action
+// does not apply.
+func periods(rules cmn.LifecycleRules, objName string) (expire, evict, noncurrent time.Duration) {
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Disabled || !strings.HasPrefix(objName, rule.Prefix) {
+			continue
+		}
+		if d := time.Duration(rule.ExpireDays) * day; d > 0 && (expire == 0 || d < expire) {
+			expire = d
+		}
+		if d := time.Duration(rule.EvictDays) * day; d > 0 && (evict == 0 || d < evict) {
+			evict = d
+		}
+		if d := time.Duration(rule.NoncurrentDays) * day; d > 0 && (noncurrent == 0 || d < noncurrent) {
+			noncurrent = d
+		}
+	}
+	return
+}
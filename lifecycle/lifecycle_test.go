@@ -0,0 +1,41 @@
+// Package lifecycle enforces bucket lifecycle rules: removes expired objects and
+// evicts cached copies of remote objects that have not been accessed for a while.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestPeriods(t *testing.T) {
+	rules := cmn.LifecycleRules{
+		{ID: "all", EvictDays: 30},
+		{ID: "logs", Prefix: "logs/", ExpireDays: 7},
+		{ID: "tmp", Prefix: "logs/tmp/", ExpireDays: 1, EvictDays: 2},
+		{ID: "versions", Prefix: "logs/", NoncurrentDays: 3},
+		{ID: "disabled", Prefix: "logs/", ExpireDays: 1, Disabled: true},
+	}
+	tests := []struct {
+		objName                   string
+		expire, evict, noncurrent time.Duration
+	}{
+		{"data/obj", 0, 30 * day, 0},
+		{"logs/obj", 7 * day, 30 * day, 3 * day},
+		{"logs/tmp/obj", day, 2 * day, 3 * day},
+	}
+	for _, test := range tests {
+		expire, evict, noncurrent := periods(rules, test.objName)
+		tassert.Errorf(t, expire == test.expire && evict == test.evict && noncurrent == test.noncurrent,
+			"%s: expected (%v, %v, %v), got (%v, %v, %v)", test.objName,
+			test.expire, test.evict, test.noncurrent, expire, evict, noncurrent)
+	}
+	expire, evict, noncurrent := periods(nil, "obj")
+	tassert.Errorf(t, expire == 0 && evict == 0 && noncurrent == 0,
+		"expected no periods, got (%v, %v, %v)", expire, evict, noncurrent)
+}
@@ -380,6 +380,14 @@ func (j *lruJ) walk(fqn string, de fs.DirEntry) error {
 	if lom.AtimeUnix()+int64(j.config.LRU.DontEvictTime) > j.now {
 		return nil
 	}
+	// locked objects are never evicted (see cmn.ObjectLockConf)
+	if lom.CheckRetention(false /*bypass governance*/) != nil {
+		return nil
+	}
+	// neither are dirty objects (see cmn.WriteBackConf)
+	if lom.Dirty() {
+		return nil
+	}
 	if lom.HasCopies() && lom.IsCopy() {
 		return nil
 	}
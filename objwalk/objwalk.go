@@ -81,6 +81,8 @@ func (w *Walk) CloudObjPage() (*cmn.BucketList, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Not all cloud providers support delimiter natively
+	objList.Entries = cmn.RollUpBckEntries(objList.Entries, w.msg.Prefix, w.msg.Delimiter)
 
 	var (
 		config          = cmn.GCO.Get()
@@ -97,6 +99,9 @@ func (w *Walk) CloudObjPage() (*cmn.BucketList, error) {
 	)
 
 	for _, e := range objList.Entries {
+		if e.IsDir() {
+			continue
+		}
 		si, _ := cluster.HrwTarget(w.bck.MakeUname(e.Name), smap)
 		if si.ID() != localID {
 			continue
@@ -69,3 +69,34 @@ func TestConcatObjLists(t *testing.T) {
 		})
 	}
 }
+
+func TestRollUpBckEntries(t *testing.T) {
+	tests := []struct {
+		name     string
+		objNames []string
+		prefix   string
+		delim    string
+		expected []string
+	}{
+		{name: "no_delim", objNames: []string{"a/b", "a/c", "d"}, expected: []string{"a/b", "a/c", "d"}},
+		{name: "root", objNames: []string{"a/b", "a/c/d", "e", "f/g"}, delim: "/", expected: []string{"a/", "e", "f/"}},
+		{name: "prefix", objNames: []string{"a/b", "a/c/d", "a/c/e", "a/f"}, prefix: "a/", delim: "/", expected: []string{"a/b", "a/c/", "a/f"}},
+		{name: "partial_prefix", objNames: []string{"ab/c", "ad/e", "af"}, prefix: "a", delim: "/", expected: []string{"ab/", "ad/", "af"}},
+		{name: "multichar_delim", objNames: []string{"a--b", "a--c", "a-d"}, delim: "--", expected: []string{"a--", "a-d"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries := make([]*cmn.BucketEntry, 0, len(test.objNames))
+			for _, name := range test.objNames {
+				entries = append(entries, &cmn.BucketEntry{Name: name})
+			}
+			entries = cmn.RollUpBckEntries(entries, test.prefix, test.delim)
+			tassert.Fatalf(t, len(entries) == len(test.expected), "expected %v, got %v", test.expected, entries)
+			for i, entry := range entries {
+				_, isDir := cmn.DirName(test.expected[i], test.prefix, test.delim)
+				tassert.Errorf(t, entry.Name == test.expected[i], "expected %q, got %q", test.expected[i], entry.Name)
+				tassert.Errorf(t, entry.IsDir() == isDir, "%q: expected dir=%t", entry.Name, isDir)
+			}
+		})
+	}
+}
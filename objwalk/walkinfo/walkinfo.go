@@ -29,6 +29,8 @@ type (
 		markerDir    string
 		msg          *cmn.SelectMsg
 		timeFormat   string
+		delimiter    string
+		lastDir      string // the last "directory" returned when rolling up by delimiter
 	}
 
 	PostCallbackFunc func(lom *cluster.LOM)
@@ -77,6 +79,7 @@ func NewWalkInfo(ctx context.Context, t cluster.Target, msg *cmn.SelectMsg) *Wal
 		markerDir:    markerDir,
 		msg:          msg,
 		timeFormat:   msg.TimeFormat,
+		delimiter:    msg.Delimiter,
 		propNeeded:   propNeeded,
 	}
 }
@@ -114,6 +117,14 @@ func (wi *WalkInfo) ProcessDir(fqn string) error {
 		return filepath.SkipDir
 	}
 
+	// When rolling up by delimiter, all objects of a directory may belong to
+	// a single "directory" entry that has been returned by the previous call.
+	if wi.delimiter != "" && wi.Marker != "" {
+		if dir, ok := cmn.DirName(ct.ObjName()+"/", wi.prefix, wi.delimiter); ok && dir <= wi.Marker {
+			return filepath.SkipDir
+		}
+	}
+
 	return nil
 }
 
@@ -121,12 +132,22 @@ func (wi *WalkInfo) SetObjectFilter(f cluster.ObjectFilter) {
 	wi.objectFilter = f
 }
 
+// CTs returns the content types to walk: objects and, if requested, their
+// previous versions (see cmn.SelectVersions).
+func (wi *WalkInfo) CTs() []string {
+	if wi.msg.IsFlagSet(cmn.SelectVersions) {
+		return []string{fs.ObjectType, fs.VersionType}
+	}
+	return []string{fs.ObjectType}
+}
+
 // Adds an info about cached object to the list if:
 //  - its name starts with prefix (if prefix is set)
 //  - it has not been already returned by previous page request
 //  - this target responses getobj request for the object
-func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntry {
-	objName := lom.ParsedFQN.ObjName
+// If the object is rolled up by delimiter, the entry of its "directory" is
+// added instead.
+func (wi *WalkInfo) lsObject(lom *cluster.LOM, objName string, objStatus uint16, dir string) *cmn.BucketEntry {
 	if wi.prefix != "" && !strings.HasPrefix(objName, wi.prefix) {
 		return nil
 	}
@@ -136,6 +157,10 @@ func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntr
 	if wi.objectFilter != nil && !wi.objectFilter(lom) {
 		return nil
 	}
+	if dir != "" {
+		wi.lastDir = dir
+		return &cmn.BucketEntry{Name: dir, Flags: cmn.EntryIsDir}
+	}
 
 	// add the obj to the page
 	fileInfo := &cmn.BucketEntry{
@@ -159,7 +184,7 @@ func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntr
 		fileInfo.TargetURL = wi.t.Snode().URL(cmn.NetworkPublic)
 	}
 	if wi.needSize() {
-		fileInfo.Size = lom.Size()
+		fileInfo.Size = lom.ContentSize()
 	}
 	if wi.postCallback != nil {
 		wi.postCallback(lom)
@@ -185,6 +210,17 @@ func (wi *WalkInfo) Callback(fqn string, de fs.DirEntry) (*cmn.BucketEntry, erro
 		return nil, err
 	}
 
+	// Roll up the object into a "directory": skip the objects of the directory
+	// that has been already returned, so that only one object per directory
+	// is loaded.
+	dir, _ := cmn.DirName(lom.ParsedFQN.ObjName, wi.prefix, wi.delimiter)
+	if dir != "" && (dir == wi.lastDir || (wi.Marker != "" && dir <= wi.Marker)) {
+		return nil, nil
+	}
+	if lom.ParsedFQN.ContentType == fs.VersionType {
+		return wi.lsVersion(lom, dir)
+	}
+
 	if err := lom.Load(); err != nil {
 		if cmn.IsErrObjNought(err) {
 			return nil, nil
@@ -208,5 +244,46 @@ func (wi *WalkInfo) Callback(fqn string, de fs.DirEntry) (*cmn.BucketEntry, erro
 	if objStatus == cmn.ObjStatusMoved && !wi.msg.IsFlagSet(cmn.SelectMisplaced) {
 		return nil, nil
 	}
-	return wi.lsObject(lom, objStatus), nil
+	return wi.lsObject(lom, lom.ParsedFQN.ObjName, objStatus, dir), nil
+}
+
+// Previous versions and delete markers are listed as separate entries named
+// "<object name>;<version>" - the names of the respective version files.
+// Only the versions stored at the object's HRW location are listed.
+func (wi *WalkInfo) lsVersion(ct *cluster.LOM, dir string) (*cmn.BucketEntry, error) {
+	objName, version, ok := fs.SplitVersion(ct.ParsedFQN.ObjName)
+	if !ok {
+		return nil, nil
+	}
+	lom := &cluster.LOM{T: wi.t, ObjName: objName}
+	if err := lom.Init(ct.Bck().Bck); err != nil {
+		return nil, err
+	}
+	if fqn, err := lom.VersionFQN(version); err != nil || fqn != ct.FQN {
+		return nil, nil
+	}
+	si, err := cluster.HrwTarget(lom.Uname(), wi.smap)
+	if err != nil {
+		return nil, err
+	}
+	if wi.t.Snode().ID() != si.ID() {
+		return nil, nil
+	}
+	vlom, err := lom.LoadVersion(version)
+	if err != nil {
+		if cmn.IsErrObjNought(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	entry := wi.lsObject(vlom, ct.ParsedFQN.ObjName, cmn.ObjStatusOK, dir)
+	if entry == nil || entry.IsDir() {
+		return entry, nil
+	}
+	entry.Flags |= cmn.EntryIsVersion
+	if vlom.IsDelMarker() {
+		entry.Flags |= cmn.EntryDelMarker
+	}
+	entry.Version = version
+	return entry, nil
 }
@@ -216,7 +216,7 @@ func ATimeBeforeFilterMsg(before time.Time) *FilterMsg {
 
 func SizeFilter(min, max int64) cluster.ObjectFilter {
 	return func(lom *cluster.LOM) bool {
-		return lom.Size() >= min && lom.Size() <= max
+		return lom.ContentSize() >= min && lom.ContentSize() <= max
 	}
 }
 
@@ -255,7 +255,9 @@ func SizeGEFilterMsg(n int64) *FilterMsg {
 func VersionFilter(min, max int) cluster.ObjectFilter {
 	return func(lom *cluster.LOM) bool {
 		intVersion, err := strconv.Atoi(lom.Version())
-		cmn.AssertNoErr(err)
+		if err != nil {
+			return false // e.g., cloud version (not a number)
+		}
 		return intVersion >= min && intVersion <= max
 	}
 }
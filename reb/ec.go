@@ -334,6 +334,8 @@ func (reb *Manager) sendFromDisk(ct *rebCT, target *cluster.Snode) error {
 	if lom != nil {
 		o.Hdr.ObjAttrs.Atime = lom.AtimeUnix()
 		o.Hdr.ObjAttrs.Version = lom.Version()
+		o.Hdr.ObjAttrs.SSE = lom.SSE()
+		o.Hdr.ObjAttrs.Compress = lom.Compression()
 		if cksum := lom.Cksum(); cksum != nil {
 			o.Hdr.ObjAttrs.CksumType, o.Hdr.ObjAttrs.CksumValue = cksum.Get()
 		}
@@ -1779,6 +1781,8 @@ func (reb *Manager) restoreObject(obj *rebObject, objMD *ec.Metadata, src io.Rea
 		return err
 	}
 	lom.SetSize(obj.objSize)
+	lom.SetSSE(objMD.ObjSSE)
+	lom.SetCompression(objMD.ObjCompress)
 	args := &ec.WriteArgs{
 		Reader:    src,
 		MD:        cmn.MustMarshal(objMD),
@@ -1811,6 +1815,8 @@ func (reb *Manager) rebuildAndSend(obj *rebObject) error {
 				CksumType:  cksumType,
 				CksumValue: cksumValue,
 				Version:    s.meta.ObjVersion,
+				SSE:        s.meta.ObjSSE,
+				Compress:   s.meta.ObjCompress,
 			},
 		}
 		reb.saveCTToDisk(memsys.NewReader(s.sgl), req, hdr)
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"path/filepath"
 	"runtime"
 	"sync"
@@ -525,7 +526,7 @@ func (rj *rebalanceJogger) jog(mpathInfo *fs.MountpathInfo) {
 
 	opts := &fs.Options{
 		Mpath:    mpathInfo,
-		CTs:      []string{fs.ObjectType},
+		CTs:      []string{fs.ObjectType, fs.VersionType},
 		Callback: rj.walk,
 		Sorted:   false,
 	}
@@ -597,6 +598,9 @@ func (rj *rebalanceJogger) walk(fqn string, de fs.DirEntry) (err error) {
 	if lom.Bck().Props.EC.Enabled {
 		return filepath.SkipDir
 	}
+	if lom.ParsedFQN.ContentType == fs.VersionType {
+		return rj.walkVersion(fqn)
+	}
 
 	// Rebalance, maybe
 	tsi, err = cluster.HrwTarget(lom.Uname(), rj.smap)
@@ -687,6 +691,9 @@ func (rj *rebalanceJogger) send(lom *cluster.LOM, tsi *cluster.Snode, addAck boo
 			CksumType:  cksumType,
 			CksumValue: cksumValue,
 			Version:    lom.Version(),
+			SSE:        lom.SSE(),
+			Compress:   lom.Compression(),
+			Dirty:      lom.Dirty(),
 		},
 	}
 	o.Callback, o.CmplPtr = rj.objSentCallback, unsafe.Pointer(lom)
@@ -703,3 +710,93 @@ func (rj *rebalanceJogger) send(lom *cluster.LOM, tsi *cluster.Snode, addAck boo
 	rj.m.laterx.Store(true)
 	return
 }
+
+// previous versions of an object go to the object's target (see cluster.PutVersion)
+func (rj *rebalanceJogger) walkVersion(fqn string) (err error) {
+	lom, version, err := cluster.VersionOf(rj.m.t, fqn)
+	if err != nil {
+		if glog.FastV(4, glog.SmoduleReb) {
+			glog.Warningf("%s, err %s - skipping...", fqn, err)
+		}
+		return nil
+	}
+	tsi, err := cluster.HrwTarget(lom.Uname(), rj.smap)
+	if err != nil {
+		return err
+	}
+	if tsi.ID() == rj.m.t.Snode().ID() {
+		return nil
+	}
+	if rj.sema == nil {
+		err = rj.sendVersion(lom, fqn, version, tsi)
+	} else {
+		rj.sema.Acquire()
+		go func() {
+			defer rj.sema.Release()
+			if err := rj.sendVersion(lom, fqn, version, tsi); err != nil {
+				glog.Error(err)
+			}
+		}()
+	}
+	return
+}
+
+// NOTE: versions are not retransmitted - a version that hasn't been acknowledged
+// remains in place and gets sent again by the next rebalance
+func (rj *rebalanceJogger) sendVersion(lom *cluster.LOM, fqn, version string, tsi *cluster.Snode) (err error) {
+	var (
+		vlom  *cluster.LOM
+		finfo os.FileInfo
+		file  cmn.ReadOpenCloser
+	)
+	lom.Lock(false) // NOTE: unlock in objSentCallback() unless err
+	defer func() {
+		if err == nil {
+			return
+		}
+		lom.Unlock(false)
+		if glog.FastV(4, glog.SmoduleReb) {
+			glog.Errorf("%s version %s, err: %v", lom, version, err)
+		}
+	}()
+	if vlom, err = lom.LoadVersionFile(fqn); err != nil {
+		return
+	}
+	if finfo, err = os.Stat(fqn); err != nil {
+		return
+	}
+	if finfo.Size() > 0 { // (delete markers are header-only)
+		if file, err = cmn.NewFileHandle(fqn); err != nil {
+			return
+		}
+	}
+	// transmit
+	var (
+		vmsg = versionMsg{
+			rebID:    rj.m.RebID(),
+			daemonID: rj.m.t.Snode().ID(),
+			version:  version,
+			mtime:    finfo.ModTime().UnixNano(),
+			meta:     vlom.PackVersionMeta(),
+		}
+		mm     = rj.m.t.SmallMMSA()
+		opaque = vmsg.NewPack(mm)
+		o      = transport.AllocSend()
+	)
+	o.Hdr = transport.ObjHdr{
+		Bck:      lom.Bck().Bck,
+		ObjName:  lom.ObjName,
+		Opaque:   opaque,
+		ObjAttrs: transport.ObjectAttrs{Size: finfo.Size()},
+	}
+	o.Callback, o.CmplPtr = rj.objSentCallback, unsafe.Pointer(lom)
+
+	rj.m.inQueue.Inc()
+	if err = rj.m.dm.Send(o, file, tsi); err != nil {
+		rj.m.inQueue.Dec()
+		mm.Free(opaque)
+		return
+	}
+	rj.m.laterx.Store(true)
+	return
+}
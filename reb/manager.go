@@ -5,6 +5,7 @@
 package reb
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -249,6 +250,9 @@ func (reb *Manager) recvObjRegular(hdr transport.ObjHdr, smap *cluster.Smap, unp
 	}
 	lom.SetAtimeUnix(hdr.ObjAttrs.Atime)
 	lom.SetVersion(hdr.ObjAttrs.Version)
+	lom.SetSSE(hdr.ObjAttrs.SSE)
+	lom.SetCompression(hdr.ObjAttrs.Compress)
+	lom.SetDirty(hdr.ObjAttrs.Dirty)
 
 	params := cluster.PutObjectParams{
 		Tag:          fs.WorkfilePut,
@@ -290,6 +294,61 @@ func (reb *Manager) recvObjRegular(hdr transport.ObjHdr, smap *cluster.Smap, unp
 	}
 }
 
+func (reb *Manager) recvVersion(hdr transport.ObjHdr, smap *cluster.Smap, unpacker *cmn.ByteUnpack, objReader io.Reader) {
+	defer cmn.DrainReader(objReader)
+
+	vmsg := &versionMsg{}
+	if err := unpacker.ReadAny(vmsg); err != nil {
+		glog.Errorf("Failed to parse version message: %v", err)
+		return
+	}
+	if vmsg.rebID != reb.RebID() {
+		glog.Warningf("received version %s of %s/%s: %s", vmsg.version, hdr.Bck, hdr.ObjName,
+			reb.rebIDMismatchMsg(vmsg.rebID))
+		return
+	}
+	lom := &cluster.LOM{T: reb.t, ObjName: hdr.ObjName}
+	if err := lom.Init(hdr.Bck); err != nil {
+		glog.Error(err)
+		return
+	}
+	if objReader == nil { // (delete marker)
+		objReader = bytes.NewReader(nil)
+	}
+	lom.Lock(true)
+	err := lom.PutVersion(vmsg.version, objReader, vmsg.meta, time.Unix(0, vmsg.mtime))
+	lom.Unlock(true)
+	if err != nil {
+		glog.Errorf("%s: failed to store version %s of %s, err: %v", reb.t.Snode(), vmsg.version, lom, err)
+		return
+	}
+	if glog.FastV(5, glog.SmoduleReb) {
+		glog.Infof("%s: from %s %s version %s", reb.t.Snode(), vmsg.daemonID, lom, vmsg.version)
+	}
+	reb.statTracker.AddMany(
+		stats.NamedVal64{Name: stats.RebRxCount, Value: 1},
+		stats.NamedVal64{Name: stats.RebRxSize, Value: hdr.ObjAttrs.Size},
+	)
+	// ACK
+	tsi := smap.GetTarget(vmsg.daemonID)
+	if tsi == nil {
+		glog.Errorf("%s target is not found in smap", vmsg.daemonID)
+		return
+	}
+	if stage := reb.stages.stage.Load(); stage < rebStageFinStreams && stage != rebStageInactive {
+		var (
+			ack = &versionMsg{rebID: reb.RebID(), daemonID: reb.t.Snode().ID(), version: vmsg.version}
+			mm  = reb.t.SmallMMSA()
+		)
+		hdr.Opaque = ack.NewPack(mm)
+		hdr.ObjAttrs.Size = 0
+		if err := reb.dm.ACK(hdr, reb.rackSentCallback, tsi); err != nil {
+			mm.Free(hdr.Opaque)
+			glog.Error(err)
+		}
+	}
+}
+
 func (reb *Manager) rackSentCallback(hdr transport.ObjHdr, _ io.ReadCloser, _ unsafe.Pointer, _ error) {
 	reb.t.SmallMMSA().Free(hdr.Opaque)
 }
@@ -344,6 +403,10 @@ func (reb *Manager) recvObj(w http.ResponseWriter, hdr transport.ObjHdr, objRead
 		reb.recvObjRegular(hdr, smap, unpacker, objReader)
 		return
 	}
+	if act == rebMsgVersion {
+		reb.recvVersion(hdr, smap, unpacker, objReader)
+		return
+	}
 
 	if act != rebMsgEC {
 		glog.Errorf("Invalid ACK type %d, expected %d", act, rebMsgEC)
@@ -460,6 +523,29 @@ func (reb *Manager) recvRegularAck(hdr transport.ObjHdr, unpacker *cmn.ByteUnpac
 	lom.Unlock(true)
 }
 
+// the version has been stored by the object's target - remove the local copy
+func (reb *Manager) recvVersionAck(hdr transport.ObjHdr, unpacker *cmn.ByteUnpack) {
+	ack := &versionMsg{}
+	if err := unpacker.ReadAny(ack); err != nil {
+		glog.Errorf("Failed to parse acknowledge: %v", err)
+		return
+	}
+	if ack.rebID != reb.rebID.Load() {
+		glog.Warningf("ACK from %s: %s", ack.daemonID, reb.rebIDMismatchMsg(ack.rebID))
+		return
+	}
+	lom := &cluster.LOM{T: reb.t, ObjName: hdr.ObjName}
+	if err := lom.Init(hdr.Bck); err != nil {
+		glog.Error(err)
+		return
+	}
+	lom.Lock(true)
+	if err := lom.RemoveVersionFiles(ack.version); err != nil {
+		glog.Errorf("%s: error removing %s version %s, err: %v", reb.t.Snode(), lom, ack.version, err)
+	}
+	lom.Unlock(true)
+}
+
 func (reb *Manager) recvAck(w http.ResponseWriter, hdr transport.ObjHdr, _ io.Reader, err error) {
 	if err != nil {
 		glog.Error(err)
@@ -477,6 +563,10 @@ func (reb *Manager) recvAck(w http.ResponseWriter, hdr transport.ObjHdr, _ io.Re
 		reb.recvECAck(hdr, unpacker)
 		return
 	}
+	if act == rebMsgVersion {
+		reb.recvVersionAck(hdr, unpacker)
+		return
+	}
 	if act != rebMsgRegular {
 		glog.Errorf("Invalid ACK type %d, expected %d", act, rebMsgRegular)
 	}
@@ -17,6 +17,7 @@ const (
 	rebMsgRegular   = iota // regular rebalance: acknowledge/Object
 	rebMsgEC               // EC rebalance: acknowledge/CT/Namespace
 	rebMsgPushStage        // push notification of target moved to the next stage
+	rebMsgVersion          // regular rebalance: acknowledge/previous version of an object
 )
 const rebMsgKindSize = 1
 
@@ -30,6 +31,14 @@ type (
 		daemonID string // sender's DaemonID
 		sliceID  uint16
 	}
+	// previous (noncurrent) version of an object and its acknowledgement
+	versionMsg struct {
+		rebID    int64
+		daemonID string // sender's DaemonID
+		version  string
+		mtime    int64  // modification time of the version (UnixNano)
+		meta     []byte // version's metadata (empty in acknowledgement)
+	}
 
 	// push notification struct - a target sends it when it enters `stage`
 	pushReq struct {
@@ -49,6 +58,8 @@ var (
 	_ cmn.Packer   = (*ecAck)(nil)
 	_ cmn.Packer   = (*pushReq)(nil)
 	_ cmn.Unpacker = (*pushReq)(nil)
+	_ cmn.Packer   = (*versionMsg)(nil)
+	_ cmn.Unpacker = (*versionMsg)(nil)
 )
 
 func (rack *regularAck) Unpack(unpacker *cmn.ByteUnpack) (err error) {
@@ -114,6 +125,45 @@ func (eack *ecAck) PackedSize() int {
 	return cmn.SizeofI64 + cmn.SizeofI16 + cmn.SizeofLen + len(eack.daemonID)
 }
 
+func (vmsg *versionMsg) Unpack(unpacker *cmn.ByteUnpack) (err error) {
+	if vmsg.rebID, err = unpacker.ReadInt64(); err != nil {
+		return
+	}
+	if vmsg.daemonID, err = unpacker.ReadString(); err != nil {
+		return
+	}
+	if vmsg.version, err = unpacker.ReadString(); err != nil {
+		return
+	}
+	if vmsg.mtime, err = unpacker.ReadInt64(); err != nil {
+		return
+	}
+	vmsg.meta, err = unpacker.ReadBytes()
+	return
+}
+
+func (vmsg *versionMsg) Pack(packer *cmn.BytePack) {
+	packer.WriteInt64(vmsg.rebID)
+	packer.WriteString(vmsg.daemonID)
+	packer.WriteString(vmsg.version)
+	packer.WriteInt64(vmsg.mtime)
+	packer.WriteBytes(vmsg.meta)
+}
+
+func (vmsg *versionMsg) NewPack(mm *memsys.MMSA) []byte {
+	l := rebMsgKindSize + vmsg.PackedSize()
+	buf, _ := mm.Alloc(int64(l))
+	packer := cmn.NewPacker(buf, l)
+	packer.WriteByte(rebMsgVersion)
+	packer.WriteAny(vmsg)
+	return packer.Bytes()
+}
+
+// rebID + DaemonID + version + mtime + metadata
+func (vmsg *versionMsg) PackedSize() int {
+	return cmn.SizeofI64*2 + cmn.SizeofLen*3 + len(vmsg.daemonID) + len(vmsg.version) + len(vmsg.meta)
+}
+
 // int64*2 + int32 + string + marker + sizeof(ec.MD)
 func (req *pushReq) PackedSize() int {
 	total := cmn.SizeofLen + cmn.SizeofI64*2 + cmn.SizeofI32 +
@@ -23,6 +23,7 @@ import (
 type (
 	joggerCtx struct {
 		xact cluster.Xact
+		t    cluster.Target
 	}
 )
 
@@ -52,10 +53,10 @@ func (reb *Manager) RunResilver(id string, skipGlobMisplaced bool, notifs ...*xa
 	slab, err := reb.t.MMSA().GetSlab(memsys.MaxPageSlabSize)
 	cmn.AssertNoErr(err)
 
-	jctx := &joggerCtx{xact: xact}
+	jctx := &joggerCtx{xact: xact, t: reb.t}
 	jg := mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
 		T:                     reb.t,
-		CTs:                   []string{fs.ObjectType, ec.SliceType},
+		CTs:                   []string{fs.ObjectType, ec.SliceType, fs.VersionType},
 		VisitObj:              jctx.visitObj,
 		VisitCT:               jctx.visitCT,
 		Slab:                  slab,
@@ -202,7 +203,26 @@ func (rj *joggerCtx) visitObj(lom *cluster.LOM, buf []byte) (err error) {
 	return nil
 }
 
+// Moves a previous version of an object to the object's mountpath
+func (rj *joggerCtx) moveVersion(ct *cluster.CT) {
+	lom, version, err := cluster.VersionOf(rj.t, ct.FQN())
+	if err != nil {
+		glog.Warning(err)
+		return
+	}
+	lom.Lock(true)
+	err = lom.MoveVersion(ct.FQN(), version)
+	lom.Unlock(true)
+	if err != nil {
+		glog.Errorf("%s: failed to move version %q (%s), err: %v", lom, version, ct.FQN(), err)
+	}
+}
+
 func (rj *joggerCtx) visitCT(ct *cluster.CT, buf []byte) (err error) {
+	if ct.ContentType() == fs.VersionType {
+		rj.moveVersion(ct)
+		return nil
+	}
 	cmn.Assert(ct.ContentType() == ec.SliceType)
 	if !ct.Bprops().EC.Enabled {
 		// Since `%ec` directory is inside a bucket, it is safe to skip
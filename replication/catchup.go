@@ -0,0 +1,230 @@
+// Package replication asynchronously replicates buckets into remote AIS clusters.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package replication
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+)
+
+type (
+	catchupProvider struct {
+		xreg.BaseBckEntry
+		xact *XactCatchup
+
+		t      cluster.Target
+		statsT stats.Tracker
+		uuid   string
+	}
+
+	// XactCatchup queues for replication the objects written (or deleted)
+	// while replication was disabled or down. Each target handles the objects
+	// it owns (by HRW):
+	//   1. lists the destination bucket;
+	//   2. traverses local mountpaths and queues PUTs of the objects that are
+	//      either missing in the destination or differ (by checksum or size);
+	//   3. queues DELETEs of the objects that exist only in the destination.
+	// Finally, it (re)starts the `replicate` xaction.
+	XactCatchup struct {
+		xaction.XactBase
+		joggers *mpather.JoggerGroup
+		t       cluster.Target
+		statsT  stats.Tracker
+		q       *queue
+		mu      sync.Mutex
+		remote  map[string]*cmn.BucketEntry // local (HRW) objects present in the destination
+		stats   struct {
+			puts    atomic.Int64
+			deletes atomic.Int64
+		}
+	}
+
+	CatchupStats struct {
+		xaction.BaseXactStats
+		Ext ExtCatchupStats `json:"ext"`
+	}
+	ExtCatchupStats struct {
+		PutCount    int64 `json:"put.n,string"`
+		DeleteCount int64 `json:"delete.n,string"`
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact      = (*XactCatchup)(nil)
+	_ cluster.XactStats = (*CatchupStats)(nil)
+)
+
+func (*catchupProvider) New(args xreg.XactArgs) xreg.BucketEntry {
+	return &catchupProvider{t: args.T, statsT: args.Custom.(stats.Tracker), uuid: args.UUID}
+}
+
+func (p *catchupProvider) Start(bck cmn.Bck) error {
+	p.xact = newXactCatchup(p.t, p.statsT, bck, p.uuid)
+	return nil
+}
+func (*catchupProvider) Kind() string        { return cmn.ActReplCatchup }
+func (p *catchupProvider) Get() cluster.Xact { return p.xact }
+func (p *catchupProvider) PreRenewHook(previousEntry xreg.BucketEntry) (keep bool, err error) {
+	err = fmt.Errorf("%s is already running", previousEntry.Get())
+	return
+}
+
+func newXactCatchup(t cluster.Target, statsT stats.Tracker, bck cmn.Bck, uuid string) *XactCatchup {
+	r := &XactCatchup{
+		XactBase: *xaction.NewXactBaseBck(uuid, cmn.ActReplCatchup, bck),
+		t:        t,
+		statsT:   statsT,
+		q:        newQueue(t.DB(), bck),
+		remote:   make(map[string]*cmn.BucketEntry, 1024),
+	}
+	r.joggers = mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
+		T:        t,
+		Bck:      bck,
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		DoLoad:   mpather.Load,
+		Throttle: true,
+	})
+	return r
+}
+
+func (r *XactCatchup) Run() (err error) {
+	glog.Infoln(r.String())
+	if err = r.listRemote(); err == nil && !r.Aborted() {
+		r.joggers.Run()
+		select {
+		case <-r.ChanAbort():
+			r.joggers.Stop()
+		case <-r.joggers.ListenFinished():
+			err = r.joggers.Stop()
+		}
+	}
+	if err == nil && !r.Aborted() {
+		err = r.queueDeletes()
+	}
+	if err == nil && r.stats.puts.Load()+r.stats.deletes.Load() > 0 {
+		err = r.replicate()
+	}
+	r.Finish(err)
+	return
+}
+
+func (r *XactCatchup) Stats() cluster.XactStats {
+	baseStats := r.XactBase.Stats().(*xaction.BaseXactStats)
+	catchupStats := CatchupStats{BaseXactStats: *baseStats}
+	catchupStats.Ext.PutCount = r.stats.puts.Load()
+	catchupStats.Ext.DeleteCount = r.stats.deletes.Load()
+	return &catchupStats
+}
+
+// phase 1: list the destination bucket
+func (r *XactCatchup) listRemote() error {
+	var (
+		smap = r.t.Sowner().Get()
+		sid  = r.t.Snode().ID()
+		bck  = cluster.NewBckEmbed(r.Bck())
+		msg  = &cmn.SelectMsg{}
+	)
+	if err := bck.Init(r.t.Bowner(), r.t.Snode()); err != nil {
+		return err
+	}
+	conf := &bck.Props.Replication
+	if !conf.Enabled {
+		return fmt.Errorf("%s: replication is disabled", r)
+	}
+	dst := cluster.NewBckEmbed(conf.DstBck())
+	if err := dst.Init(r.t.Bowner(), r.t.Snode()); err != nil {
+		return err
+	}
+	msg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum)
+	cloud := r.t.Cloud(dst)
+	for !r.Aborted() {
+		objList, _, err := cloud.ListObjects(context.Background(), dst, msg)
+		if err != nil {
+			return err
+		}
+		for _, entry := range objList.Entries {
+			// ownership is determined by the source bucket (see Record)
+			si, err := cluster.HrwTarget(bck.MakeUname(entry.Name), smap)
+			if err != nil {
+				return err
+			}
+			if si.ID() == sid {
+				r.remote[entry.Name] = entry
+			}
+		}
+		if objList.ContinuationToken == "" {
+			break
+		}
+		msg.ContinuationToken = objList.ContinuationToken
+	}
+	return nil
+}
+
+// phase 2: queue PUTs of missing and changed objects
+func (r *XactCatchup) visitObj(lom *cluster.LOM, _ []byte) error {
+	r.mu.Lock()
+	be, ok := r.remote[lom.ObjName]
+	delete(r.remote, lom.ObjName)
+	r.mu.Unlock()
+	if ok && !changed(be, lom) {
+		return nil
+	}
+	if err := r.q.put(&entry{ObjName: lom.ObjName, Op: OpPut, Time: r.StartTime().UnixNano()}); err != nil {
+		return err
+	}
+	r.stats.puts.Inc()
+	r.ObjectsInc()
+	r.BytesAdd(lom.Size())
+	return nil
+}
+
+// changed returns true if the destination object, as listed, differs from
+// the local one: by checksum, if both are known, otherwise by size.
+func changed(be *cmn.BucketEntry, lom *cluster.LOM) bool {
+	if cksum := lom.Cksum(); be.Checksum != "" && cksum != nil && cksum.Value() != "" {
+		return be.Checksum != cksum.Value()
+	}
+	return be.Size != lom.Size()
+}
+
+// phase 3: queue DELETEs of the objects that exist only in the destination
+func (r *XactCatchup) queueDeletes() error {
+	now := r.StartTime().UnixNano()
+	for objName := range r.remote {
+		if err := r.q.put(&entry{ObjName: objName, Op: OpDelete, Time: now}); err != nil {
+			return err
+		}
+		r.stats.deletes.Inc()
+		r.ObjectsInc()
+	}
+	return nil
+}
+
+func (r *XactCatchup) replicate() error {
+	bck := cluster.NewBckEmbed(r.Bck())
+	for i := 0; i < 2; i++ {
+		xact, err := xreg.RenewBucketXact(cmn.ActReplicate, bck, xreg.XactArgs{T: r.t, Custom: r.statsT})
+		if err != nil {
+			return err
+		}
+		if err = xact.(*Xaction).Notify(); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: failed to start replication (the queued operations are kept)", r)
+}
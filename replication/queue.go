@@ -0,0 +1,98 @@
+// Package replication asynchronously replicates buckets into remote AIS clusters.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package replication
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+)
+
+// Persistent (per-target) replication queue: one database record per object
+// that is yet to be replicated, keyed by the object's uname. A new PUT or
+// DELETE of the same object overwrites the record - only the latest operation
+// gets replicated.
+
+const dbCollection = "replication"
+
+// enum: replicated operations
+const (
+	OpPut    = "put"
+	OpDelete = "delete"
+)
+
+type (
+	entry struct {
+		ObjName string `json:"name"`
+		Op      string `json:"op"`
+		Time    int64  `json:"time,string"` // when PUT (DELETE) took place, Unix nanoseconds
+	}
+	queue struct {
+		db     dbdriver.Driver
+		prefix string // bucket's uname (all keys start with it)
+	}
+)
+
+// serializes adding vs removing (replicated) records
+var mu sync.Mutex
+
+func newQueue(db dbdriver.Driver, bck cmn.Bck) *queue {
+	return &queue{db: db, prefix: cluster.NewBckEmbed(bck).MakeUname("")}
+}
+
+// Record adds a given PUT or DELETE to the bucket's replication queue, thus
+// making sure it gets replicated even if the target restarts in the meantime.
+func Record(db dbdriver.Driver, bck cmn.Bck, objName, op string) error {
+	return newQueue(db, bck).put(&entry{ObjName: objName, Op: op, Time: time.Now().UnixNano()})
+}
+
+func (q *queue) put(e *entry) error {
+	mu.Lock()
+	err := q.db.Set(dbCollection, q.prefix+e.ObjName, e)
+	mu.Unlock()
+	return err
+}
+
+func (q *queue) keys() ([]string, error) { return q.db.List(dbCollection, q.prefix) }
+
+func (q *queue) get(key string) (*entry, error) {
+	e := &entry{}
+	if err := q.db.Get(dbCollection, key, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// remove a replicated record unless it has been overwritten in the meantime
+func (q *queue) remove(e *entry) error {
+	var (
+		curr = &entry{}
+		key  = q.prefix + e.ObjName
+	)
+	mu.Lock()
+	defer mu.Unlock()
+	if err := q.db.Get(dbCollection, key, curr); err != nil {
+		if dbdriver.IsErrNotFound(err) {
+			err = nil
+		}
+		return err
+	}
+	if *curr != *e {
+		return nil
+	}
+	return q.db.Delete(dbCollection, key)
+}
+
+// returns true if there's at least one record
+func (q *queue) nonEmpty() bool {
+	keys, err := q.keys()
+	return err == nil && len(keys) > 0
+}
+
+// NonEmpty returns true if the bucket has operations pending replication.
+func NonEmpty(db dbdriver.Driver, bck cmn.Bck) bool { return newQueue(db, bck).nonEmpty() }
@@ -0,0 +1,53 @@
+// Package replication asynchronously replicates buckets into remote AIS clusters.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package replication
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestQueue(t *testing.T) {
+	var (
+		db    = dbdriver.NewDBMock()
+		bck   = cmn.Bck{Name: "src", Provider: cmn.ProviderAIS}
+		other = cmn.Bck{Name: "other", Provider: cmn.ProviderAIS}
+		q     = newQueue(db, bck)
+	)
+	tassert.Fatalf(t, !NonEmpty(db, bck), "expected empty queue")
+
+	tassert.CheckFatal(t, Record(db, bck, "a/obj1", OpPut))
+	tassert.CheckFatal(t, Record(db, bck, "a/obj2", OpPut))
+	tassert.CheckFatal(t, Record(db, other, "a/obj1", OpPut))
+	tassert.CheckFatal(t, Record(db, bck, "a/obj1", OpDelete)) // coalesced with the PUT
+
+	keys, err := q.keys()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(keys) == 2, "expected 2 records, got %v", keys)
+	e, err := q.get(q.prefix + "a/obj1")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, e.ObjName == "a/obj1" && e.Op == OpDelete, "expected the latest operation, got %+v", e)
+
+	// overwritten in the meantime - must be kept
+	stale := *e
+	stale.Time--
+	tassert.CheckFatal(t, q.remove(&stale))
+	_, err = q.get(q.prefix + "a/obj1")
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, q.remove(e))
+	e2, err := q.get(q.prefix + "a/obj2")
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, q.remove(e2))
+	tassert.CheckFatal(t, q.remove(e2)) // removing twice is a no-op
+
+	keys, err = q.keys()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(keys) == 0, "expected empty queue, got %v", keys)
+	tassert.Errorf(t, !NonEmpty(db, bck), "expected empty queue")
+	tassert.Errorf(t, NonEmpty(db, other), "expected other bucket's queue to remain non-empty")
+}
@@ -0,0 +1,321 @@
+// Package replication asynchronously replicates buckets into remote AIS clusters.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package replication
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+)
+
+// When replication is enabled for a bucket (see cmn.RemoteReplConf), each
+// PUT and DELETE gets recorded in the target's persistent replication queue
+// (see queue.go) and the bucket's `replicate` xaction - one per bucket per
+// target - gets notified. The xaction replays the recorded operations against
+// the destination bucket in the remote cluster:
+//   - records are removed only upon successful replication, so that the
+//     replication resumes after the remote cluster becomes reachable again
+//     or after the target restarts (see Resume);
+//   - upon failures the xaction keeps retrying with increasing delay;
+//   - the time from PUT (DELETE) to its replication is reported as
+//     `repl.lag.ns` (see package stats).
+//
+// Objects written while replication was disabled (or the destination was not
+// configured) are queued by the `repl-catchup` xaction (see catchup.go).
+
+const (
+	maxConsecErrs = 3                // to consider the remote cluster unreachable and back off
+	minRetryDelay = time.Second      // doubles with each failed pass
+	maxRetryDelay = 64 * time.Second //
+)
+
+type (
+	replProvider struct {
+		xreg.BaseBckEntry
+		xact *Xaction
+
+		t      cluster.Target
+		statsT stats.Tracker
+	}
+	Xaction struct {
+		// implements cluster.Xact interface
+		xaction.XactDemandBase
+		// runtime
+		mu      sync.Mutex
+		kickCh  chan struct{}
+		queued  atomic.Int64
+		stopped bool
+		// init
+		t      cluster.Target
+		statsT stats.Tracker
+		q      *queue
+	}
+
+	ReplStats struct {
+		xaction.BaseXactStats
+		Ext ExtReplStats `json:"ext"`
+	}
+	ExtReplStats struct {
+		QueuedCount int64 `json:"queued.n,string"`
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact      = (*Xaction)(nil)
+	_ cluster.XactStats = (*ReplStats)(nil)
+)
+
+func init() {
+	xreg.RegisterBucketXact(&replProvider{})
+	xreg.RegisterBucketXact(&catchupProvider{})
+}
+
+func (*replProvider) New(args xreg.XactArgs) xreg.BucketEntry {
+	return &replProvider{t: args.T, statsT: args.Custom.(stats.Tracker)}
+}
+
+func (p *replProvider) Start(bck cmn.Bck) error {
+	p.xact = newXaction(p.t, p.statsT, bck)
+	go func() {
+		err := p.xact.Run()
+		p.xact.Finish(err)
+	}()
+	return nil
+}
+func (*replProvider) Kind() string        { return cmn.ActReplicate }
+func (p *replProvider) Get() cluster.Xact { return p.xact }
+
+// Resume starts replicating all buckets that have operations pending
+// replication - e.g., upon target restart.
+func Resume(t cluster.Target, statsT stats.Tracker) {
+	t.Bowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		if !bck.Props.Replication.Enabled || !NonEmpty(t.DB(), bck.Bck) {
+			return false
+		}
+		if _, err := xreg.RenewBucketXact(cmn.ActReplicate, bck, xreg.XactArgs{T: t, Custom: statsT}); err != nil {
+			glog.Errorf("%s: failed to resume replication, err: %v", bck, err)
+		}
+		return false
+	})
+}
+
+/////////////
+// Xaction //
+/////////////
+
+func newXaction(t cluster.Target, statsT stats.Tracker, bck cmn.Bck) *Xaction {
+	r := &Xaction{
+		XactDemandBase: *xaction.NewXactDemandBaseBck(cmn.ActReplicate, bck),
+		kickCh:         make(chan struct{}, 1),
+		t:              t,
+		statsT:         statsT,
+		q:              newQueue(t.DB(), bck),
+	}
+	r.InitIdle()
+	return r
+}
+
+func (r *Xaction) Run() error {
+	glog.Infoln(r.String())
+	delay := minRetryDelay
+	for {
+		// notifications (see Notify) that are handled by this pass
+		n := r.Pending()
+		left, failed, err := r.pass()
+		if err != nil {
+			r.stop()
+			return err
+		}
+		r.queued.Store(int64(left))
+		if left == 0 {
+			r.SubPending(int(n))
+		} else if n == 0 {
+			r.IncPending() // not idle while there are records to replicate
+		}
+		var retryCh <-chan time.Time
+		if failed {
+			retryCh = time.After(delay)
+			delay = cmn.MinDuration(2*delay, maxRetryDelay)
+		} else {
+			delay = minRetryDelay
+		}
+		select {
+		case <-r.kickCh:
+		case <-retryCh:
+		case <-r.IdleTimer():
+			r.stop()
+			return nil
+		case <-r.ChanAbort():
+			r.stop()
+			return cmn.NewAbortedError(r.String())
+		}
+	}
+}
+
+func (r *Xaction) Stats() cluster.XactStats {
+	baseStats := r.XactDemandBase.Stats().(*xaction.BaseXactStats)
+	replStats := ReplStats{BaseXactStats: *baseStats}
+	replStats.Ext.QueuedCount = r.queued.Load()
+	return &replStats
+}
+
+// Notify wakes up the xaction to replicate newly recorded operation(s) (see Record).
+func (r *Xaction) Notify() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped || r.Finished() {
+		return xaction.NewErrXactExpired("Cannot replicate: " + r.String())
+	}
+	r.IncPending()
+	select {
+	case r.kickCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// replay all queued records; return the number of those that remain
+func (r *Xaction) pass() (left int, failed bool, err error) {
+	bck := cluster.NewBckEmbed(r.Bck())
+	if err = bck.Init(r.t.Bowner(), r.t.Snode()); err != nil {
+		return
+	}
+	conf := &bck.Props.Replication
+	if !conf.Enabled {
+		return 0, false, fmt.Errorf("%s: replication is disabled (remaining records are kept)", r)
+	}
+	keys, err := r.q.keys()
+	if err != nil {
+		return
+	}
+	var consecErrs int
+	for i, key := range keys {
+		if r.Aborted() {
+			return len(keys) - i, false, nil
+		}
+		e, errGet := r.q.get(key)
+		if errGet != nil {
+			if !dbdriver.IsErrNotFound(errGet) {
+				glog.Error(errGet)
+				left++
+			}
+			continue
+		}
+		size, errRepl := r.replicate(conf.DstBck(), e)
+		if errRepl != nil {
+			glog.Errorf("%s: failed to replicate %s(%s), err: %v", r, e.ObjName, e.Op, errRepl)
+			r.statsT.Add(stats.ErrReplCount, 1)
+			left++
+			failed = true
+			if consecErrs++; consecErrs >= maxConsecErrs {
+				return left + len(keys) - i - 1, true, nil
+			}
+			continue
+		}
+		consecErrs = 0
+		if errRm := r.q.remove(e); errRm != nil {
+			glog.Error(errRm)
+		}
+		r.ObjectsInc()
+		r.BytesAdd(size)
+		r.statsT.AddMany(
+			stats.NamedVal64{Name: stats.ReplCount, Value: 1},
+			stats.NamedVal64{Name: stats.ReplSize, Value: size},
+			stats.NamedVal64{Name: stats.ReplLag, Value: time.Now().UnixNano() - e.Time},
+		)
+	}
+	return
+}
+
+// replicate a single PUT or DELETE
+func (r *Xaction) replicate(dstBck cmn.Bck, e *entry) (size int64, err error) {
+	dst := &cluster.LOM{T: r.t, ObjName: e.ObjName}
+	if err = dst.Init(dstBck); err != nil {
+		return
+	}
+	cloud := r.t.Cloud(dst.Bck())
+	if e.Op == OpDelete {
+		var errCode int
+		if errCode, err = cloud.DeleteObj(context.Background(), dst); errCode == http.StatusNotFound {
+			err = nil
+		}
+		return
+	}
+	var (
+		fh       cmn.ReadOpenCloser
+		restored bool
+		lom      = &cluster.LOM{T: r.t, ObjName: e.ObjName}
+	)
+	if err = lom.Init(r.Bck()); err != nil {
+		return
+	}
+	if fh, err = open(lom); err != nil {
+		if !cmn.IsObjNotExist(err) {
+			return
+		}
+		if restored, err = r.handover(lom); !restored {
+			return
+		}
+		if fh, err = open(lom); err != nil {
+			return
+		}
+	}
+	size = lom.ContentSize()
+	dst.SetSize(size)
+	dst.SetCksum(lom.ContentCksum())
+	_, _, err = cloud.PutObj(context.Background(), fh, dst) // NOTE: closes the handle
+	return
+}
+
+// handover the replication of an object that is not present locally: if the
+// object has been migrated (rebalance), its current owner takes over;
+// otherwise, the object is either misplaced (resilver) or has been deleted
+// (in which case the deletion gets replicated as well)
+func (r *Xaction) handover(lom *cluster.LOM) (restored bool, err error) {
+	tsi, err := cluster.HrwTarget(lom.Uname(), r.t.Sowner().Get())
+	if err != nil {
+		return
+	}
+	if tsi.ID() == r.t.Snode().ID() {
+		restored = lom.RestoreObjectFromAny()
+		return
+	}
+	errCode, err := r.t.HandoverRepl(lom, tsi)
+	if errCode == http.StatusNotFound {
+		err = nil // removed at the owner (that replicates the deletion)
+	}
+	return
+}
+
+func open(lom *cluster.LOM) (fh cmn.ReadOpenCloser, err error) {
+	lom.Lock(false)
+	if err = lom.Load(false); err == nil {
+		fh, err = lom.Open()
+	}
+	lom.Unlock(false)
+	return
+}
+
+func (r *Xaction) stop() {
+	r.XactDemandBase.Stop()
+	r.mu.Lock()
+	r.stopped = true
+	r.mu.Unlock()
+	if n := r.queued.Load(); n > 0 {
+		glog.Warningf("%s: %d operation(s) remain queued", r, n)
+	}
+}
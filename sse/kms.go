@@ -0,0 +1,134 @@
+// Package sse provides server-side encryption of objects at rest.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+)
+
+type (
+	// KMS is a key management service that owns master keys: it generates
+	// data keys and encrypts (decrypts) them with a given master key.
+	KMS interface {
+		// GenerateKey returns a new random data key along with its copy
+		// encrypted with the master key.
+		GenerateKey(keyID string) (key, encKey []byte, err error)
+		// DecryptKey decrypts a data key previously returned by GenerateKey.
+		DecryptKey(keyID string, encKey []byte) (key []byte, err error)
+	}
+	// NewKMS creates KMS of a given provider (see RegisterKMS).
+	NewKMS func(conf *cmn.KMSConf) (KMS, error)
+
+	// localKMS loads master keys from a local (JSON) key file that maps
+	// key IDs to base64-encoded 256-bit keys. The file must be the same
+	// on all targets.
+	localKMS struct {
+		keys map[string][]byte
+	}
+)
+
+// interface guard
+var _ KMS = (*localKMS)(nil)
+
+var (
+	mu        sync.Mutex
+	providers = map[string]NewKMS{cmn.KMSProviderLocal: newLocalKMS}
+
+	kms          KMS
+	defaultKeyID string
+)
+
+// RegisterKMS registers KMS provider - must be called prior to Init.
+func RegisterKMS(provider string, newKMS NewKMS) {
+	mu.Lock()
+	providers[provider] = newKMS
+	mu.Unlock()
+}
+
+// Init creates KMS as per configuration; no-op if KMS is not configured.
+func Init(conf *cmn.KMSConf) (err error) {
+	if conf.Provider == "" {
+		return nil
+	}
+	mu.Lock()
+	newKMS, ok := providers[conf.Provider]
+	mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown KMS provider %q", conf.Provider)
+	}
+	if kms, err = newKMS(conf); err != nil {
+		return fmt.Errorf("failed to initialize %q KMS: %v", conf.Provider, err)
+	}
+	defaultKeyID = conf.DefaultKeyID
+	return nil
+}
+
+// Enabled returns true if KMS is configured (and, therefore, objects can
+// be encrypted with master keys).
+func Enabled() bool { return kms != nil }
+
+func DefaultKeyID() string { return defaultKeyID }
+
+//////////////
+// localKMS //
+//////////////
+
+func newLocalKMS(conf *cmn.KMSConf) (KMS, error) {
+	var (
+		encoded = make(cmn.SimpleKVs)
+		lkms    = &localKMS{}
+	)
+	if _, err := jsp.Load(conf.KeyFile, &encoded, jsp.Plain()); err != nil {
+		return nil, err
+	}
+	lkms.keys = make(map[string][]byte, len(encoded))
+	for id, s := range encoded {
+		key, err := base64.StdEncoding.DecodeString(s)
+		if err != nil || len(key) != KeySize {
+			return nil, fmt.Errorf("%s: invalid key %q (expected base64-encoded %d bytes)", conf.KeyFile, id, KeySize)
+		}
+		lkms.keys[id] = key
+	}
+	if _, ok := lkms.keys[conf.DefaultKeyID]; !ok {
+		return nil, fmt.Errorf("%s: default key %q not found", conf.KeyFile, conf.DefaultKeyID)
+	}
+	return lkms, nil
+}
+
+func (lkms *localKMS) masterKey(keyID string) ([]byte, error) {
+	kek, ok := lkms.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("master key %q not found", keyID)
+	}
+	return kek, nil
+}
+
+func (lkms *localKMS) GenerateKey(keyID string) (key, encKey []byte, err error) {
+	var kek []byte
+	if kek, err = lkms.masterKey(keyID); err != nil {
+		return
+	}
+	key = make([]byte, KeySize)
+	if _, err = io.ReadFull(rand.Reader, key); err != nil {
+		return
+	}
+	encKey, err = wrapKey(kek, key)
+	return
+}
+
+func (lkms *localKMS) DecryptKey(keyID string, encKey []byte) ([]byte, error) {
+	kek, err := lkms.masterKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return unwrapKey(kek, encKey)
+}
@@ -0,0 +1,181 @@
+// Package sse provides server-side encryption of objects at rest.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Objects are encrypted with AES-256-GCM using a random per-object data key
+// (aka envelope encryption). The data key, in turn, is encrypted either with
+// a master key of the KMS (see KMS and cmn.KMSConf) or with the key provided
+// by the client (SSE-C), and gets stored in the object's metadata (see Info
+// and cluster.LOM). SSE-C keys themselves are never stored - only their MD5.
+//
+// To support range reads, the content is split into chunks of ChunkSize
+// bytes, each sealed separately with its own authentication tag and a nonce
+// derived from the chunk's index. The last chunk is sealed with a distinct
+// nonce, so that truncated or extended content fails authentication. The
+// encrypted content is what gets stored, checksummed, replicated, erasure
+// coded, and rebalanced - it is decrypted only when read by users.
+
+const (
+	KeySize   = 32           // AES-256
+	ChunkSize = 64 * cmn.KiB // plaintext bytes per chunk
+	TagSize   = 16           // GCM authentication tag
+
+	chunkTotal = ChunkSize + TagSize // ciphertext bytes per chunk
+)
+
+type (
+	// Info is the encryption metadata of an object.
+	Info struct {
+		KeyID   string `json:"kid,omitempty"`  // KMS master key (empty for SSE-C)
+		DataKey []byte `json:"dk"`             // encrypted data key
+		KeyMD5  string `json:"kmd5,omitempty"` // SSE-C: base64-encoded MD5 of the client's key
+	}
+
+	// Params is the encryption requested by a client (S3 API); when present,
+	// it takes precedence over the bucket's encryption properties.
+	Params struct {
+		KeyID       string // KMS master key; empty means the KMS default key
+		CustomerKey []byte // SSE-C
+	}
+)
+
+var (
+	ErrCustomerKeyRequired = errors.New("object is encrypted with a customer-provided key")
+	ErrCustomerKeyMismatch = errors.New("customer-provided key does not match the object's key")
+)
+
+// NewInfo generates a new data key and returns it along with the object's
+// encryption metadata. The data key gets encrypted with the customer key,
+// if provided, or otherwise with a given (or default) master key of the KMS.
+func NewInfo(params *Params) (info *Info, key []byte, err error) {
+	if params.CustomerKey != nil {
+		key = make([]byte, KeySize)
+		if _, err = rand.Read(key); err != nil {
+			return
+		}
+		info = &Info{KeyMD5: CustomerKeyMD5(params.CustomerKey)}
+		info.DataKey, err = wrapKey(params.CustomerKey, key)
+		return
+	}
+	if kms == nil {
+		return nil, nil, errors.New("server-side encryption requires KMS (see kms config)")
+	}
+	info = &Info{KeyID: params.KeyID}
+	if info.KeyID == "" {
+		info.KeyID = defaultKeyID
+	}
+	key, info.DataKey, err = kms.GenerateKey(info.KeyID)
+	return
+}
+
+func ParseInfo(s string) (*Info, error) {
+	info := &Info{}
+	if err := jsoniter.UnmarshalFromString(s, info); err != nil {
+		return nil, fmt.Errorf("invalid encryption metadata: %v", err)
+	}
+	return info, nil
+}
+
+func (info *Info) String() string {
+	s, err := jsoniter.MarshalToString(info)
+	cmn.AssertNoErr(err)
+	return s
+}
+
+func (info *Info) IsCustomer() bool { return info.KeyMD5 != "" }
+
+// Key decrypts and returns the data key; the customer key is required
+// (and used) only for SSE-C objects.
+func (info *Info) Key(customerKey []byte) ([]byte, error) {
+	if !info.IsCustomer() {
+		if kms == nil {
+			return nil, errors.New("cannot decrypt data key: KMS is not configured")
+		}
+		return kms.DecryptKey(info.KeyID, info.DataKey)
+	}
+	if customerKey == nil {
+		return nil, ErrCustomerKeyRequired
+	}
+	if subtle.ConstantTimeCompare([]byte(CustomerKeyMD5(customerKey)), []byte(info.KeyMD5)) != 1 {
+		return nil, ErrCustomerKeyMismatch
+	}
+	return unwrapKey(customerKey, info.DataKey)
+}
+
+// CustomerKeyMD5 returns base64-encoded MD5 of the key (as in S3 SSE-C headers).
+func CustomerKeyMD5(key []byte) string {
+	sum := md5.Sum(key)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// CipherSize returns the size of the encrypted content given the plaintext size.
+func CipherSize(size int64) int64 {
+	chunks := (size + ChunkSize - 1) / ChunkSize
+	if chunks == 0 {
+		chunks = 1 // empty content is sealed as well
+	}
+	return size + chunks*TagSize
+}
+
+// PlainSize returns the size of the decrypted content given the encrypted size.
+func PlainSize(size int64) int64 {
+	chunks := (size + chunkTotal - 1) / chunkTotal
+	return cmn.MaxI64(size-chunks*TagSize, 0)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("invalid key size %d (expected %d)", len(key), KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypts the data key with a given key-encryption key: random nonce || sealed data key
+func wrapKey(kek, key []byte) ([]byte, error) {
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(key)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, key, nil), nil
+}
+
+func unwrapKey(kek, wrapped []byte) ([]byte, error) {
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, errors.New("invalid encrypted data key")
+	}
+	nonce, sealed := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	key, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key: %v", err)
+	}
+	return key, nil
+}
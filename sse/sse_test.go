@@ -0,0 +1,132 @@
+// Package sse provides server-side encryption of objects at rest.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func encrypt(t *testing.T, key, plain []byte) []byte {
+	var (
+		buf     = &bytes.Buffer{}
+		ew, err = NewWriter(buf, key)
+	)
+	tassert.CheckFatal(t, err)
+	// write in odd-sized pieces to exercise chunk boundaries
+	for b := plain; len(b) > 0; {
+		n := len(b)
+		if n > 1000 {
+			n = 1000
+		}
+		_, err = ew.Write(b[:n])
+		tassert.CheckFatal(t, err)
+		b = b[n:]
+	}
+	tassert.CheckFatal(t, ew.Close())
+	tassert.Errorf(t, ew.Size() == int64(buf.Len()), "size %d != %d", ew.Size(), buf.Len())
+	return buf.Bytes()
+}
+
+func TestRoundTrip(t *testing.T) {
+	key := make([]byte, KeySize)
+	_, err := rand.Read(key)
+	tassert.CheckFatal(t, err)
+
+	for _, size := range []int{0, 1, ChunkSize - 1, ChunkSize, ChunkSize + 1, 3*ChunkSize + 17} {
+		plain := make([]byte, size)
+		_, err := rand.Read(plain)
+		tassert.CheckFatal(t, err)
+
+		sealed := encrypt(t, key, plain)
+		tassert.Fatalf(t, int64(len(sealed)) == CipherSize(int64(size)),
+			"size %d: encrypted size %d != %d", size, len(sealed), CipherSize(int64(size)))
+		tassert.Fatalf(t, PlainSize(int64(len(sealed))) == int64(size),
+			"size %d: plain size %d", size, PlainSize(int64(len(sealed))))
+
+		dr, err := NewReader(bytes.NewReader(sealed), int64(len(sealed)), key)
+		tassert.CheckFatal(t, err)
+		got, err := ioutil.ReadAll(dr)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, bytes.Equal(got, plain), "size %d: content mismatch", size)
+
+		// range reads
+		if size < 2 {
+			continue
+		}
+		for _, off := range []int{0, 1, size / 2, size - 1} {
+			length := size - off
+			if length > ChunkSize+5 {
+				length = ChunkSize + 5
+			}
+			b := make([]byte, length)
+			n, err := dr.ReadAt(b, int64(off))
+			if err != nil && err != io.EOF {
+				t.Fatal(err)
+			}
+			tassert.Fatalf(t, n == length && bytes.Equal(b, plain[off:off+length]),
+				"size %d: range [%d, %d) mismatch", size, off, off+length)
+		}
+	}
+}
+
+func TestTamper(t *testing.T) {
+	key := make([]byte, KeySize)
+	plain := make([]byte, 2*ChunkSize+100)
+	sealed := encrypt(t, key, plain)
+
+	read := func(b []byte) error {
+		dr, err := NewReader(bytes.NewReader(b), int64(len(b)), key)
+		if err != nil {
+			return err
+		}
+		_, err = ioutil.ReadAll(dr)
+		return err
+	}
+
+	// flipped bit
+	b := append([]byte{}, sealed...)
+	b[ChunkSize+10] ^= 1
+	tassert.Errorf(t, errors.Is(read(b), ErrAuth), "expected authentication error (flipped bit)")
+
+	// truncated at a chunk boundary
+	b = sealed[:2*chunkTotal]
+	tassert.Errorf(t, errors.Is(read(b), ErrAuth), "expected authentication error (truncated)")
+
+	// wrong key
+	other := make([]byte, KeySize)
+	other[0] = 1
+	dr, err := NewReader(bytes.NewReader(sealed), int64(len(sealed)), other)
+	tassert.CheckFatal(t, err)
+	_, err = ioutil.ReadAll(dr)
+	tassert.Errorf(t, errors.Is(err, ErrAuth), "expected authentication error (wrong key)")
+}
+
+func TestCustomerKey(t *testing.T) {
+	ckey := make([]byte, KeySize)
+	_, err := rand.Read(ckey)
+	tassert.CheckFatal(t, err)
+
+	info, key, err := NewInfo(&Params{CustomerKey: ckey})
+	tassert.CheckFatal(t, err)
+	parsed, err := ParseInfo(info.String())
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, parsed.IsCustomer(), "expected SSE-C")
+
+	got, err := parsed.Key(ckey)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(got, key), "data key mismatch")
+
+	_, err = parsed.Key(nil)
+	tassert.Errorf(t, err == ErrCustomerKeyRequired, "expected %v, got %v", ErrCustomerKeyRequired, err)
+	_, err = parsed.Key(make([]byte, KeySize))
+	tassert.Errorf(t, err == ErrCustomerKeyMismatch, "expected %v, got %v", ErrCustomerKeyMismatch, err)
+}
@@ -0,0 +1,195 @@
+// Package sse provides server-side encryption of objects at rest.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+const nonceSize = 12 // [ chunk index (8) | last chunk (1) | zeros (3) ]
+
+type (
+	// Writer encrypts the content and writes it to the underlying writer.
+	// Close must be called to seal the last chunk; it does not close the
+	// underlying writer.
+	Writer struct {
+		w     io.Writer
+		aead  cipher.AEAD
+		buf   []byte // plaintext of the current chunk
+		out   []byte // sealed chunk
+		idx   int64
+		size  int64 // encrypted bytes written
+		nonce [nonceSize]byte
+	}
+
+	// Reader decrypts the content read from the underlying reader; in
+	// addition to io.Reader, it implements io.ReaderAt (range reads).
+	Reader struct {
+		r      io.ReaderAt
+		aead   cipher.AEAD
+		plain  []byte // decrypted chunk `idx`
+		sealed []byte
+		csize  int64 // encrypted size
+		size   int64 // decrypted size
+		last   int64 // index of the last chunk
+		idx    int64
+		off    int64 // (io.Reader)
+		nonce  [nonceSize]byte
+	}
+)
+
+// interface guard
+var (
+	_ io.WriteCloser = (*Writer)(nil)
+	_ io.Reader      = (*Reader)(nil)
+	_ io.ReaderAt    = (*Reader)(nil)
+)
+
+var ErrAuth = errors.New("encrypted content failed authentication")
+
+func setNonce(nonce []byte, idx int64, last bool) {
+	binary.BigEndian.PutUint64(nonce, uint64(idx))
+	nonce[8] = 0
+	if last {
+		nonce[8] = 1
+	}
+}
+
+////////////
+// Writer //
+////////////
+
+func NewWriter(w io.Writer, key []byte) (*Writer, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{
+		w:    w,
+		aead: aead,
+		buf:  make([]byte, 0, ChunkSize),
+		out:  make([]byte, 0, chunkTotal),
+	}, nil
+}
+
+// Size returns the number of encrypted bytes written so far.
+func (ew *Writer) Size() int64 { return ew.size }
+
+func (ew *Writer) Write(b []byte) (n int, err error) {
+	for len(b) > 0 {
+		// seal a full chunk only when more content arrives - to know the last one
+		if len(ew.buf) == ChunkSize {
+			if err = ew.seal(false); err != nil {
+				return
+			}
+		}
+		m := copy(ew.buf[len(ew.buf):ChunkSize], b)
+		ew.buf = ew.buf[:len(ew.buf)+m]
+		n += m
+		b = b[m:]
+	}
+	return
+}
+
+func (ew *Writer) Close() error { return ew.seal(true) }
+
+func (ew *Writer) seal(last bool) error {
+	setNonce(ew.nonce[:], ew.idx, last)
+	ew.out = ew.aead.Seal(ew.out[:0], ew.nonce[:], ew.buf, nil)
+	ew.buf = ew.buf[:0]
+	ew.idx++
+	n, err := ew.w.Write(ew.out)
+	ew.size += int64(n)
+	return err
+}
+
+////////////
+// Reader //
+////////////
+
+// NewReader returns Reader of the encrypted content of a given size.
+func NewReader(r io.ReaderAt, size int64, key []byte) (*Reader, error) {
+	chunks := (size + chunkTotal - 1) / chunkTotal
+	if chunks == 0 || size-(chunks-1)*chunkTotal < TagSize {
+		return nil, fmt.Errorf("invalid size of encrypted content %d", size)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{
+		r:      r,
+		aead:   aead,
+		plain:  make([]byte, 0, ChunkSize),
+		sealed: make([]byte, chunkTotal),
+		csize:  size,
+		size:   size - chunks*TagSize,
+		last:   chunks - 1,
+		idx:    -1,
+	}, nil
+}
+
+// Size returns the size of the decrypted content.
+func (dr *Reader) Size() int64 { return dr.size }
+
+func (dr *Reader) Read(b []byte) (n int, err error) {
+	n, err = dr.ReadAt(b, dr.off)
+	dr.off += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return
+}
+
+func (dr *Reader) ReadAt(b []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, fmt.Errorf("invalid offset %d", off)
+	}
+	for len(b) > 0 && off < dr.size {
+		idx := off / ChunkSize
+		if err = dr.open(idx); err != nil {
+			return
+		}
+		m := copy(b, dr.plain[off-idx*ChunkSize:])
+		n += m
+		off += int64(m)
+		b = b[m:]
+	}
+	if len(b) > 0 {
+		err = io.EOF
+	}
+	return
+}
+
+// reads and decrypts a given chunk
+func (dr *Reader) open(idx int64) (err error) {
+	if idx == dr.idx {
+		return
+	}
+	var (
+		off    = idx * chunkTotal
+		sealed = dr.sealed[:cmn.MinI64(chunkTotal, dr.csize-off)]
+		n      int
+	)
+	dr.idx = -1
+	if n, err = dr.r.ReadAt(sealed, off); n < len(sealed) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return
+	}
+	setNonce(dr.nonce[:], idx, idx == dr.last)
+	if dr.plain, err = dr.aead.Open(dr.plain[:0], dr.nonce[:], sealed, nil); err != nil {
+		return fmt.Errorf("%w (chunk %d)", ErrAuth, idx)
+	}
+	dr.idx = idx
+	return nil
+}
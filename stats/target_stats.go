@@ -36,11 +36,15 @@ const (
 	RebTxSize  = "reb.tx.size"
 	RebRxCount = "reb.rx.n"
 	RebRxSize  = "reb.rx.size"
+	// replication (see cmn.RemoteReplConf)
+	ReplCount = "repl.n"
+	ReplSize  = "repl.size"
 	// errors
 	ErrCksumCount    = "err.cksum.n"
 	ErrCksumSize     = "err.cksum.size"
 	ErrMetadataCount = "err.md.n"
 	ErrIOCount       = "err.io.n"
+	ErrReplCount     = "err.repl.n"
 	// special
 	RestartCount = "restart.n"
 
@@ -50,6 +54,7 @@ const (
 	GetRedirLatency = "get.redir.ns"
 	PutRedirLatency = "put.redir.ns"
 	DownloadLatency = "dl.ns"
+	ReplLag         = "repl.lag.ns" // time from PUT (DELETE) to its replication
 
 	// DSort
 	DSortCreationReqCount    = "dsort.creation.req.n"
@@ -146,6 +151,7 @@ func (r *Trunner) RegisterAll() {
 	r.Register(ErrCksumSize, KindCounter)
 	r.Register(ErrMetadataCount, KindCounter)
 	r.Register(ErrIOCount, KindCounter)
+	r.Register(ErrReplCount, KindCounter)
 
 	// rebalance
 	r.Register(RebTxCount, KindCounter)
@@ -153,6 +159,11 @@ func (r *Trunner) RegisterAll() {
 	r.Register(RebRxCount, KindCounter)
 	r.Register(RebRxSize, KindCounter)
 
+	// replication
+	r.Register(ReplCount, KindCounter)
+	r.Register(ReplSize, KindCounter)
+	r.Register(ReplLag, KindLatency)
+
 	// special
 	r.Register(RestartCount, KindCounter)
 
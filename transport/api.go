@@ -57,6 +57,9 @@ type (
 		CksumType  string // checksum type
 		CksumValue string // checksum of the object produced by given checksum type
 		Version    string // version of the object
+		SSE        string // encryption metadata of the (encrypted) object
+		Compress   string // compression metadata of the (compressed) object
+		Dirty      bool   // not yet written back to the remote bucket
 	}
 	// object header
 	ObjHdr struct {
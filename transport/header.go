@@ -99,12 +99,23 @@ func insUint64(off int, to []byte, i uint64) int {
 	return off + cmn.SizeofI64
 }
 
+func insBool(off int, to []byte, b bool) int {
+	var i uint64
+	if b {
+		i = 1
+	}
+	return insUint64(off, to, i)
+}
+
 func insAttrs(off int, to []byte, attr ObjectAttrs) int {
 	off = insInt64(off, to, attr.Size)
 	off = insInt64(off, to, attr.Atime)
 	off = insString(off, to, attr.CksumType)
 	off = insString(off, to, attr.CksumValue)
 	off = insString(off, to, attr.Version)
+	off = insString(off, to, attr.SSE)
+	off = insString(off, to, attr.Compress)
+	off = insBool(off, to, attr.Dirty)
 	return off
 }
 
@@ -168,11 +179,19 @@ func extUint64(off int, from []byte) (int, uint64) {
 	return off, size
 }
 
+func extBool(off int, from []byte) (int, bool) {
+	off, val := extUint64(off, from)
+	return off, val != 0
+}
+
 func extAttrs(off int, from []byte) (n int, attr ObjectAttrs) {
 	off, attr.Size = extInt64(off, from)
 	off, attr.Atime = extInt64(off, from)
 	off, attr.CksumType = extString(off, from)
 	off, attr.CksumValue = extString(off, from)
 	off, attr.Version = extString(off, from)
+	off, attr.SSE = extString(off, from)
+	off, attr.Compress = extString(off, from)
+	off, attr.Dirty = extBool(off, from)
 	return off, attr
 }
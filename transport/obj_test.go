@@ -374,6 +374,9 @@ func Test_ObjAttrs(t *testing.T) {
 			CksumType:  cmn.ChecksumXXHash,
 			CksumValue: "120421",
 			Version:    "102.44",
+			SSE:        `{"kid":"k1","dk":"AAEC"}`,
+			Compress:   `{"alg":"zstd","size":1024}`,
+			Dirty:      true,
 		},
 		{
 			Size:       0,
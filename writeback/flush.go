@@ -0,0 +1,103 @@
+// Package writeback uploads objects PUT into write-back remote buckets to their
+// remote (cloud or remote AIS) buckets asynchronously.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package writeback
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+)
+
+type (
+	flushProvider struct {
+		xreg.BaseBckEntry
+		xact *XactFlush
+
+		t    cluster.Target
+		uuid string
+	}
+
+	// XactFlush traverses all local mountpaths and writes back all dirty
+	// objects of a given bucket. Unlike the `write-back` xaction, it finishes
+	// only when there are no dirty objects left (or when some of them failed
+	// all retries, in which case it finishes with error).
+	XactFlush struct {
+		xaction.XactBase
+		joggers *mpather.JoggerGroup
+		t       cluster.Target
+		failed  atomic.Int64
+	}
+)
+
+// interface guard
+var _ cluster.Xact = (*XactFlush)(nil)
+
+func (*flushProvider) New(args xreg.XactArgs) xreg.BucketEntry {
+	return &flushProvider{t: args.T, uuid: args.UUID}
+}
+
+func (p *flushProvider) Start(bck cmn.Bck) error {
+	p.xact = newXactFlush(p.t, bck, p.uuid)
+	return nil
+}
+func (*flushProvider) Kind() string        { return cmn.ActFlush }
+func (p *flushProvider) Get() cluster.Xact { return p.xact }
+
+func newXactFlush(t cluster.Target, bck cmn.Bck, uuid string) *XactFlush {
+	r := &XactFlush{XactBase: *xaction.NewXactBaseBck(uuid, cmn.ActFlush, bck), t: t}
+	r.joggers = mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
+		T:        t,
+		Bck:      bck,
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		DoLoad:   mpather.Load,
+		Throttle: true,
+	})
+	return r
+}
+
+func (r *XactFlush) Run() (err error) {
+	glog.Infoln(r.String())
+	r.joggers.Run()
+	select {
+	case <-r.ChanAbort():
+		r.joggers.Stop()
+		err = cmn.NewAbortedError(r.String())
+	case <-r.joggers.ListenFinished():
+		err = r.joggers.Stop()
+	}
+	if err == nil {
+		if n := r.failed.Load(); n > 0 {
+			err = fmt.Errorf("%s: failed to write back %d object(s)", r, n)
+		}
+	}
+	r.Finish(err)
+	return
+}
+
+func (r *XactFlush) visitObj(lom *cluster.LOM, _ []byte) error {
+	if !lom.Dirty() {
+		return nil
+	}
+	size, err := uploadRetry(r.t, lom, lom.Bprops().WriteBack.Retries, r.ChanAbort())
+	if err != nil {
+		glog.Errorf("%s: failed to write back %s, err: %v", r, lom, err)
+		r.failed.Inc()
+		return nil
+	}
+	if size > 0 {
+		r.ObjectsInc()
+		r.BytesAdd(size)
+	}
+	return nil
+}
@@ -0,0 +1,323 @@
+// Package writeback uploads objects PUT into write-back remote buckets to their
+// remote (cloud or remote AIS) buckets asynchronously.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package writeback
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+)
+
+// When write-back is enabled for a remote bucket (see cmn.WriteBackConf), PUT
+// stores the object locally, marks it dirty (see cluster.LOM.Dirty), and
+// responds right away. The object then gets queued to the bucket's `write-back`
+// xaction - one per bucket per target - that uploads dirty objects to the
+// remote bucket in the background:
+//   - a failed upload is retried up to `write_back.retries` times;
+//   - PUTs of the same object are coalesced, and the object is never uploaded
+//     concurrently with itself, so that the remote bucket ends up with the
+//     latest content;
+//   - once uploaded, the object's dirty flag is cleared - unless the object
+//     was overwritten in the meantime.
+//
+// The total size of the objects pending write-back is bounded (per target) by
+// `write_back.max_dirty`: when exceeded, PUTs revert to synchronous upload.
+//
+// Objects that remain dirty - e.g., failed all retries or got migrated by
+// rebalance or are left from before restart - are written back by the `flush`
+// xaction (see flush.go).
+
+const (
+	workChanCap     = 1024 // max number of queued objects
+	workersPerMpath = 2
+	retryDelay      = time.Second // multiplied by the attempt number
+)
+
+type (
+	wbProvider struct {
+		xreg.BaseBckEntry
+		xact *Xaction
+
+		t cluster.Target
+	}
+	Xaction struct {
+		// implements cluster.Xact interface
+		xaction.XactDemandBase
+		// runtime
+		mu      sync.Mutex
+		objs    map[string]*pending // by object name
+		workCh  chan string
+		stopCh  *cmn.StopCh
+		wg      sync.WaitGroup
+		stopped bool
+		// init
+		t cluster.Target
+	}
+	pending struct {
+		size     int64 // reserved (see Reserve)
+		inflight bool
+		again    bool // PUT while in flight
+	}
+)
+
+// interface guard
+var _ cluster.Xact = (*Xaction)(nil)
+
+// total size of the objects pending write-back (this target)
+var dirtySize atomic.Int64
+
+func init() {
+	xreg.RegisterBucketXact(&wbProvider{})
+	xreg.RegisterBucketXact(&flushProvider{})
+}
+
+func (*wbProvider) New(args xreg.XactArgs) xreg.BucketEntry {
+	return &wbProvider{t: args.T}
+}
+
+func (p *wbProvider) Start(bck cmn.Bck) error {
+	p.xact = newXaction(p.t, bck)
+	go func() {
+		err := p.xact.Run()
+		p.xact.Finish(err)
+	}()
+	return nil
+}
+func (*wbProvider) Kind() string        { return cmn.ActWriteBack }
+func (p *wbProvider) Get() cluster.Xact { return p.xact }
+
+// Reserve accounts for a new dirty object of a given size. Returns false if
+// the total size of dirty objects would exceed the limit (0 - no limit).
+func Reserve(size, limit int64) bool {
+	if n := dirtySize.Add(size); limit > 0 && n > limit {
+		dirtySize.Sub(size)
+		return false
+	}
+	return true
+}
+
+// Release undoes Reserve.
+func Release(size int64) { dirtySize.Sub(size) }
+
+// DirtySize returns the total size of the objects pending write-back.
+func DirtySize() int64 { return dirtySize.Load() }
+
+// Upload writes back a given (dirty) object to its remote bucket and, upon
+// success, clears the object's dirty flag - unless the object was overwritten
+// while being uploaded. Not dirty (or not existing) object is a no-op.
+func Upload(ctx context.Context, t cluster.Target, lom *cluster.LOM) (size int64, errCode int, err error) {
+	var (
+		finfo os.FileInfo
+		fh    cmn.ReadOpenCloser
+		bck   = lom.Bck()
+	)
+	lom.Lock(false)
+	if err = lom.Load(false); err == nil && lom.Dirty() {
+		if finfo, err = os.Stat(lom.FQN); err == nil {
+			fh, err = lom.Open()
+		}
+	}
+	lom.Unlock(false)
+	if err != nil || fh == nil {
+		if cmn.IsObjNotExist(err) || os.IsNotExist(err) {
+			err = nil // removed in the meantime
+		}
+		return
+	}
+	cloud := t.Cloud(bck)
+	version, errCode, err := cloud.PutObj(ctx, fh, lom) // NOTE: remote AIS closes the handle
+	if !bck.IsRemoteAIS() {
+		cmn.Close(fh)
+	}
+	if err != nil {
+		return
+	}
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err = lom.Load(false); err != nil {
+		if cmn.IsObjNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	if curr, errStat := os.Stat(lom.FQN); errStat != nil || !os.SameFile(finfo, curr) {
+		return // overwritten - the new content is yet to be written back
+	}
+	if !bck.IsRemoteAIS() {
+		customMD := cmn.SimpleKVs{cluster.SourceObjMD: cloud.Provider()}
+		if version != "" {
+			customMD[cluster.VersionObjMD] = version
+		}
+		lom.SetCustomMD(customMD)
+	}
+	if lom.VersionConf().Enabled {
+		lom.SetVersion(version)
+	}
+	lom.SetDirty(false)
+	if err = lom.Persist(); err != nil {
+		return
+	}
+	lom.ReCache()
+	return lom.ContentSize(), 0, nil
+}
+
+// uploadRetry calls Upload up to 1 + `retries` times, with increasing delay
+// between attempts.
+func uploadRetry(t cluster.Target, lom *cluster.LOM, retries int, abort <-chan struct{}) (size int64, err error) {
+	for i := 0; ; i++ {
+		if size, _, err = Upload(context.Background(), t, lom); err == nil || i >= retries {
+			return
+		}
+		glog.Warningf("%s: write-back failed (attempt %d/%d), err: %v", lom, i+1, retries+1, err)
+		select {
+		case <-abort:
+			return
+		case <-time.After(time.Duration(i+1) * retryDelay):
+		}
+	}
+}
+
+/////////////
+// Xaction //
+/////////////
+
+func newXaction(t cluster.Target, bck cmn.Bck) *Xaction {
+	r := &Xaction{
+		XactDemandBase: *xaction.NewXactDemandBaseBck(cmn.ActWriteBack, bck),
+		objs:           make(map[string]*pending),
+		workCh:         make(chan string, workChanCap),
+		stopCh:         cmn.NewStopCh(),
+		t:              t,
+	}
+	r.InitIdle()
+	return r
+}
+
+func (r *Xaction) Run() error {
+	glog.Infoln(r.String())
+	numWorkers := cmn.Max(fs.NumAvail(), 1) * workersPerMpath
+	for i := 0; i < numWorkers; i++ {
+		r.wg.Add(1)
+		go r.work()
+	}
+	for {
+		select {
+		case <-r.IdleTimer():
+			r.stop()
+			return nil
+		case <-r.ChanAbort():
+			r.stop()
+			return cmn.NewAbortedError(r.String())
+		}
+	}
+}
+
+// Enqueue queues a given dirty object for write-back; the caller must have
+// reserved the object's size (see Reserve) - the reservation gets released
+// once the object is written back.
+func (r *Xaction) Enqueue(lom *cluster.LOM) error {
+	if r.Finished() {
+		return xaction.NewErrXactExpired("Cannot write back: " + r.String())
+	}
+	r.mu.Lock()
+	if r.stopped {
+		r.mu.Unlock()
+		return xaction.NewErrXactExpired("Cannot write back: " + r.String())
+	}
+	if p, ok := r.objs[lom.ObjName]; ok {
+		// coalesce with the one that is already queued (or in flight)
+		Release(p.size)
+		p.size = lom.Size()
+		p.again = p.inflight
+		r.mu.Unlock()
+		return nil
+	}
+	r.objs[lom.ObjName] = &pending{size: lom.Size()}
+	r.IncPending()
+	r.mu.Unlock()
+	select {
+	case r.workCh <- lom.ObjName:
+	case <-r.stopCh.Listen(): // the object remains dirty (see stop)
+	}
+	return nil
+}
+
+func (r *Xaction) work() {
+	defer r.wg.Done()
+	for {
+		select {
+		case objName := <-r.workCh:
+			r.do(objName)
+		case <-r.stopCh.Listen():
+			return
+		}
+	}
+}
+
+func (r *Xaction) do(objName string) {
+	r.mu.Lock()
+	p, ok := r.objs[objName]
+	if !ok { // dropped upon stop
+		r.mu.Unlock()
+		return
+	}
+	p.inflight = true
+	r.mu.Unlock()
+
+	for {
+		lom := &cluster.LOM{T: r.t, ObjName: objName}
+		if err := lom.Init(r.Bck()); err != nil {
+			glog.Error(err)
+		} else if size, err := uploadRetry(r.t, lom, lom.Bprops().WriteBack.Retries, r.ChanAbort()); err != nil {
+			glog.Errorf("%s: failed to write back %s, err: %v", r, lom, err)
+		} else if size > 0 {
+			r.ObjectsInc()
+			r.BytesAdd(size)
+		}
+		r.mu.Lock()
+		if p.again {
+			p.again = false
+			r.mu.Unlock()
+			continue
+		}
+		delete(r.objs, objName)
+		Release(p.size)
+		r.mu.Unlock()
+		r.DecPending()
+		return
+	}
+}
+
+func (r *Xaction) stop() {
+	r.XactDemandBase.Stop()
+	r.mu.Lock()
+	r.stopped = true
+	r.mu.Unlock()
+	r.stopCh.Close()
+	r.wg.Wait()
+	// objects that are still queued remain dirty (see `flush`)
+	r.mu.Lock()
+	n := len(r.objs)
+	for objName, p := range r.objs {
+		Release(p.size)
+		delete(r.objs, objName)
+	}
+	r.mu.Unlock()
+	if n > 0 {
+		r.SubPending(n)
+		glog.Warningf("%s: %d object(s) left dirty", r, n)
+	}
+}
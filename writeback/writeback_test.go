@@ -0,0 +1,28 @@
+// Package writeback uploads objects PUT into write-back remote buckets to their
+// remote (cloud or remote AIS) buckets asynchronously.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package writeback
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestReserve(t *testing.T) {
+	const limit = 10 * cmn.KiB
+	tassert.Fatalf(t, Reserve(4*cmn.KiB, limit), "expected to reserve")
+	tassert.Fatalf(t, Reserve(6*cmn.KiB, limit), "expected to reserve up to the limit")
+	tassert.Fatalf(t, !Reserve(1, limit), "expected to exceed the limit")
+	tassert.Errorf(t, DirtySize() == limit, "expected %d, got %d", limit, DirtySize())
+
+	Release(4 * cmn.KiB)
+	tassert.Fatalf(t, Reserve(cmn.KiB, limit), "expected to reserve after release")
+	tassert.Fatalf(t, Reserve(limit, 0), "expected no limit")
+
+	Release(6*cmn.KiB + cmn.KiB + limit)
+	tassert.Errorf(t, DirtySize() == 0, "expected nothing reserved, got %d", DirtySize())
+}
@@ -63,6 +63,7 @@ var _ cluster.XactStats = (*BaseXactStats)(nil)
 var XactsDtor = map[string]XactDescriptor{
 	// bucket-less (aka "global") xactions with scope = (target | cluster)
 	cmn.ActLRU:       {Type: XactTypeGlobal, Startable: true, Mountpath: true},
+	cmn.ActLifecycle: {Type: XactTypeGlobal, Startable: true, Mountpath: true},
 	cmn.ActElection:  {Type: XactTypeGlobal, Startable: false},
 	cmn.ActResilver:  {Type: XactTypeGlobal, Startable: true, Mountpath: true},
 	cmn.ActRebalance: {Type: XactTypeGlobal, Startable: true, Metasync: true, Owned: false, Mountpath: true},
@@ -74,6 +75,11 @@ var XactsDtor = map[string]XactDescriptor{
 	cmn.ActECRespond:     {Type: XactTypeBck, Startable: false},
 	cmn.ActMakeNCopies:   {Type: XactTypeBck, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
 	cmn.ActPutCopies:     {Type: XactTypeBck, Startable: false},
+	cmn.ActWriteBack:     {Type: XactTypeBck, Startable: false},
+	cmn.ActSyncBucket:    {Type: XactTypeBck, Startable: false, Mountpath: true},
+	cmn.ActFlush:         {Type: XactTypeBck, Startable: true, Mountpath: true},
+	cmn.ActReplicate:     {Type: XactTypeBck, Startable: false},
+	cmn.ActReplCatchup:   {Type: XactTypeBck, Startable: true, Mountpath: true},
 	cmn.ActRenameLB:      {Type: XactTypeBck, Startable: false, Metasync: true, Owned: false, Mountpath: true},
 	cmn.ActCopyBucket:    {Type: XactTypeBck, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
 	cmn.ActETLBucket:     {Type: XactTypeBck, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
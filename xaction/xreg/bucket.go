@@ -69,9 +69,16 @@ type (
 		UUID     string
 		RangeMsg *cmn.RangeMsg
 		ListMsg  *cmn.ListMsg
+		QueryMsg *query.DefMsg // selects objects by prefix and filters (bucket is ignored)
 		Evict    bool
 	}
 
+	SyncBckArgs struct {
+		Ctx  context.Context
+		UUID string
+		Msg  *cmn.SyncBckMsg
+	}
+
 	BckRenameArgs struct {
 		RebID   xaction.RebID
 		BckFrom *cluster.Bck
@@ -263,6 +270,14 @@ func (r *registry) renewPrefetch(t cluster.Target, bck *cluster.Bck, args *Delet
 	return xact
 }
 
+func RenewSyncBck(t cluster.Target, bck *cluster.Bck, args *SyncBckArgs) (cluster.Xact, error) {
+	return defaultReg.renewBucketXact(cmn.ActSyncBucket, bck, XactArgs{
+		T:      t,
+		UUID:   args.UUID,
+		Custom: args,
+	})
+}
+
 func RenewBckRename(t cluster.Target, bckFrom, bckTo *cluster.Bck,
 	uuid string, rmdVersion int64, phase string) (cluster.Xact, error) {
 	return defaultReg.renewBckRename(t, bckFrom, bckTo, uuid, rmdVersion, phase)
@@ -101,6 +101,17 @@ func (r *registry) renewLRU(id string) cluster.Xact {
 	return res.entry.Get()
 }
 
+func RenewLifecycle(id string) cluster.Xact { return defaultReg.renewLifecycle(id) }
+
+func (r *registry) renewLifecycle(id string) cluster.Xact {
+	e := r.globalXacts[cmn.ActLifecycle].New(XactArgs{UUID: id})
+	res := r.renewGlobalXaction(e)
+	if !res.isNew { // previous lifecycle is still running
+		return nil
+	}
+	return res.entry.Get()
+}
+
 func RenewDownloader(t cluster.Target, statsT stats.Tracker) (cluster.Xact, error) {
 	return defaultReg.renewDownloader(t, statsT)
 }
@@ -0,0 +1,323 @@
+// Package runners provides implementation for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package xrun
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/writeback"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+)
+
+// The `sync-bucket` xaction reconciles locally cached objects of a remote
+// bucket (cloud, remote AIS, or ais bucket with backend) with the remote
+// bucket itself. Each target handles the objects it owns (by HRW):
+//   1. lists the remote bucket and fetches the objects that are either
+//      not cached or cached with a different version (or ETag or size);
+//   2. traverses local mountpaths and evicts the cached objects that are no
+//      longer present in the remote bucket - or, when requested, uploads them.
+// Dirty objects (see cmn.WriteBackConf) are never fetched over or evicted.
+
+func init() {
+	xreg.RegisterBucketXact(&bckSyncProvider{})
+}
+
+type (
+	bckSyncProvider struct {
+		xreg.BaseBckEntry
+		xact *bckSync
+
+		t    cluster.Target
+		args *xreg.SyncBckArgs
+	}
+	bckSync struct {
+		xaction.XactBase
+		t       cluster.Target
+		args    *xreg.SyncBckArgs
+		joggers *mpather.JoggerGroup
+		remote  map[string]struct{} // local (HRW) objects present in the remote bucket
+		stats   struct {
+			fetched atomic.Int64
+			evicted atomic.Int64
+			pushed  atomic.Int64
+			errors  atomic.Int64
+		}
+	}
+
+	BckSyncStats struct {
+		xaction.BaseXactStats
+		Ext ExtBckSyncStats `json:"ext"`
+	}
+	ExtBckSyncStats struct {
+		FetchedCount int64 `json:"fetched.n,string"`
+		EvictedCount int64 `json:"evicted.n,string"`
+		PushedCount  int64 `json:"pushed.n,string"`
+		ErrCount     int64 `json:"err.n,string"`
+		DryRun       bool  `json:"dry_run"`
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact      = (*bckSync)(nil)
+	_ cluster.XactStats = (*BckSyncStats)(nil)
+)
+
+func (*bckSyncProvider) New(args xreg.XactArgs) xreg.BucketEntry {
+	return &bckSyncProvider{
+		t:    args.T,
+		args: args.Custom.(*xreg.SyncBckArgs),
+	}
+}
+
+func (p *bckSyncProvider) Start(bck cmn.Bck) error {
+	p.xact = newBckSync(p.args.UUID, bck, p.t, p.args)
+	return nil
+}
+func (*bckSyncProvider) Kind() string        { return cmn.ActSyncBucket }
+func (p *bckSyncProvider) Get() cluster.Xact { return p.xact }
+func (p *bckSyncProvider) PreRenewHook(previousEntry xreg.BucketEntry) (keep bool, err error) {
+	err = fmt.Errorf("%s is already running", previousEntry.Get())
+	return
+}
+
+func newBckSync(uuid string, bck cmn.Bck, t cluster.Target, args *xreg.SyncBckArgs) *bckSync {
+	r := &bckSync{
+		XactBase: *xaction.NewXactBaseBck(uuid, cmn.ActSyncBucket, bck),
+		t:        t,
+		args:     args,
+		remote:   make(map[string]struct{}, 1024),
+	}
+	r.joggers = mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
+		T:        t,
+		Bck:      bck,
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		DoLoad:   mpather.Load,
+		Throttle: true,
+	})
+	return r
+}
+
+func (r *bckSync) Run() (err error) {
+	glog.Infoln(r.String())
+	if err = r.syncRemote(); err == nil && !r.Aborted() {
+		err = r.syncLocal()
+	}
+	if err == nil {
+		if n := r.stats.errors.Load(); n > 0 {
+			err = fmt.Errorf("%s: failed to sync %d object(s)", r, n)
+		}
+	}
+	r.Finish(err)
+	return
+}
+
+func (r *bckSync) Stats() cluster.XactStats {
+	baseStats := r.XactBase.Stats().(*xaction.BaseXactStats)
+	syncStats := BckSyncStats{BaseXactStats: *baseStats}
+	syncStats.Ext.FetchedCount = r.stats.fetched.Load()
+	syncStats.Ext.EvictedCount = r.stats.evicted.Load()
+	syncStats.Ext.PushedCount = r.stats.pushed.Load()
+	syncStats.Ext.ErrCount = r.stats.errors.Load()
+	syncStats.Ext.DryRun = r.args.Msg.DryRun
+	return &syncStats
+}
+
+// phase 1: fetch new and changed objects
+func (r *bckSync) syncRemote() error {
+	var (
+		smap = r.t.Sowner().Get()
+		sid  = r.t.Snode().ID()
+		bck  = cluster.NewBckEmbed(r.Bck())
+		msg  = &cmn.SelectMsg{Prefix: r.args.Msg.Prefix}
+	)
+	msg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum, cmn.GetPropsVersion)
+	if err := bck.Init(r.t.Bowner(), r.t.Snode()); err != nil {
+		return err
+	}
+	cloud := r.t.Cloud(bck)
+	for !r.Aborted() {
+		objList, _, err := cloud.ListObjects(r.args.Ctx, bck, msg)
+		if err != nil {
+			return err
+		}
+		for _, entry := range objList.Entries {
+			if r.Aborted() {
+				return nil
+			}
+			local, err := isLocalObject(smap, r.Bck(), entry.Name, sid)
+			if err != nil {
+				return err
+			}
+			if !local {
+				continue
+			}
+			r.remote[entry.Name] = struct{}{}
+			if err := r.fetch(entry); err != nil {
+				glog.Errorf("%s: failed to fetch %s, err: %v", r, entry.Name, err)
+				r.stats.errors.Inc()
+			}
+		}
+		if objList.ContinuationToken == "" {
+			break
+		}
+		msg.ContinuationToken = objList.ContinuationToken
+	}
+	return nil
+}
+
+func (r *bckSync) fetch(entry *cmn.BucketEntry) error {
+	lom := &cluster.LOM{T: r.t, ObjName: entry.Name}
+	if err := lom.Init(r.Bck()); err != nil {
+		return err
+	}
+	lom.Lock(false)
+	if err := lom.Load(); err != nil {
+		lom.Unlock(false)
+		if !cmn.IsErrObjNought(err) {
+			return err
+		}
+	} else {
+		var (
+			dirty   = lom.Dirty()
+			version = lom.Version()
+			size    = lom.ContentSize() // as stored remotely (not compressed or encrypted)
+		)
+		if v, ok := lom.GetCustomMD(cluster.VersionObjMD); ok {
+			version = v
+		}
+		md5, _ := lom.GetCustomMD(cluster.MD5ObjMD)
+		lom.Unlock(false)
+		if dirty {
+			return nil // local changes are yet to be written back
+		}
+		if !remoteChanged(entry, version, md5, size) {
+			return nil
+		}
+	}
+	if r.args.Msg.DryRun {
+		r.stats.fetched.Inc()
+		return nil
+	}
+	// see prefetch
+	lom.SetAtimeUnix(-time.Now().UnixNano())
+	if _, err := r.t.GetCold(r.args.Ctx, lom, cluster.Prefetch); err != nil {
+		if errors.Is(err, cmn.ErrSkip) {
+			return nil
+		}
+		return err
+	}
+	r.stats.fetched.Inc()
+	r.ObjectsInc()
+	r.BytesAdd(lom.Size())
+	return nil
+}
+
+// remoteChanged returns true if the remote object, as listed, differs from
+// its cached copy: by version, if both are known, otherwise by MD5
+// checksum (ETag), otherwise by size.
+func remoteChanged(entry *cmn.BucketEntry, version, md5 string, size int64) bool {
+	if entry.Version != "" && version != "" {
+		return entry.Version != version
+	}
+	if entry.Checksum != "" && md5 != "" {
+		return entry.Checksum != md5
+	}
+	return entry.Size != size
+}
+
+// phase 2: evict (or push) local-only objects
+func (r *bckSync) syncLocal() (err error) {
+	r.joggers.Run()
+	select {
+	case <-r.ChanAbort():
+		r.joggers.Stop()
+	case <-r.joggers.ListenFinished():
+		err = r.joggers.Stop()
+	}
+	return
+}
+
+func (r *bckSync) visitObj(lom *cluster.LOM, _ []byte) error {
+	if !strings.HasPrefix(lom.ObjName, r.args.Msg.Prefix) {
+		return nil
+	}
+	if _, ok := r.remote[lom.ObjName]; ok {
+		return nil
+	}
+	if r.args.Msg.Push {
+		if err := r.push(lom); err != nil {
+			glog.Errorf("%s: failed to push %s, err: %v", r, lom, err)
+			r.stats.errors.Inc()
+		}
+		return nil
+	}
+	if lom.Dirty() {
+		return nil // not yet written back (or failed to)
+	}
+	if r.args.Msg.DryRun {
+		r.stats.evicted.Inc()
+		return nil
+	}
+	if errCode, err := r.t.DeleteObject(r.args.Ctx, lom, true /*evict*/); err != nil {
+		if !cmn.IsObjNotExist(err) && errCode != http.StatusConflict {
+			glog.Errorf("%s: failed to evict %s, err: %v", r, lom, err)
+			r.stats.errors.Inc()
+		}
+		return nil
+	}
+	r.stats.evicted.Inc()
+	r.ObjectsInc()
+	r.BytesAdd(lom.Size())
+	return nil
+}
+
+// push uploads a local-only object to the remote bucket - by marking it
+// dirty and writing it back
+func (r *bckSync) push(lom *cluster.LOM) error {
+	if r.args.Msg.DryRun {
+		r.stats.pushed.Inc()
+		return nil
+	}
+	if !lom.Dirty() {
+		lom.Lock(true)
+		err := lom.Load(false)
+		if err == nil {
+			lom.SetDirty(true)
+			if err = lom.Persist(); err == nil {
+				lom.ReCache()
+			}
+		}
+		lom.Unlock(true)
+		if err != nil {
+			if cmn.IsObjNotExist(err) {
+				err = nil
+			}
+			return err
+		}
+	}
+	size, _, err := writeback.Upload(r.args.Ctx, r.t, lom)
+	if err != nil {
+		return err
+	}
+	if size > 0 {
+		r.stats.pushed.Inc()
+		r.ObjectsInc()
+		r.BytesAdd(size)
+	}
+	return nil
+}
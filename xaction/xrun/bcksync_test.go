@@ -0,0 +1,33 @@
+// Package runners provides implementation for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package xrun
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestRemoteChanged(t *testing.T) {
+	tests := []struct {
+		entry   cmn.BucketEntry
+		version string
+		md5     string
+		size    int64
+		changed bool
+	}{
+		{entry: cmn.BucketEntry{Version: "2", Checksum: "a", Size: 1}, version: "2", md5: "b", size: 2, changed: false},
+		{entry: cmn.BucketEntry{Version: "2", Checksum: "a", Size: 1}, version: "1", md5: "a", size: 1, changed: true},
+		{entry: cmn.BucketEntry{Checksum: "a", Size: 1}, version: "1", md5: "a", size: 2, changed: false},
+		{entry: cmn.BucketEntry{Checksum: "a", Size: 1}, md5: "b", size: 1, changed: true},
+		{entry: cmn.BucketEntry{Checksum: "a", Size: 1}, size: 1, changed: false},
+		{entry: cmn.BucketEntry{Size: 1}, size: 2, changed: true},
+	}
+	for i, test := range tests {
+		changed := remoteChanged(&test.entry, test.version, test.md5, test.size)
+		tassert.Errorf(t, changed == test.changed, "test #%d: expected changed=%t, got %t", i, test.changed, changed)
+	}
+}
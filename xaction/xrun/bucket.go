@@ -173,9 +173,12 @@ func newEvictDelete(uuid, kind string, bck cmn.Bck, t cluster.Target, args *xreg
 
 func (r *evictDelete) Run() error {
 	var err error
-	if r.args.RangeMsg != nil {
+	switch {
+	case r.args.RangeMsg != nil:
 		err = r.iterateBucketRange(r.args)
-	} else {
+	case r.args.QueryMsg != nil:
+		err = r.iterateQuery(r.args, r.doObjEvictDelete)
+	default:
 		err = r.listOperation(r.args, r.args.ListMsg)
 	}
 	r.Finish()
@@ -228,9 +231,12 @@ func newPrefetch(uuid, kind string, bck cmn.Bck, t cluster.Target, args *xreg.De
 
 func (r *prefetch) Run() error {
 	var err error
-	if r.args.RangeMsg != nil {
+	switch {
+	case r.args.RangeMsg != nil:
 		err = r.iterateBucketRange(r.args)
-	} else {
+	case r.args.QueryMsg != nil:
+		err = r.iterateQuery(r.args, r.prefetchMissing)
+	default:
 		err = r.listOperation(r.args, r.args.ListMsg)
 	}
 	r.Finish()
@@ -6,12 +6,14 @@ package xrun
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/objwalk"
+	"github.com/NVIDIA/aistore/query"
 	"github.com/NVIDIA/aistore/xaction/xreg"
 )
 
@@ -151,7 +153,39 @@ func (r *listRangeBase) iterateRange(args *xreg.DeletePrefetchArgs, cb objCallba
 	if len(pt.Ranges) != 0 {
 		return r.iterateTemplate(args, smap, &pt, cb)
 	}
-	return r.iteratePrefix(args, smap, pt.Prefix, cb)
+	return r.iteratePrefix(args, smap, pt.Prefix, nil, cb)
+}
+
+// iterateQuery visits the objects selected by prefix and filters (see
+// query.DefMsg). The filters apply to the locally cached object or, when
+// there's no such object, to the size and version from the bucket listing -
+// with atime being zero (never accessed).
+func (r *listRangeBase) iterateQuery(args *xreg.DeletePrefetchArgs, cb objCallback) error {
+	msg := args.QueryMsg
+	if msg.OuterSelect.Template != "" {
+		return fmt.Errorf("%s: query by template is not supported, use range instead", r)
+	}
+	filter, err := query.ObjFilterFromMsg(msg.Where.Filter)
+	if err != nil {
+		return err
+	}
+	smap := r.t.Sowner().Get()
+	return r.iteratePrefix(args, smap, msg.OuterSelect.Prefix, filter, cb)
+}
+
+func (r *listRangeBase) matchFilter(be *cmn.BucketEntry, filter cluster.ObjectFilter) (bool, error) {
+	lom := &cluster.LOM{T: r.t, ObjName: be.Name}
+	if err := lom.Init(r.Bck()); err != nil {
+		return false, err
+	}
+	if err := lom.Load(); err != nil {
+		if !cmn.IsErrObjNought(err) {
+			return false, err
+		}
+		lom.SetSize(be.Size)
+		lom.SetVersion(be.Version)
+	}
+	return filter(lom), nil
 }
 
 func (r *listRangeBase) iterateTemplate(args *xreg.DeletePrefetchArgs, smap *cluster.Smap, pt *cmn.ParsedTemplate, cb objCallback) error {
@@ -177,7 +211,8 @@ func (r *listRangeBase) iterateTemplate(args *xreg.DeletePrefetchArgs, smap *clu
 	return nil
 }
 
-func (r *listRangeBase) iteratePrefix(args *xreg.DeletePrefetchArgs, smap *cluster.Smap, prefix string, cb objCallback) error {
+func (r *listRangeBase) iteratePrefix(args *xreg.DeletePrefetchArgs, smap *cluster.Smap, prefix string,
+	filter cluster.ObjectFilter, cb objCallback) error {
 	var (
 		objList *cmn.BucketList
 		sid     = r.t.Snode().ID()
@@ -190,6 +225,9 @@ func (r *listRangeBase) iteratePrefix(args *xreg.DeletePrefetchArgs, smap *clust
 	}
 
 	msg := &cmn.SelectMsg{Prefix: prefix, Props: cmn.GetPropsStatus}
+	if filter != nil {
+		msg.AddProps(cmn.GetPropsSize, cmn.GetPropsVersion)
+	}
 	for !r.Aborted() {
 		if bck.IsAIS() {
 			walk := objwalk.NewWalk(args.Ctx, r.t, bck, msg)
@@ -216,6 +254,15 @@ func (r *listRangeBase) iteratePrefix(args *xreg.DeletePrefetchArgs, smap *clust
 					continue
 				}
 			}
+			if filter != nil {
+				match, err := r.matchFilter(be, filter)
+				if err != nil {
+					return err
+				}
+				if !match {
+					continue
+				}
+			}
 
 			if err := cb(args, be.Name); err != nil {
 				return err